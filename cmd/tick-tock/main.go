@@ -1,12 +1,15 @@
 package main
 
 import (
+	"io"
 	"math/rand"
 	"os"
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/afero"
+	"github.com/thewizardplusplus/tick-tock/internal/diagram"
 	"github.com/thewizardplusplus/tick-tock/internal/options"
 	"github.com/thewizardplusplus/tick-tock/interpreter"
 	"github.com/thewizardplusplus/tick-tock/runtime"
@@ -15,9 +18,28 @@ import (
 )
 
 func main() {
-	rand.Seed(time.Now().UnixNano())
+	sources := runtime.NewSourceGroup()
+	errorHandler := runtime.NewDefaultErrorHandler(os.Stderr, os.Exit).WithSources(sources)
+	// the file with the same name as a command is interpreted as a program
+	if len(os.Args) > 1 && !isExistingFile(os.Args[1]) {
+		var command func() error
+		switch os.Args[1] {
+		case options.TraceDiagramCommand:
+			command = writeTraceDiagram
+		case options.GraphCommand:
+			command = func() error { return writeGraph(sources) }
+		case options.REPLCommand:
+			command = func() error { return runREPL(sources, errorHandler) }
+		}
+		if command != nil {
+			if err := command(); err != nil {
+				errorHandler.HandleError(err)
+			}
+
+			return
+		}
+	}
 
-	errorHandler := runtime.NewDefaultErrorHandler(os.Stderr, os.Exit)
 	options, err := options.Parse(os.Args, options.Dependencies{
 		UsageWriter: os.Stdout,
 		ErrorWriter: os.Stderr,
@@ -27,16 +49,181 @@ func main() {
 		errorHandler.HandleError(err)
 	}
 
+	// random numbers of the round-robin scheduler are defined by its own seed
+	rand.Seed(time.Now().UnixNano())
+
+	recorder, scheduler, err := makeRecorder(options, errorHandler)
+	if err != nil {
+		errorHandler.HandleError(err)
+	}
+
+	tracer, err := makeTracer(options)
+	if err != nil {
+		errorHandler.HandleError(err)
+	}
+
 	ctx := context.NewDefaultContext()
 	context.SetValues(ctx, builtin.Values)
 
 	var waiter sync.WaitGroup
 	if err := interpreter.Interpret(ctx, options, interpreter.Dependencies{
-		Reader:  interpreter.ReaderDependencies{DefaultReader: os.Stdin, FileSystem: afero.NewOsFs()},
-		Runtime: runtime.Dependencies{WaitGroup: &waiter, ErrorHandler: errorHandler},
+		Reader: interpreter.ReaderDependencies{DefaultReader: os.Stdin, FileSystem: afero.NewOsFs()},
+		Runtime: runtime.Dependencies{
+			WaitGroup:    &waiter,
+			ErrorHandler: errorHandler,
+			Recorder:     recorder,
+			Tracer:       tracer,
+		},
+		Sources:   sources,
+		Scheduler: scheduler,
 	}); err != nil {
 		errorHandler.HandleError(err)
 	}
 
 	waiter.Wait()
 }
+
+func writeTraceDiagram() error {
+	options, err := options.ParseTraceDiagram(os.Args, options.Dependencies{
+		UsageWriter: os.Stdout,
+		ErrorWriter: os.Stderr,
+		Exiter:      os.Exit,
+	})
+	if err != nil {
+		return err
+	}
+
+	reader := io.Reader(os.Stdin)
+	if options.Filename != "" && options.Filename != "-" {
+		file, err := os.Open(options.Filename)
+		if err != nil {
+			return errors.Wrap(err, "unable to open the trace file")
+		}
+		defer file.Close() // nolint: errcheck
+
+		reader = file
+	}
+
+	events, err := runtime.LoadTraceEvents(reader)
+	if err != nil {
+		return errors.Wrap(err, "unable to load the trace")
+	}
+
+	return diagram.WriteSequenceDiagram(os.Stdout, events, options.Format)
+}
+
+func writeGraph(sources *runtime.SourceGroup) error {
+	options, err := options.ParseGraph(os.Args, options.Dependencies{
+		UsageWriter: os.Stdout,
+		ErrorWriter: os.Stderr,
+		Exiter:      os.Exit,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, builtin.Values)
+
+	stateGraphs, err := interpreter.BuildStateGraphs(
+		ctx,
+		interpreter.Options{Filename: options.Filename, InitialState: options.InitialState},
+		interpreter.Dependencies{
+			Reader:  interpreter.ReaderDependencies{DefaultReader: os.Stdin, FileSystem: afero.NewOsFs()},
+			Sources: sources,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return diagram.WriteStateDiagram(os.Stdout, stateGraphs, options.Format)
+}
+
+func runREPL(sources *runtime.SourceGroup, errorHandler runtime.ErrorHandler) error {
+	options, err := options.ParseREPL(os.Args, options.Dependencies{
+		UsageWriter: os.Stdout,
+		ErrorWriter: os.Stderr,
+		Exiter:      os.Exit,
+	})
+	if err != nil {
+		return err
+	}
+
+	rand.Seed(time.Now().UnixNano())
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, builtin.Values)
+
+	var waiter sync.WaitGroup
+	repl := interpreter.NewREPL(ctx, options, interpreter.Dependencies{
+		Reader: interpreter.ReaderDependencies{DefaultReader: os.Stdin, FileSystem: afero.NewOsFs()},
+		Runtime: runtime.Dependencies{
+			WaitGroup:    &waiter,
+			ErrorHandler: errorHandler,
+		},
+		Sources: sources,
+	})
+	if err := repl.Run(os.Stdin, os.Stdout); err != nil {
+		return err
+	}
+
+	waiter.Wait()
+	return nil
+}
+
+func makeTracer(options interpreter.Options) (runtime.Tracer, error) {
+	if options.Trace == "" {
+		return nil, nil
+	}
+
+	// the file isn't closed, because the program may be terminated at any moment
+	file, err := os.Create(options.Trace)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the trace file")
+	}
+
+	return runtime.NewTraceWriter(file, options.TraceFormat), nil
+}
+
+// the scheduler is returned only for replaying
+func makeRecorder(
+	options interpreter.Options,
+	errorHandler runtime.ErrorHandler,
+) (runtime.Recorder, runtime.Scheduler, error) {
+	switch {
+	case options.Record != "":
+		// the file isn't closed, because the program may be terminated at any moment
+		file, err := os.Create(options.Record)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to create the trace file")
+		}
+
+		return runtime.NewTraceRecorder(file), nil, nil
+	case options.Replay != "":
+		file, err := os.Open(options.Replay)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to open the trace file")
+		}
+		defer file.Close() // nolint: errcheck
+
+		trace, err := runtime.LoadTrace(file)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to load the trace")
+		}
+
+		scheduler, err := runtime.NewReplayScheduler(trace, errorHandler)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to replay the trace")
+		}
+
+		return scheduler, scheduler, nil
+	default:
+		return nil, nil, nil
+	}
+}
+
+func isExistingFile(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,45 @@
+package diagram
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Format ...
+type Format int
+
+// ...
+const (
+	PlantUMLFormat Format = iota
+	MermaidFormat
+	DOTFormat
+)
+
+// nolint: gochecknoglobals
+var (
+	formatNames = []string{"plantuml", "mermaid", "dot"}
+)
+
+// FormatNames ...
+func FormatNames() []string {
+	return append([]string(nil), formatNames...)
+}
+
+// ParseFormat ...
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range formatNames {
+		if formatName == name {
+			return Format(format), nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown diagram format %s", name)
+}
+
+// String ...
+func (format Format) String() string {
+	if format < 0 || int(format) >= len(formatNames) {
+		return "unknown"
+	}
+
+	return formatNames[format]
+}
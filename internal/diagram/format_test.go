@@ -0,0 +1,80 @@
+package diagram
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatNames(test *testing.T) {
+	got := FormatNames()
+	got[0] = "modified"
+
+	assert.Equal(test, []string{"plantuml", "mermaid", "dot"}, FormatNames())
+}
+
+func TestParseFormat(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		args    string
+		want    Format
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with the PlantUML format",
+			args:    "plantuml",
+			want:    PlantUMLFormat,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the Mermaid format",
+			args:    "mermaid",
+			want:    MermaidFormat,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the DOT format",
+			args:    "dot",
+			want:    DOTFormat,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    "unknown",
+			want:    0,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := ParseFormat(testData.args)
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestFormat_String(test *testing.T) {
+	for _, testData := range []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "known format",
+			format: MermaidFormat,
+			want:   "mermaid",
+		},
+		{
+			name:   "unknown format",
+			format: Format(23),
+			want:   "unknown",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.format.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+const interpreterParticipant = "interpreter"
+
+// WriteSequenceDiagram ...
+//
+// It renders the sequence diagram by the events of the trace written
+// by the tracer. Each sending of a message is rendered as an arrow from its sender
+// to its receiver (messages sent not by actors are sent from the interpreter),
+// each start of an actor by another actor is rendered as a dashed arrow,
+// and state changes and errors are rendered as notes over the actors.
+//
+// The DOT format isn't supported.
+func WriteSequenceDiagram(writer io.Writer, events []runtime.TraceEvent, format Format) error {
+	if format == DOTFormat {
+		return errors.Errorf("the %s format isn't supported for sequence diagrams", format)
+	}
+
+	diagram := sequenceDiagram{format: format}
+	diagram.writeHeader()
+	diagram.writeParticipants(events)
+	for _, event := range events {
+		switch event.Kind {
+		case runtime.StartEventKind:
+			if event.Parent != 0 {
+				diagram.writeArrow(actorParticipant(event.Parent), actorParticipant(event.Actor), "start", true)
+			}
+		case runtime.SendEventKind:
+			sender := interpreterParticipant
+			if event.Sender != 0 {
+				sender = actorParticipant(event.Sender)
+			}
+
+			label := event.Message + "(" + formatArguments(event.Arguments) + ")"
+			diagram.writeArrow(sender, actorParticipant(event.Actor), label, false)
+		case runtime.StateChangeEventKind:
+			text := event.PreviousState + " -> " + event.State
+			diagram.writeNote(actorParticipant(event.Actor), text)
+		case runtime.ErrorEventKind:
+			diagram.writeNote(actorParticipant(event.Actor), "error: "+event.Error)
+		}
+	}
+	diagram.writeFooter()
+
+	if _, err := writer.Write(diagram.buffer.Bytes()); err != nil {
+		return errors.Wrap(err, "unable to write the diagram")
+	}
+
+	return nil
+}
+
+type sequenceDiagram struct {
+	format Format
+	buffer bytes.Buffer
+}
+
+func (diagram *sequenceDiagram) writeHeader() {
+	switch diagram.format {
+	case PlantUMLFormat:
+		diagram.buffer.WriteString("@startuml\n")
+	case MermaidFormat:
+		diagram.buffer.WriteString("sequenceDiagram\n")
+	}
+}
+
+// participants are declared in order of their first appearance
+func (diagram *sequenceDiagram) writeParticipants(events []runtime.TraceEvent) {
+	for _, event := range events {
+		if event.Kind == runtime.SendEventKind && event.Sender == 0 {
+			diagram.writeParticipant(interpreterParticipant, interpreterParticipant)
+			break
+		}
+	}
+
+	declaredActors := make(map[int]struct{})
+	for _, event := range events {
+		if _, ok := declaredActors[event.Actor]; ok {
+			continue
+		}
+
+		declaredActors[event.Actor] = struct{}{}
+		diagram.writeParticipant(
+			actorParticipant(event.Actor),
+			fmt.Sprintf("%s#%d", event.Class, event.Actor),
+		)
+	}
+}
+
+func (diagram *sequenceDiagram) writeParticipant(name string, label string) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		fmt.Fprintf(&diagram.buffer, "participant %q as %s\n", label, name)
+	case MermaidFormat:
+		fmt.Fprintf(&diagram.buffer, "  participant %s as %s\n", name, label)
+	}
+}
+
+func (diagram *sequenceDiagram) writeArrow(from string, to string, label string, dashed bool) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		arrow := "->"
+		if dashed {
+			arrow = "-->"
+		}
+
+		fmt.Fprintf(&diagram.buffer, "%s %s %s : %s\n", from, arrow, to, escapePlantUML(label))
+	case MermaidFormat:
+		arrow := "->>"
+		if dashed {
+			arrow = "-->>"
+		}
+
+		fmt.Fprintf(&diagram.buffer, "  %s%s%s: %s\n", from, arrow, to, escapeMermaid(label))
+	}
+}
+
+func (diagram *sequenceDiagram) writeNote(participant string, text string) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		fmt.Fprintf(&diagram.buffer, "note over %s : %s\n", participant, escapePlantUML(text))
+	case MermaidFormat:
+		fmt.Fprintf(&diagram.buffer, "  Note over %s: %s\n", participant, escapeMermaid(text))
+	}
+}
+
+func (diagram *sequenceDiagram) writeFooter() {
+	if diagram.format == PlantUMLFormat {
+		diagram.buffer.WriteString("@enduml\n")
+	}
+}
+
+func actorParticipant(id int) string {
+	return fmt.Sprintf("actor%d", id)
+}
+
+// arguments are marshalled to a JSON list
+func formatArguments(arguments []byte) string {
+	text := strings.TrimPrefix(string(arguments), "[")
+	return strings.TrimSuffix(text, "]")
+}
+
+// the double underscores mean underlining in PlantUML
+func escapePlantUML(text string) string {
+	text = strings.Replace(text, "__", "~__", -1)
+	return strings.Replace(text, "\n", `\n`, -1)
+}
+
+// the number signs and semicolons are special in Mermaid, so they are replaced
+// by entity codes
+func escapeMermaid(text string) string {
+	return strings.NewReplacer("#", "#35;", ";", "#59;", "\n", "<br/>").Replace(text)
+}
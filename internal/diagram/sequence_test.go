@@ -0,0 +1,94 @@
+package diagram
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestWriteSequenceDiagram(test *testing.T) {
+	events := []runtime.TraceEvent{
+		{Kind: runtime.StartEventKind, Actor: 1, Class: "Main", State: "__initialization__"},
+		{Kind: runtime.SendEventKind, Actor: 1, Class: "Main", Message: "__initialize__"},
+		{
+			Kind:    runtime.DequeueEventKind,
+			Actor:   1,
+			Class:   "Main",
+			Message: "__initialize__",
+			State:   "__initialization__",
+		},
+		{Kind: runtime.StartEventKind, Actor: 2, Class: "Counter", Parent: 1, State: "initial"},
+		{
+			Kind:        runtime.SendEventKind,
+			Actor:       2,
+			Class:       "Counter",
+			Sender:      1,
+			SenderClass: "Main",
+			Message:     "inc",
+			Arguments:   json.RawMessage(`[23,"hi"]`),
+		},
+		{
+			Kind:          runtime.StateChangeEventKind,
+			Actor:         2,
+			Class:         "Counter",
+			State:         "done",
+			PreviousState: "initial",
+		},
+		{Kind: runtime.ErrorEventKind, Actor: 2, Class: "Counter", Error: "command #0; test"},
+	}
+
+	for _, testData := range []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "PlantUML",
+			format: PlantUMLFormat,
+			want: `@startuml
+participant "interpreter" as interpreter
+participant "Main#1" as actor1
+participant "Counter#2" as actor2
+interpreter -> actor1 : ~__initialize~__()
+actor1 --> actor2 : start
+actor1 -> actor2 : inc(23,"hi")
+note over actor2 : initial -> done
+note over actor2 : error: command #0; test
+@enduml
+`,
+		},
+		{
+			name:   "Mermaid",
+			format: MermaidFormat,
+			want: `sequenceDiagram
+  participant interpreter as interpreter
+  participant actor1 as Main#1
+  participant actor2 as Counter#2
+  interpreter->>actor1: __initialize__()
+  actor1-->>actor2: start
+  actor1->>actor2: inc(23,"hi")
+  Note over actor2: initial -> done
+  Note over actor2: error: command #35;0#59; test
+`,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var buffer bytes.Buffer
+			err := WriteSequenceDiagram(&buffer, events, testData.format)
+
+			assert.Equal(test, testData.want, buffer.String())
+			assert.NoError(test, err)
+		})
+	}
+}
+
+func TestWriteSequenceDiagram_withDOTFormat(test *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteSequenceDiagram(&buffer, nil, DOTFormat)
+
+	assert.Empty(test, buffer.String())
+	assert.Error(test, err)
+}
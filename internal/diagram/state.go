@@ -0,0 +1,129 @@
+package diagram
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/translator"
+)
+
+// WriteStateDiagram ...
+//
+// It renders the state graphs of all actor classes in a single diagram,
+// where each class is a separate group (a composite state or a cluster)
+// containing its states as nodes and the transitions between them as edges
+// labelled by the messages. The initial state is marked by an edge from
+// the start node.
+func WriteStateDiagram(writer io.Writer, stateGraphs []translator.StateGraph, format Format) error {
+	diagram := stateDiagram{format: format}
+	diagram.writeHeader()
+	for classIndex, stateGraph := range stateGraphs {
+		class := "class" + strconv.Itoa(classIndex+1)
+		stateIDs := make(map[string]string)
+		for stateIndex, state := range stateGraph.States {
+			stateIDs[state] = class + "_state" + strconv.Itoa(stateIndex+1)
+		}
+
+		diagram.writeGroupStart(class, stateGraph.Class)
+		for _, state := range stateGraph.States {
+			diagram.writeState(stateIDs[state], state)
+		}
+		if initialStateID, ok := stateIDs[stateGraph.InitialState]; ok {
+			diagram.writeInitialTransition(class, initialStateID)
+		}
+		for _, transition := range stateGraph.Transitions {
+			diagram.writeTransition(stateIDs[transition.From], stateIDs[transition.To], transition.Message)
+		}
+		diagram.writeGroupEnd()
+	}
+	diagram.writeFooter()
+
+	if _, err := writer.Write(diagram.buffer.Bytes()); err != nil {
+		return errors.Wrap(err, "unable to write the diagram")
+	}
+
+	return nil
+}
+
+type stateDiagram struct {
+	format Format
+	buffer bytes.Buffer
+}
+
+func (diagram *stateDiagram) writeHeader() {
+	switch diagram.format {
+	case PlantUMLFormat:
+		diagram.buffer.WriteString("@startuml\n")
+	case MermaidFormat:
+		diagram.buffer.WriteString("stateDiagram-v2\n")
+	case DOTFormat:
+		diagram.buffer.WriteString("digraph {\n")
+	}
+}
+
+func (diagram *stateDiagram) writeGroupStart(id string, label string) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		fmt.Fprintf(&diagram.buffer, "state %q as %s {\n", escapePlantUML(label), id)
+	case MermaidFormat:
+		// Mermaid doesn't support descriptions in declarations of composite states
+		fmt.Fprintf(&diagram.buffer, "  state %q as %s\n  state %s {\n", label, id, id)
+	case DOTFormat:
+		fmt.Fprintf(&diagram.buffer, "  subgraph cluster_%s {\n    label=%q;\n", id, label)
+	}
+}
+
+func (diagram *stateDiagram) writeState(id string, label string) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		fmt.Fprintf(&diagram.buffer, "  state %q as %s\n", escapePlantUML(label), id)
+	case MermaidFormat:
+		fmt.Fprintf(&diagram.buffer, "    state %q as %s\n", label, id)
+	case DOTFormat:
+		fmt.Fprintf(&diagram.buffer, "    %s [label=%q];\n", id, label)
+	}
+}
+
+func (diagram *stateDiagram) writeInitialTransition(group string, to string) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		fmt.Fprintf(&diagram.buffer, "  [*] --> %s\n", to)
+	case MermaidFormat:
+		fmt.Fprintf(&diagram.buffer, "    [*] --> %s\n", to)
+	case DOTFormat:
+		fmt.Fprintf(&diagram.buffer, "    %s_start [shape=point];\n", group)
+		fmt.Fprintf(&diagram.buffer, "    %s_start -> %s;\n", group, to)
+	}
+}
+
+func (diagram *stateDiagram) writeTransition(from string, to string, label string) {
+	switch diagram.format {
+	case PlantUMLFormat:
+		fmt.Fprintf(&diagram.buffer, "  %s --> %s : %s\n", from, to, escapePlantUML(label))
+	case MermaidFormat:
+		fmt.Fprintf(&diagram.buffer, "    %s --> %s: %s\n", from, to, escapeMermaid(label))
+	case DOTFormat:
+		fmt.Fprintf(&diagram.buffer, "    %s -> %s [label=%q];\n", from, to, label)
+	}
+}
+
+func (diagram *stateDiagram) writeGroupEnd() {
+	switch diagram.format {
+	case PlantUMLFormat:
+		diagram.buffer.WriteString("}\n")
+	case MermaidFormat, DOTFormat:
+		diagram.buffer.WriteString("  }\n")
+	}
+}
+
+func (diagram *stateDiagram) writeFooter() {
+	switch diagram.format {
+	case PlantUMLFormat:
+		diagram.buffer.WriteString("@enduml\n")
+	case DOTFormat:
+		diagram.buffer.WriteString("}\n")
+	}
+}
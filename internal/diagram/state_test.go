@@ -0,0 +1,98 @@
+package diagram
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/tick-tock/translator"
+)
+
+func TestWriteStateDiagram(test *testing.T) {
+	stateGraphs := []translator.StateGraph{
+		{
+			Class:        "Counter",
+			InitialState: "__initialization__",
+			States:       []string{"__initialization__", "done"},
+			Transitions: []translator.StateTransition{
+				{From: "__initialization__", To: "done", Message: "inc"},
+				{From: "done", To: "done", Message: "enter hook"},
+			},
+		},
+		{
+			Class:        "Main",
+			InitialState: "__initialization__",
+			States:       []string{"state"},
+		},
+	}
+
+	for _, testData := range []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{
+			name:   "PlantUML",
+			format: PlantUMLFormat,
+			want: `@startuml
+state "Counter" as class1 {
+  state "~__initialization~__" as class1_state1
+  state "done" as class1_state2
+  [*] --> class1_state1
+  class1_state1 --> class1_state2 : inc
+  class1_state2 --> class1_state2 : enter hook
+}
+state "Main" as class2 {
+  state "state" as class2_state1
+}
+@enduml
+`,
+		},
+		{
+			name:   "Mermaid",
+			format: MermaidFormat,
+			want: `stateDiagram-v2
+  state "Counter" as class1
+  state class1 {
+    state "__initialization__" as class1_state1
+    state "done" as class1_state2
+    [*] --> class1_state1
+    class1_state1 --> class1_state2: inc
+    class1_state2 --> class1_state2: enter hook
+  }
+  state "Main" as class2
+  state class2 {
+    state "state" as class2_state1
+  }
+`,
+		},
+		{
+			name:   "DOT",
+			format: DOTFormat,
+			want: `digraph {
+  subgraph cluster_class1 {
+    label="Counter";
+    class1_state1 [label="__initialization__"];
+    class1_state2 [label="done"];
+    class1_start [shape=point];
+    class1_start -> class1_state1;
+    class1_state1 -> class1_state2 [label="inc"];
+    class1_state2 -> class1_state2 [label="enter hook"];
+  }
+  subgraph cluster_class2 {
+    label="Main";
+    class2_state1 [label="state"];
+  }
+}
+`,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var buffer bytes.Buffer
+			err := WriteStateDiagram(&buffer, stateGraphs, testData.format)
+
+			assert.Equal(test, testData.want, buffer.String())
+			assert.NoError(test, err)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package options
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/internal/diagram"
+)
+
+// ...
+const (
+	GraphCommand = "graph"
+
+	DefaultGraphFormat = "dot"
+)
+
+// GraphOptions ...
+type GraphOptions struct {
+	Filename     string
+	InitialState string
+	Format       diagram.Format
+}
+
+// ParseGraph ...
+//
+// It expects the name of the command in the second argument.
+func ParseGraph(args []string, dependencies Dependencies) (GraphOptions, error) {
+	app := newApp(
+		filepath.Base(args[0])+" "+GraphCommand,
+		"Render the state diagram of actor classes of the program.",
+		dependencies,
+	)
+
+	var options GraphOptions
+	app.Flag("state", "Initial state.").
+		Short('s').
+		Default(DefaultInitialState).
+		StringVar(&options.InitialState)
+	var format string
+	app.Flag("format", "Format of the diagram.").
+		Short('f').
+		Default(DefaultGraphFormat).
+		EnumVar(&format, diagram.FormatNames()...)
+	app.Arg("filename", `Source file name. Empty or "-" means stdin.`).StringVar(&options.Filename)
+
+	terminated, err := parseArgs(app, args[2:], dependencies)
+	if err != nil {
+		return GraphOptions{}, err
+	}
+	if terminated {
+		return GraphOptions{}, nil
+	}
+
+	options.Format, err = diagram.ParseFormat(format)
+	if err != nil {
+		return GraphOptions{}, errors.Wrap(err, "unable to parse the diagram format")
+	}
+
+	return options, nil
+}
@@ -0,0 +1,107 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/internal/diagram"
+)
+
+func TestParseGraph(test *testing.T) {
+	type args struct {
+		args []string
+	}
+
+	const executablePath = "path/to/an/executable/file"
+	defaultOptions := GraphOptions{InitialState: DefaultInitialState, Format: diagram.DOTFormat}
+	for _, testData := range []struct {
+		name                   string
+		args                   args
+		initializeDependencies func(usage *[]byte, writer *MockWriter, exiter *MockExiterInterface)
+		wantUsage              []byte
+		want                   GraphOptions
+		wantErr                assert.ErrorAssertionFunc
+	}{
+		{
+			name:                   "success without flags and arguments",
+			args:                   args{[]string{executablePath, GraphCommand}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   defaultOptions,
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --version flag",
+			args:                   args{[]string{executablePath, GraphCommand, "--version"}},
+			initializeDependencies: initializeForUsage,
+			wantUsage:              []byte(Version + "\n"),
+			want:                   GraphOptions{},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the -h flag",
+			args:                   args{[]string{executablePath, GraphCommand, "-h"}},
+			initializeDependencies: initializeForHelp,
+			want:                   GraphOptions{},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --state flag",
+			args:                   args{[]string{executablePath, GraphCommand, "--state", "test"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   GraphOptions{InitialState: "test", Format: diagram.DOTFormat},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --format flag",
+			args:                   args{[]string{executablePath, GraphCommand, "--format", "mermaid"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: GraphOptions{
+				InitialState: DefaultInitialState,
+				Format:       diagram.MermaidFormat,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "success with the filename argument",
+			args:                   args{[]string{executablePath, GraphCommand, "main.tt"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: GraphOptions{
+				Filename:     "main.tt",
+				InitialState: DefaultInitialState,
+				Format:       diagram.DOTFormat,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "error with the --format flag (unknown format)",
+			args:                   args{[]string{executablePath, GraphCommand, "--format", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   GraphOptions{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with an extra argument",
+			args:                   args{[]string{executablePath, GraphCommand, "one", "two"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   GraphOptions{},
+			wantErr:                assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var usage []byte
+			usageWriter := new(MockWriter)
+			exiter := new(MockExiterInterface)
+			testData.initializeDependencies(&usage, usageWriter, exiter)
+
+			errorWriter := new(MockWriter)
+			dependencies := Dependencies{usageWriter, errorWriter, exiter.Exit}
+			got, err := ParseGraph(testData.args.args, dependencies)
+
+			mock.AssertExpectationsForObjects(test, usageWriter, errorWriter, exiter)
+			assert.Equal(test, testData.wantUsage, usage)
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/pkg/errors"
 	"github.com/thewizardplusplus/tick-tock/interpreter"
 	"github.com/thewizardplusplus/tick-tock/runtime"
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
@@ -15,8 +16,14 @@ const (
 	Version = "v2.2.4"
 
 	DefaultInboxSize      = 10
+	DefaultInboxPolicy    = "unbounded"
 	DefaultInitialState   = "__initialization__"
 	DefaultInitialMessage = "__initialize__"
+	DefaultFailurePolicy  = "crash-process"
+	DefaultDeadLetterMode = "ignore"
+	DefaultScheduler      = "goroutine"
+	DefaultSeed           = 0
+	DefaultTraceFormat    = "jsonl"
 )
 
 // Dependencies ...
@@ -28,20 +35,22 @@ type Dependencies struct {
 
 // Parse ...
 func Parse(args []string, dependencies Dependencies) (interpreter.Options, error) {
-	app := kingpin.New(filepath.Base(args[0]), "")
-	app.UsageWriter(dependencies.UsageWriter)
-	app.ErrorWriter(dependencies.ErrorWriter)
-	app.Terminate(dependencies.Exiter)
-
-	app.Version(Version)
-	app.VersionFlag.Short('v')
-	app.HelpFlag.Short('h')
+	app := newApp(filepath.Base(args[0]), "", dependencies)
 
 	var options interpreter.Options
 	app.Flag("inbox", "Inbox buffer size.").
 		Short('i').
 		Default(strconv.Itoa(DefaultInboxSize)).
 		IntVar(&options.InboxSize)
+	var inboxPolicy string
+	app.Flag("overflow", "Default inbox overflow policy of actors.").
+		Short('o').
+		Default(DefaultInboxPolicy).
+		EnumVar(&inboxPolicy, runtime.InboxPolicyNames()...)
+	classInboxPolicies :=
+		app.Flag("class-overflow", "Inbox overflow policy of the class (e.g. Class=block).").
+			Short('O').
+			StringMap()
 	app.Flag("state", "Initial state.").
 		Short('s').
 		Default(DefaultInitialState).
@@ -50,11 +59,195 @@ func Parse(args []string, dependencies Dependencies) (interpreter.Options, error
 		Short('m').
 		Default(DefaultInitialMessage).
 		StringVar(&options.InitialMessage)
+	var failurePolicy string
+	app.Flag("failure", "Default failure policy of actors.").
+		Short('f').
+		Default(DefaultFailurePolicy).
+		EnumVar(&failurePolicy, runtime.FailurePolicyNames()...)
+	classFailurePolicies :=
+		app.Flag("class-failure", "Failure policy of the class (e.g. Class=stop-actor).").
+			Short('c').
+			StringMap()
+	var deadLetterMode string
+	app.Flag("dead-letters", "Mode of reporting of unhandled messages.").
+		Short('d').
+		Default(DefaultDeadLetterMode).
+		EnumVar(&deadLetterMode, runtime.DeadLetterModeNames()...)
+	var scheduler string
+	app.Flag("scheduler", "Scheduler of actors.").
+		Default(DefaultScheduler).
+		EnumVar(&scheduler, runtime.SchedulerKindNames()...)
+	app.Flag("seed", "Seed of the round-robin scheduler and its random numbers.").
+		Default(strconv.Itoa(DefaultSeed)).
+		Int64Var(&options.Seed)
+	app.Flag("record", "Record the message delivery order to the trace file.").
+		PlaceHolder("TRACE").
+		StringVar(&options.Record)
+	app.Flag("replay", "Replay the message delivery order from the trace file.").
+		PlaceHolder("TRACE").
+		StringVar(&options.Replay)
+	app.Flag("trace", "Write events of actors to the trace file.").
+		PlaceHolder("TRACE").
+		StringVar(&options.Trace)
+	var traceFormat string
+	app.Flag("trace-format", "Format of the trace file.").
+		Default(DefaultTraceFormat).
+		EnumVar(&traceFormat, runtime.TraceFormatNames()...)
 	app.Arg("filename", `Source file name. Empty or "-" means stdin.`).StringVar(&options.Filename)
 
-	if _, err := app.Parse(args[1:]); err != nil {
+	terminated, err := parseArgs(app, args[1:], dependencies)
+	if err != nil {
+		return interpreter.Options{}, err
+	}
+	if terminated {
+		return options, nil
+	}
+
+	if options.Record != "" && options.Replay != "" {
+		return interpreter.Options{}, errors.New("unable to both record and replay the trace")
+	}
+
+	options.InboxPolicies, err = parseInboxPolicies(inboxPolicy, *classInboxPolicies)
+	if err != nil {
+		return interpreter.Options{}, err
+	}
+
+	options.FailurePolicies, err = parseFailurePolicies(failurePolicy, *classFailurePolicies)
+	if err != nil {
 		return interpreter.Options{}, err
 	}
 
+	options.DeadLetters, err = runtime.ParseDeadLetterMode(deadLetterMode)
+	if err != nil {
+		return interpreter.Options{}, errors.Wrap(err, "unable to parse the dead letter mode")
+	}
+
+	options.Scheduler, err = runtime.ParseSchedulerKind(scheduler)
+	if err != nil {
+		return interpreter.Options{}, errors.Wrap(err, "unable to parse the scheduler kind")
+	}
+
+	options.TraceFormat, err = runtime.ParseTraceFormat(traceFormat)
+	if err != nil {
+		return interpreter.Options{}, errors.Wrap(err, "unable to parse the trace format")
+	}
+
+	// actors sharing the goroutine can't block their senders
+	if options.Scheduler == runtime.RoundRobinSchedulerKind && hasBlockPolicy(options.InboxPolicies) {
+		return interpreter.Options{}, errors.New(
+			"the block inbox policy isn't supported by the round-robin scheduler",
+		)
+	}
+	if options.Replay != "" && hasBlockPolicy(options.InboxPolicies) {
+		return interpreter.Options{}, errors.New("the block inbox policy isn't supported by replaying")
+	}
+
 	return options, nil
 }
+
+func newApp(name string, help string, dependencies Dependencies) *kingpin.Application {
+	app := kingpin.New(name, help)
+	app.UsageWriter(dependencies.UsageWriter)
+	app.ErrorWriter(dependencies.ErrorWriter)
+	app.Terminate(dependencies.Exiter)
+
+	app.Version(Version)
+	app.VersionFlag.Short('v')
+	app.HelpFlag.Short('h')
+
+	return app
+}
+
+// the exiter may not exit the process (e.g. in tests), so the application
+// terminated by the help or version flag is reported explicitly to skip
+// the validation of the options
+func parseArgs(
+	app *kingpin.Application,
+	args []string,
+	dependencies Dependencies,
+) (terminated bool, err error) {
+	app.Terminate(func(code int) {
+		terminated = true
+		dependencies.Exiter(code)
+	})
+
+	if _, err := app.Parse(args); err != nil {
+		return false, err
+	}
+
+	return terminated, nil
+}
+
+func parseFailurePolicies(
+	defaultPolicy string,
+	classPolicies map[string]string,
+) (runtime.FailurePolicyGroup, error) {
+	var policies runtime.FailurePolicyGroup
+	var err error
+	policies.Default, err = runtime.ParseFailurePolicy(defaultPolicy)
+	if err != nil {
+		return runtime.FailurePolicyGroup{}, errors.Wrap(err, "unable to parse the default policy")
+	}
+
+	for className, classPolicy := range classPolicies {
+		policy, err := runtime.ParseFailurePolicy(classPolicy)
+		if err != nil {
+			return runtime.FailurePolicyGroup{}, errors.Wrapf(
+				err,
+				"unable to parse the policy for the class %s",
+				className,
+			)
+		}
+
+		if policies.ByClass == nil {
+			policies.ByClass = make(map[string]runtime.FailurePolicy)
+		}
+		policies.ByClass[className] = policy
+	}
+
+	return policies, nil
+}
+
+func parseInboxPolicies(
+	defaultPolicy string,
+	classPolicies map[string]string,
+) (runtime.InboxPolicyGroup, error) {
+	var policies runtime.InboxPolicyGroup
+	var err error
+	policies.Default, err = runtime.ParseInboxPolicy(defaultPolicy)
+	if err != nil {
+		return runtime.InboxPolicyGroup{}, errors.Wrap(err, "unable to parse the default inbox policy")
+	}
+
+	for className, classPolicy := range classPolicies {
+		policy, err := runtime.ParseInboxPolicy(classPolicy)
+		if err != nil {
+			return runtime.InboxPolicyGroup{}, errors.Wrapf(
+				err,
+				"unable to parse the inbox policy for the class %s",
+				className,
+			)
+		}
+
+		if policies.ByClass == nil {
+			policies.ByClass = make(map[string]runtime.InboxPolicy)
+		}
+		policies.ByClass[className] = policy
+	}
+
+	return policies, nil
+}
+
+func hasBlockPolicy(policies runtime.InboxPolicyGroup) bool {
+	if policies.Default == runtime.BlockInboxPolicy {
+		return true
+	}
+
+	for _, policy := range policies.ByClass {
+		if policy == runtime.BlockInboxPolicy {
+			return true
+		}
+	}
+
+	return false
+}
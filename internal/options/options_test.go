@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thewizardplusplus/tick-tock/interpreter"
+	"github.com/thewizardplusplus/tick-tock/runtime"
 )
 
 func TestParse(test *testing.T) {
@@ -24,10 +25,31 @@ Flags:
                   --help-man).
   -v, --version   Show application version.
   -i, --inbox=10  Inbox buffer size.
+  -o, --overflow=unbounded  ` + `
+                  Default inbox overflow policy of actors.
+  -O, --class-overflow=CLASS-OVERFLOW ...  ` + `
+                  Inbox overflow policy of the class (e.g. Class=block).
   -s, --state="__initialization__"  ` + `
                   Initial state.
   -m, --message="__initialize__"  ` + `
                   Initial message.
+  -f, --failure=crash-process  ` + `
+                  Default failure policy of actors.
+  -c, --class-failure=CLASS-FAILURE ...  ` + `
+                  Failure policy of the class (e.g. Class=stop-actor).
+  -d, --dead-letters=ignore  ` + `
+                  Mode of reporting of unhandled messages.
+      --scheduler=goroutine  ` + `
+                  Scheduler of actors.
+      --seed=0    Seed of the round-robin scheduler and its random numbers.
+      --record=TRACE  ` + `
+                  Record the message delivery order to the trace file.
+      --replay=TRACE  ` + `
+                  Replay the message delivery order from the trace file.
+      --trace=TRACE  ` + `
+                  Write events of actors to the trace file.
+      --trace-format=jsonl  ` + `
+                  Format of the trace file.
 
 Args:
   [<filename>]  Source file name. Empty or "-" means stdin.
@@ -99,6 +121,59 @@ Args:
 			want:                   setOption(defaultOptions, "InboxSize", 1000),
 			wantErr:                assert.NoError,
 		},
+		{
+			name:                   "success with the -o flag",
+			args:                   args{[]string{executablePath, "-o", "block"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"InboxPolicies.Default",
+				runtime.BlockInboxPolicy,
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "success with the --overflow flag",
+			args:                   args{[]string{executablePath, "--overflow", "drop-newest"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"InboxPolicies.Default",
+				runtime.DropNewestInboxPolicy,
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "success with the -O flag",
+			args:                   args{[]string{executablePath, "-O", "Test=drop-oldest"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"InboxPolicies.ByClass",
+				map[string]runtime.InboxPolicy{"Test": runtime.DropOldestInboxPolicy},
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the --class-overflow flag",
+			args: args{[]string{
+				executablePath,
+				"--class-overflow",
+				"One=block",
+				"--class-overflow",
+				"Two=fail",
+			}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"InboxPolicies.ByClass",
+				map[string]runtime.InboxPolicy{
+					"One": runtime.BlockInboxPolicy,
+					"Two": runtime.FailInboxPolicy,
+				},
+			),
+			wantErr: assert.NoError,
+		},
 		{
 			name:                   "success with the -s flag",
 			args:                   args{[]string{executablePath, "-s", "test"}},
@@ -127,6 +202,115 @@ Args:
 			want:                   setOption(defaultOptions, "InitialMessage", "test"),
 			wantErr:                assert.NoError,
 		},
+		{
+			name:                   "success with the -f flag",
+			args:                   args{[]string{executablePath, "-f", "stop-actor"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"FailurePolicies.Default",
+				runtime.StopActorPolicy,
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "success with the --failure flag",
+			args:                   args{[]string{executablePath, "--failure", "stop-actor"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"FailurePolicies.Default",
+				runtime.StopActorPolicy,
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "success with the -c flag",
+			args:                   args{[]string{executablePath, "-c", "Test=restart-actor"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"FailurePolicies.ByClass",
+				map[string]runtime.FailurePolicy{"Test": runtime.RestartActorPolicy},
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the --class-failure flag",
+			args: args{[]string{
+				executablePath,
+				"--class-failure",
+				"One=restart-actor",
+				"--class-failure",
+				"Two=ignore-and-log",
+			}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: setOption(
+				defaultOptions,
+				"FailurePolicies.ByClass",
+				map[string]runtime.FailurePolicy{
+					"One": runtime.RestartActorPolicy,
+					"Two": runtime.IgnoreAndLogPolicy,
+				},
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "success with the -d flag",
+			args:                   args{[]string{executablePath, "-d", "warn"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "DeadLetters", runtime.WarnOnDeadLetters),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --dead-letters flag",
+			args:                   args{[]string{executablePath, "--dead-letters", "actor"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "DeadLetters", runtime.ForwardDeadLetters),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --scheduler flag",
+			args:                   args{[]string{executablePath, "--scheduler", "round-robin"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "Scheduler", runtime.RoundRobinSchedulerKind),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --seed flag",
+			args:                   args{[]string{executablePath, "--seed", "23"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "Seed", int64(23)),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --record flag",
+			args:                   args{[]string{executablePath, "--record", "trace.jsonl"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "Record", "trace.jsonl"),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --replay flag",
+			args:                   args{[]string{executablePath, "--replay", "trace.jsonl"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "Replay", "trace.jsonl"),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --trace flag",
+			args:                   args{[]string{executablePath, "--trace", "events.jsonl"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "Trace", "events.jsonl"),
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --trace-format flag",
+			args:                   args{[]string{executablePath, "--trace-format", "otel"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   setOption(defaultOptions, "TraceFormat", runtime.OpenTelemetryTraceFormat),
+			wantErr:                assert.NoError,
+		},
 		{
 			name:                   "success with the filename argument",
 			args:                   args{[]string{executablePath, "test"}},
@@ -155,6 +339,20 @@ Args:
 			want:                   interpreter.Options{},
 			wantErr:                assert.Error,
 		},
+		{
+			name:                   "error with the --overflow flag (unknown policy)",
+			args:                   args{[]string{executablePath, "--overflow", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with the --class-overflow flag (unknown policy)",
+			args:                   args{[]string{executablePath, "--class-overflow", "Test=unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
 		{
 			name:                   "error with the --state flag (missed argument)",
 			args:                   args{[]string{executablePath, "--state"}},
@@ -169,6 +367,84 @@ Args:
 			want:                   interpreter.Options{},
 			wantErr:                assert.Error,
 		},
+		{
+			name:                   "error with the --failure flag (unknown policy)",
+			args:                   args{[]string{executablePath, "--failure", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with the --class-failure flag (unknown policy)",
+			args:                   args{[]string{executablePath, "--class-failure", "Test=unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with the --dead-letters flag (unknown mode)",
+			args:                   args{[]string{executablePath, "--dead-letters", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with the --scheduler flag (unknown kind)",
+			args:                   args{[]string{executablePath, "--scheduler", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name: "error with the --scheduler and --overflow flags (block policy)",
+			args: args{
+				[]string{executablePath, "--scheduler", "round-robin", "--overflow", "block"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name: "error with the --scheduler and --class-overflow flags (block policy)",
+			args: args{
+				[]string{executablePath, "--scheduler", "round-robin", "--class-overflow", "Test=block"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name: "error with the --replay and --overflow flags (block policy)",
+			args: args{
+				[]string{executablePath, "--replay", "trace.jsonl", "--overflow", "block"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with the --seed flag (incorrect number)",
+			args:                   args{[]string{executablePath, "--seed", "incorrect"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name: "error with the --record and --replay flags",
+			args: args{
+				[]string{executablePath, "--record", "trace.jsonl", "--replay", "trace.jsonl"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with the --trace-format flag (unknown format)",
+			args:                   args{[]string{executablePath, "--trace-format", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
 		{
 			name:                   "error with an extra argument",
 			args:                   args{[]string{executablePath, "one", "two"}},
@@ -204,6 +480,11 @@ func initializeForUsage(usage *[]byte, writer *MockWriter, exiter *MockExiterInt
 	exiter.On("Exit", 0).Return()
 }
 
+// the help text itself is checked by the tests of the Parse function
+func initializeForHelp(_ *[]byte, writer *MockWriter, exiter *MockExiterInterface) {
+	initializeForUsage(new([]byte), writer, exiter)
+}
+
 func setOption(options interpreter.Options, path string, value interface{}) interpreter.Options {
 	optionReflection := reflect.ValueOf(&options).Elem()
 	for _, field := range strings.Split(path, ".") {
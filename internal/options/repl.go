@@ -0,0 +1,83 @@
+package options
+
+import (
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/interpreter"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+// ...
+const (
+	REPLCommand = "repl"
+
+	// errors of actors shouldn't terminate the interactive session
+	DefaultREPLFailurePolicy = "ignore-and-log"
+)
+
+// ParseREPL ...
+//
+// It expects the name of the command in the second argument.
+func ParseREPL(args []string, dependencies Dependencies) (interpreter.Options, error) {
+	app := newApp(
+		filepath.Base(args[0])+" "+REPLCommand,
+		"Evaluate definitions and commands interactively.",
+		dependencies,
+	)
+
+	var options interpreter.Options
+	app.Flag("inbox", "Inbox buffer size.").
+		Short('i').
+		Default(strconv.Itoa(DefaultInboxSize)).
+		IntVar(&options.InboxSize)
+	var inboxPolicy string
+	app.Flag("overflow", "Default inbox overflow policy of actors.").
+		Short('o').
+		Default(DefaultInboxPolicy).
+		EnumVar(&inboxPolicy, runtime.InboxPolicyNames()...)
+	app.Flag("state", "Initial state.").
+		Short('s').
+		Default(DefaultInitialState).
+		StringVar(&options.InitialState)
+	app.Flag("message", "Initial message.").
+		Short('m').
+		Default(DefaultInitialMessage).
+		StringVar(&options.InitialMessage)
+	var failurePolicy string
+	app.Flag("failure", "Default failure policy of actors.").
+		Short('f').
+		Default(DefaultREPLFailurePolicy).
+		EnumVar(&failurePolicy, runtime.FailurePolicyNames()...)
+	var deadLetterMode string
+	app.Flag("dead-letters", "Mode of reporting of unhandled messages.").
+		Short('d').
+		Default(DefaultDeadLetterMode).
+		EnumVar(&deadLetterMode, runtime.DeadLetterModeNames()...)
+
+	terminated, err := parseArgs(app, args[2:], dependencies)
+	if err != nil {
+		return interpreter.Options{}, err
+	}
+	if terminated {
+		return interpreter.Options{}, nil
+	}
+
+	options.InboxPolicies, err = parseInboxPolicies(inboxPolicy, nil)
+	if err != nil {
+		return interpreter.Options{}, err
+	}
+
+	options.FailurePolicies, err = parseFailurePolicies(failurePolicy, nil)
+	if err != nil {
+		return interpreter.Options{}, err
+	}
+
+	options.DeadLetters, err = runtime.ParseDeadLetterMode(deadLetterMode)
+	if err != nil {
+		return interpreter.Options{}, errors.Wrap(err, "unable to parse the dead letter mode")
+	}
+
+	return options, nil
+}
@@ -0,0 +1,108 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/interpreter"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestParseREPL(test *testing.T) {
+	type args struct {
+		args []string
+	}
+
+	const executablePath = "path/to/an/executable/file"
+	defaultOptions := interpreter.Options{
+		InboxSize:       DefaultInboxSize,
+		InitialState:    DefaultInitialState,
+		InitialMessage:  DefaultInitialMessage,
+		FailurePolicies: runtime.FailurePolicyGroup{Default: runtime.IgnoreAndLogPolicy},
+	}
+	for _, testData := range []struct {
+		name                   string
+		args                   args
+		initializeDependencies func(usage *[]byte, writer *MockWriter, exiter *MockExiterInterface)
+		wantUsage              []byte
+		want                   interpreter.Options
+		wantErr                assert.ErrorAssertionFunc
+	}{
+		{
+			name:                   "success without flags",
+			args:                   args{[]string{executablePath, REPLCommand}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   defaultOptions,
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --version flag",
+			args:                   args{[]string{executablePath, REPLCommand, "--version"}},
+			initializeDependencies: initializeForUsage,
+			wantUsage:              []byte(Version + "\n"),
+			want:                   interpreter.Options{},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the -h flag",
+			args:                   args{[]string{executablePath, REPLCommand, "-h"}},
+			initializeDependencies: initializeForHelp,
+			want:                   interpreter.Options{},
+			wantErr:                assert.NoError,
+		},
+		{
+			name: "success with all flags",
+			args: args{[]string{
+				executablePath,
+				REPLCommand,
+				"--inbox", "23",
+				"--overflow", "block",
+				"--state", "state_0",
+				"--message", "message_0",
+				"--failure", "stop-actor",
+				"--dead-letters", "warn",
+			}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: interpreter.Options{
+				InboxSize:       23,
+				InboxPolicies:   runtime.InboxPolicyGroup{Default: runtime.BlockInboxPolicy},
+				InitialState:    "state_0",
+				InitialMessage:  "message_0",
+				FailurePolicies: runtime.FailurePolicyGroup{Default: runtime.StopActorPolicy},
+				DeadLetters:     runtime.WarnOnDeadLetters,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:                   "error with the --failure flag (unknown policy)",
+			args:                   args{[]string{executablePath, REPLCommand, "--failure", "unknown"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with an argument",
+			args:                   args{[]string{executablePath, REPLCommand, "main.tt"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   interpreter.Options{},
+			wantErr:                assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var usage []byte
+			usageWriter := new(MockWriter)
+			exiter := new(MockExiterInterface)
+			testData.initializeDependencies(&usage, usageWriter, exiter)
+
+			errorWriter := new(MockWriter)
+			dependencies := Dependencies{usageWriter, errorWriter, exiter.Exit}
+			got, err := ParseREPL(testData.args.args, dependencies)
+
+			mock.AssertExpectationsForObjects(test, usageWriter, errorWriter, exiter)
+			assert.Equal(test, testData.wantUsage, usage)
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
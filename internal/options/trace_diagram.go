@@ -0,0 +1,56 @@
+package options
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/internal/diagram"
+)
+
+// ...
+const (
+	TraceDiagramCommand = "trace-diagram"
+
+	DefaultDiagramFormat = "plantuml"
+)
+
+// TraceDiagramOptions ...
+type TraceDiagramOptions struct {
+	Filename string
+	Format   diagram.Format
+}
+
+// ParseTraceDiagram ...
+//
+// It expects the name of the command in the second argument.
+func ParseTraceDiagram(args []string, dependencies Dependencies) (TraceDiagramOptions, error) {
+	app := newApp(
+		filepath.Base(args[0])+" "+TraceDiagramCommand,
+		"Render the sequence diagram by the trace written by the --trace option.",
+		dependencies,
+	)
+
+	var options TraceDiagramOptions
+	var format string
+	app.Flag("format", "Format of the diagram.").
+		Short('f').
+		Default(DefaultDiagramFormat).
+		EnumVar(&format, diagram.PlantUMLFormat.String(), diagram.MermaidFormat.String())
+	app.Arg("filename", `Trace file name in the JSON Lines format. Empty or "-" means stdin.`).
+		StringVar(&options.Filename)
+
+	terminated, err := parseArgs(app, args[2:], dependencies)
+	if err != nil {
+		return TraceDiagramOptions{}, err
+	}
+	if terminated {
+		return TraceDiagramOptions{}, nil
+	}
+
+	options.Format, err = diagram.ParseFormat(format)
+	if err != nil {
+		return TraceDiagramOptions{}, errors.Wrap(err, "unable to parse the diagram format")
+	}
+
+	return options, nil
+}
@@ -0,0 +1,115 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/internal/diagram"
+)
+
+func TestParseTraceDiagram(test *testing.T) {
+	type args struct {
+		args []string
+	}
+
+	const executablePath = "path/to/an/executable/file"
+	for _, testData := range []struct {
+		name                   string
+		args                   args
+		initializeDependencies func(usage *[]byte, writer *MockWriter, exiter *MockExiterInterface)
+		wantUsage              []byte
+		want                   TraceDiagramOptions
+		wantErr                assert.ErrorAssertionFunc
+	}{
+		{
+			name:                   "success without flags and arguments",
+			args:                   args{[]string{executablePath, TraceDiagramCommand}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   TraceDiagramOptions{Format: diagram.PlantUMLFormat},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the --version flag",
+			args:                   args{[]string{executablePath, TraceDiagramCommand, "--version"}},
+			initializeDependencies: initializeForUsage,
+			wantUsage:              []byte(Version + "\n"),
+			want:                   TraceDiagramOptions{},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the -h flag",
+			args:                   args{[]string{executablePath, TraceDiagramCommand, "-h"}},
+			initializeDependencies: initializeForHelp,
+			want:                   TraceDiagramOptions{},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the -f flag",
+			args:                   args{[]string{executablePath, TraceDiagramCommand, "-f", "mermaid"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   TraceDiagramOptions{Format: diagram.MermaidFormat},
+			wantErr:                assert.NoError,
+		},
+		{
+			name: "success with the --format flag",
+			args: args{
+				[]string{executablePath, TraceDiagramCommand, "--format", "mermaid"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   TraceDiagramOptions{Format: diagram.MermaidFormat},
+			wantErr:                assert.NoError,
+		},
+		{
+			name:                   "success with the filename argument",
+			args:                   args{[]string{executablePath, TraceDiagramCommand, "trace.jsonl"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want: TraceDiagramOptions{
+				Filename: "trace.jsonl",
+				Format:   diagram.PlantUMLFormat,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with the --format flag (unknown format)",
+			args: args{
+				[]string{executablePath, TraceDiagramCommand, "--format", "unknown"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   TraceDiagramOptions{},
+			wantErr:                assert.Error,
+		},
+		{
+			name: "error with the --format flag (unsupported format)",
+			args: args{
+				[]string{executablePath, TraceDiagramCommand, "--format", "dot"},
+			},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   TraceDiagramOptions{},
+			wantErr:                assert.Error,
+		},
+		{
+			name:                   "error with an extra argument",
+			args:                   args{[]string{executablePath, TraceDiagramCommand, "one", "two"}},
+			initializeDependencies: func(*[]byte, *MockWriter, *MockExiterInterface) {},
+			want:                   TraceDiagramOptions{},
+			wantErr:                assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var usage []byte
+			usageWriter := new(MockWriter)
+			exiter := new(MockExiterInterface)
+			testData.initializeDependencies(&usage, usageWriter, exiter)
+
+			errorWriter := new(MockWriter)
+			dependencies := Dependencies{usageWriter, errorWriter, exiter.Exit}
+			got, err := ParseTraceDiagram(testData.args.args, dependencies)
+
+			mock.AssertExpectationsForObjects(test, usageWriter, errorWriter, exiter)
+			assert.Equal(test, testData.wantUsage, usage)
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package interpreter
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+type fileImporter struct {
+	dependencies ReaderDependencies
+	sources      *runtime.SourceGroup
+}
+
+func (importer fileImporter) Import(path string, importingFilename string) (
+	filename string,
+	program *parser.Program,
+	err error,
+) {
+	filename = path
+	if !filepath.IsAbs(path) && !isEmptyFilename(importingFilename) {
+		filename = filepath.Join(filepath.Dir(importingFilename), path)
+	}
+	filename = filepath.Clean(filename)
+
+	code, err := readCode(filename, importer.dependencies)
+	if err != nil {
+		return "", nil, err
+	}
+
+	program, err = parseCode(filename, code, importer.sources)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "unable to parse the file %s", filename)
+	}
+
+	return filename, program, nil
+}
+
+func normalizeFilename(filename string) string {
+	if isEmptyFilename(filename) {
+		return ""
+	}
+
+	return filepath.Clean(filename)
+}
@@ -0,0 +1,139 @@
+package interpreter
+
+import (
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestFileImporter_Import(test *testing.T) {
+	type args struct {
+		path              string
+		importingFilename string
+	}
+
+	for _, testData := range []struct {
+		name                   string
+		args                   args
+		initializeDependencies func(fileSystem *MockFileSystem, file *MockFile)
+		wantFilename           string
+		wantProgram            *parser.Program
+		wantErr                assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the relative path",
+			args: args{path: "../lib/test.tt", importingFilename: "/code/main/main.tt"},
+			initializeDependencies: func(fileSystem *MockFileSystem, file *MockFile) {
+				fileSystem.On("Open", "/code/lib/test.tt").Return(file, nil)
+
+				file.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int { return copy(buffer, "actor Main();") }, io.EOF)
+				file.On("Close").Return(nil)
+			},
+			wantFilename: "/code/lib/test.tt",
+			wantProgram: &parser.Program{
+				Definitions: []*parser.Definition{
+					{Actor: &parser.Actor{Name: "Main", Parameters: &parser.IdentifierGroup{}}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the absolute path",
+			args: args{path: "/lib/./test.tt", importingFilename: "/code/main/main.tt"},
+			initializeDependencies: func(fileSystem *MockFileSystem, file *MockFile) {
+				fileSystem.On("Open", "/lib/test.tt").Return(file, nil)
+
+				file.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int { return copy(buffer, "") }, io.EOF)
+				file.On("Close").Return(nil)
+			},
+			wantFilename: "/lib/test.tt",
+			wantProgram:  new(parser.Program),
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success with the default source as the importing file",
+			args: args{path: "./lib/test.tt", importingFilename: ""},
+			initializeDependencies: func(fileSystem *MockFileSystem, file *MockFile) {
+				fileSystem.On("Open", "lib/test.tt").Return(file, nil)
+
+				file.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int { return copy(buffer, "") }, io.EOF)
+				file.On("Close").Return(nil)
+			},
+			wantFilename: "lib/test.tt",
+			wantProgram:  new(parser.Program),
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "error on file reading",
+			args: args{path: "test.tt", importingFilename: "main.tt"},
+			initializeDependencies: func(fileSystem *MockFileSystem, _ *MockFile) {
+				fileSystem.On("Open", "test.tt").Return(nil, iotest.ErrTimeout)
+			},
+			wantFilename: "",
+			wantProgram:  nil,
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error on code parsing",
+			args: args{path: "test.tt", importingFilename: "main.tt"},
+			initializeDependencies: func(fileSystem *MockFileSystem, file *MockFile) {
+				fileSystem.On("Open", "test.tt").Return(file, nil)
+
+				file.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int { return copy(buffer, "incorrect") }, io.EOF)
+				file.On("Close").Return(nil)
+			},
+			wantFilename: "",
+			wantProgram:  nil,
+			wantErr:      assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			defaultReader, fileSystem, file := new(MockReader), new(MockFileSystem), new(MockFile)
+			testData.initializeDependencies(fileSystem, file)
+
+			importer := fileImporter{
+				dependencies: ReaderDependencies{defaultReader, fileSystem},
+				sources:      runtime.NewSourceGroup(),
+			}
+			gotFilename, gotProgram, err :=
+				importer.Import(testData.args.path, testData.args.importingFilename)
+			parser.ClearPositions(gotProgram)
+
+			mock.AssertExpectationsForObjects(test, defaultReader, fileSystem, file)
+			assert.Equal(test, testData.wantFilename, gotFilename)
+			assert.Equal(test, testData.wantProgram, gotProgram)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestNormalizeFilename(test *testing.T) {
+	for _, testData := range []struct {
+		name     string
+		filename string
+		want     string
+	}{
+		{name: "empty", filename: "", want: ""},
+		{name: "dash", filename: "-", want: ""},
+		{name: "nonempty", filename: "./code/../main.tt", want: "main.tt"},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := normalizeFilename(testData.filename)
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
@@ -9,50 +9,120 @@ import (
 
 // Options ...
 type Options struct {
-	Filename       string
-	InboxSize      int
-	InitialState   string
-	InitialMessage string
+	Filename        string
+	InboxSize       int
+	InboxPolicies   runtime.InboxPolicyGroup
+	InitialState    string
+	InitialMessage  string
+	FailurePolicies runtime.FailurePolicyGroup
+	DeadLetters     runtime.DeadLetterMode
+	Scheduler       runtime.SchedulerKind
+	Seed            int64
+	Record          string
+	Replay          string
+	Trace           string
+	TraceFormat     runtime.TraceFormat
 }
 
 // Dependencies ...
+//
+// The Sources field is optional. If it's set, the read source code is stored there
+// for reporting of errors.
+//
+// The Scheduler field is optional. If it isn't set, the scheduler is created
+// by the options.
 type Dependencies struct {
-	Reader  ReaderDependencies
-	Runtime runtime.Dependencies
+	Reader    ReaderDependencies
+	Runtime   runtime.Dependencies
+	Sources   *runtime.SourceGroup
+	Scheduler runtime.Scheduler
 }
 
 // Interpret ...
 func Interpret(ctx context.Context, options Options, dependencies Dependencies) error {
-	code, err := readCode(options.Filename, dependencies.Reader)
+	program, translatorOptions, err := loadProgram(options, dependencies)
 	if err != nil {
 		return err
 	}
 
-	program := new(parser.Program)
-	if err = parser.ParseToAST(code, program); err != nil {
-		return err
-	}
-
 	definitions, initialFactories, err := translator.TranslateProgram(
 		program,
 		ctx.ValuesNames(),
-		translator.Options{
-			InboxSize:    options.InboxSize,
-			InitialState: context.State{Name: options.InitialState},
-		},
+		translatorOptions,
 		dependencies.Runtime,
 	)
 	if err != nil {
 		return err
 	}
 
+	for name, definition := range definitions {
+		// bind top-level functions to the global context
+		if function, ok := definition.(runtime.Function); ok {
+			definitions[name] = function.WithClosure(ctx)
+		}
+	}
 	context.SetValues(ctx, definitions)
 
-	actors := runtime.NewConcurrentActorGroup(ctx)
+	scheduler := dependencies.Scheduler
+	if scheduler == nil {
+		scheduler = runtime.NewScheduler(options.Scheduler, options.Seed)
+	}
+
+	actors := runtime.NewConcurrentActorGroup(ctx).WithScheduler(scheduler)
 	for _, factory := range initialFactories {
 		actors.RegisterActor(factory.CreateActor(), nil)
 	}
 	actors.SendMessage(context.Message{Name: options.InitialMessage})
+	// start the scheduler only after sending the initial message for determinism
+	scheduler.Start()
 
 	return nil
 }
+
+// BuildStateGraphs ...
+//
+// It reads and translates the program in the same way as Interpret, but doesn't
+// run it and returns the state graphs of its actor classes instead.
+func BuildStateGraphs(
+	ctx context.Context,
+	options Options,
+	dependencies Dependencies,
+) ([]translator.StateGraph, error) {
+	program, translatorOptions, err := loadProgram(options, dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return translator.TranslateStateGraphs(program, ctx.ValuesNames(), translatorOptions)
+}
+
+func loadProgram(options Options, dependencies Dependencies) (
+	program *parser.Program,
+	translatorOptions translator.Options,
+	err error,
+) {
+	code, err := readCode(options.Filename, dependencies.Reader)
+	if err != nil {
+		return nil, translator.Options{}, err
+	}
+
+	filename := normalizeFilename(options.Filename)
+	program, err = parseCode(filename, code, dependencies.Sources)
+	if err != nil {
+		return nil, translator.Options{}, err
+	}
+
+	translatorOptions = translator.Options{
+		InboxSize:       options.InboxSize,
+		InboxPolicies:   options.InboxPolicies,
+		InitialState:    context.State{Name: options.InitialState},
+		FailurePolicies: options.FailurePolicies,
+		DeadLetters:     options.DeadLetters,
+		Filename:        filename,
+		Importer: fileImporter{
+			dependencies: dependencies.Reader,
+			sources:      dependencies.Sources,
+		},
+	}
+	return program, translatorOptions, nil
+}
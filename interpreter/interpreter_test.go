@@ -4,15 +4,21 @@ import (
 	"fmt"
 	"go/types"
 	"io"
+	"strings"
 	"sync"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	syncutils "github.com/thewizardplusplus/go-sync-utils"
 	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/builtin"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/translator"
 )
 
 func TestInterpret(test *testing.T) {
@@ -37,8 +43,12 @@ func TestInterpret(test *testing.T) {
 				context.On("ValuesNames").Return(mapset.NewSet("test"))
 				context.On("Value", "test").Return(types.Nil{}, true)
 				context.On("SetValue", "Main", mock.AnythingOfType("runtime.ConcurrentActorFactory")).Return()
+				context.On("SetValue", "self", mock.AnythingOfType("*runtime.ConcurrentActor")).Return()
+				context.On("SetValue", "sender", mock.AnythingOfType("types.Nil")).Return()
 				context.On("SetMessageSender", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
 				context.On("SetActorRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetTopicRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetNameRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
 				context.On("SetStateHolder", mock.AnythingOfType("*runtime.Actor")).Return()
 				context.On("Copy").Return(context)
 
@@ -68,8 +78,12 @@ func TestInterpret(test *testing.T) {
 				context.On("ValuesNames").Return(mapset.NewSet("test"))
 				context.On("Value", "test").Return(float64(23), true)
 				context.On("SetValue", "Main", mock.AnythingOfType("runtime.ConcurrentActorFactory")).Return()
+				context.On("SetValue", "self", mock.AnythingOfType("*runtime.ConcurrentActor")).Return()
+				context.On("SetValue", "sender", mock.AnythingOfType("types.Nil")).Return()
 				context.On("SetMessageSender", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
 				context.On("SetActorRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetTopicRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetNameRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
 				context.On("SetStateHolder", mock.AnythingOfType("*runtime.Actor")).Return()
 				context.On("Copy").Return(context)
 
@@ -88,6 +102,52 @@ func TestInterpret(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with the function",
+			initializeDependencies: func(
+				options Options,
+				context *MockContext,
+				waiter *MockWaitGroup,
+				defaultReader *MockReader,
+			) {
+				var function interface{}
+				context.On("ValuesNames").Return(mapset.NewSet("test"))
+				context.On("Value", "test").Return(float64(23), true)
+				context.On("Value", "function").Return(func(string) interface{} { return function }, true)
+				context.
+					On("SetValue", "function", mock.AnythingOfType("runtime.Function")).
+					Run(func(arguments mock.Arguments) { function = arguments.Get(1) }).
+					Return()
+				context.On("SetValue", "Main", mock.AnythingOfType("runtime.ConcurrentActorFactory")).Return()
+				context.On("SetValue", "self", mock.AnythingOfType("*runtime.ConcurrentActor")).Return()
+				context.On("SetValue", "sender", mock.AnythingOfType("types.Nil")).Return()
+				context.On("SetMessageSender", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetMessageSender", mock.AnythingOfType("*interpreter.MockContext")).Return()
+				context.On("SetActorRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetActorRegister", mock.AnythingOfType("*interpreter.MockContext")).Return()
+				context.On("SetTopicRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetNameRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).Return()
+				context.On("SetTopicRegister", mock.AnythingOfType("*interpreter.MockContext")).Return()
+				context.On("SetNameRegister", mock.AnythingOfType("*interpreter.MockContext")).Return()
+				context.On("SetStateHolder", mock.AnythingOfType("*runtime.Actor")).Return()
+				context.On("SetStateHolder", mock.AnythingOfType("*interpreter.MockContext")).Return()
+				context.On("Copy").Return(context)
+
+				waiter.On("Add", 1).Return()
+				waiter.On("Done").Return()
+
+				defaultReader.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int {
+						return copy(buffer, fmt.Sprintf(
+							`func function() test; actor Main() state %s() message %s() function();;;`,
+							options.InitialState,
+							options.InitialMessage,
+						))
+					}, io.EOF)
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "error on code reading",
 			initializeDependencies: func(
@@ -193,3 +253,125 @@ func TestInterpret(test *testing.T) {
 		})
 	}
 }
+
+func TestBuildStateGraphs(test *testing.T) {
+	for _, testData := range []struct {
+		name                   string
+		initializeDependencies func(context *MockContext, defaultReader *MockReader)
+		want                   []translator.StateGraph
+		wantErr                assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			initializeDependencies: func(context *MockContext, defaultReader *MockReader) {
+				context.On("ValuesNames").Return(mapset.NewSet("test"))
+
+				defaultReader.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int {
+						return copy(buffer, `actor Main()
+							state __initialization__() message test() set done();;
+							state done();
+						;`)
+					}, io.EOF)
+			},
+			want: []translator.StateGraph{
+				{
+					Class:        "Main",
+					InitialState: "__initialization__",
+					States:       []string{"__initialization__", "done"},
+					Transitions: []translator.StateTransition{
+						{From: "__initialization__", To: "done", Message: "test"},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error on code reading",
+			initializeDependencies: func(_ *MockContext, defaultReader *MockReader) {
+				defaultReader.On("Read", mock.AnythingOfType("[]uint8")).Return(0, iotest.ErrTimeout)
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name: "error on code translation",
+			initializeDependencies: func(context *MockContext, defaultReader *MockReader) {
+				context.On("ValuesNames").Return(mapset.NewSet("test"))
+
+				defaultReader.
+					On("Read", mock.AnythingOfType("[]uint8")).
+					Return(func(buffer []byte) int {
+						return copy(buffer, "actor Main() state __initialization__() message test() unknown;;;")
+					}, io.EOF)
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			options := Options{InitialState: "__initialization__"}
+			context := new(MockContext)
+			defaultReader := new(MockReader)
+			fileSystem := new(MockFileSystem)
+			testData.initializeDependencies(context, defaultReader)
+
+			dependencies := Dependencies{Reader: ReaderDependencies{defaultReader, fileSystem}}
+			got, err := BuildStateGraphs(context, options, dependencies)
+
+			mock.AssertExpectationsForObjects(test, context, defaultReader, fileSystem)
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestInterpret_withNamespacedImport(test *testing.T) {
+	results := make(chan float64, 1)
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, builtin.Values)
+	ctx.SetValue("test", func(value float64) (float64, error) {
+		results <- value
+		return value, nil
+	})
+
+	file := new(MockFile)
+	file.
+		On("Read", mock.AnythingOfType("[]uint8")).
+		Return(func(buffer []byte) int {
+			return copy(buffer, `
+				func helper(value) test(value);
+				actor Ticker()
+					state __initialization__()
+						message __initialize__()
+							helper(23)
+						;
+					;
+				;
+			`)
+		}, io.EOF)
+	file.On("Close").Return(nil)
+
+	fileSystem := new(MockFileSystem)
+	fileSystem.On("Open", "lib.tt").Return(file, nil)
+
+	errorHandler := new(MockErrorHandler)
+	err := Interpret(ctx, Options{
+		InboxSize:      1,
+		InitialState:   "__initialization__",
+		InitialMessage: "__initialize__",
+	}, Dependencies{
+		Reader:  ReaderDependencies{strings.NewReader(`import "lib.tt" as lib`), fileSystem},
+		Runtime: runtime.Dependencies{WaitGroup: new(sync.WaitGroup), ErrorHandler: errorHandler},
+	})
+	require.NoError(test, err)
+
+	select {
+	case result := <-results:
+		assert.Equal(test, float64(23), result)
+	case <-time.After(time.Second):
+		test.Fatal("the imported actor hasn't processed the message")
+	}
+	mock.AssertExpectationsForObjects(test, file, fileSystem, errorHandler)
+}
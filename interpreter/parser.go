@@ -0,0 +1,42 @@
+package interpreter
+
+import (
+	"strings"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/translator"
+)
+
+func parseCode(filename string, code string, sources *runtime.SourceGroup) (
+	program *parser.Program,
+	err error,
+) {
+	if sources != nil {
+		sources.AddSource(filename, code)
+	}
+
+	program = new(parser.Program)
+	if err := parser.ParseFileToAST(filename, code, program); err != nil {
+		if parsingErr, ok := errors.Cause(err).(participle.Error); ok {
+			err = positionParsingError(parsingErr)
+		}
+
+		return nil, err
+	}
+
+	return program, nil
+}
+
+func positionParsingError(err participle.Error) error {
+	position := err.Position()
+	// remove the position prefix added by the lexer.FormatError() function
+	message := strings.TrimPrefix(err.Error(), lexer.FormatError(position, ""))
+
+	positionedErr :=
+		runtime.NewPositionedError(errors.New(message), translator.TranslatePosition(position))
+	return errors.Wrap(positionedErr, "unable to parse the code")
+}
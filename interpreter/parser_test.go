@@ -0,0 +1,66 @@
+package interpreter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestParseCode(test *testing.T) {
+	type args struct {
+		filename string
+		code     string
+	}
+
+	for _, testData := range []struct {
+		name         string
+		args         args
+		wantProgram  *parser.Program
+		wantExcerpt  string
+		wantPosition runtime.Position
+		wantErr      string
+	}{
+		{
+			name: "success",
+			args: args{filename: "test.tt", code: "actor Main();"},
+			wantProgram: &parser.Program{
+				Definitions: []*parser.Definition{
+					{Actor: &parser.Actor{Name: "Main", Parameters: &parser.IdentifierGroup{}}},
+				},
+			},
+			wantExcerpt: "actor Main();\n^",
+		},
+		{
+			name:         "error",
+			args:         args{filename: "test.tt", code: "actor Main();\nactor Test() incorrect"},
+			wantProgram:  nil,
+			wantExcerpt:  "actor Main();\n^",
+			wantPosition: runtime.Position{Filename: "test.tt", Line: 2, Column: 14},
+			wantErr:      `unable to parse the code: unexpected "incorrect" (expected ";")`,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			sources := runtime.NewSourceGroup()
+			gotProgram, err := parseCode(testData.args.filename, testData.args.code, sources)
+			parser.ClearPositions(gotProgram)
+
+			gotExcerpt, _ := sources.Excerpt(runtime.Position{
+				Filename: testData.args.filename,
+				Line:     1,
+				Column:   1,
+			})
+			gotPosition, _ := runtime.FindPosition(err)
+
+			assert.Equal(test, testData.wantProgram, gotProgram)
+			assert.Equal(test, testData.wantExcerpt, gotExcerpt)
+			assert.Equal(test, testData.wantPosition, gotPosition)
+			if testData.wantErr != "" {
+				assert.EqualError(test, err, testData.wantErr)
+			} else {
+				assert.NoError(test, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package interpreter
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/scanner"
+
+	"github.com/alecthomas/participle"
+	"github.com/alecthomas/participle/lexer"
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+	"github.com/thewizardplusplus/tick-tock/translator"
+)
+
+const (
+	prompt                 = "> "
+	continuationPrompt     = "... "
+	formattingFunctionName = "str"
+)
+
+var (
+	errIncompleteInput = errors.New("incomplete input")
+
+	// each block started by these keywords is ended by a semicolon
+	blockKeywords = map[string]struct{}{
+		"actor":   {},
+		"class":   {},
+		"func":    {},
+		"state":   {},
+		"enter":   {},
+		"exit":    {},
+		"message": {},
+		"when":    {},
+	}
+)
+
+// REPL ...
+//
+// It evaluates definitions and commands one by one in the persistent context,
+// so the values, functions and actor classes defined by previous inputs are
+// available to the next ones, including the already started actors. The actors
+// defined by the actor definitions are started immediately.
+type REPL struct {
+	ctx               context.Context
+	actors            *runtime.ConcurrentActorGroup
+	initialMessage    string
+	translatorOptions translator.Options
+	dependencies      Dependencies
+	inputCount        int
+}
+
+// NewREPL ...
+//
+// The Filename and recording options are ignored. Values of the context become
+// shared with contexts of the actors.
+func NewREPL(ctx context.Context, options Options, dependencies Dependencies) *REPL {
+	ctx.SetValueStore(newSharedValueStore(ctx))
+
+	scheduler := dependencies.Scheduler
+	if scheduler == nil {
+		scheduler = runtime.NewScheduler(options.Scheduler, options.Seed)
+	}
+
+	actors := runtime.NewConcurrentActorGroup(ctx).WithScheduler(scheduler)
+	scheduler.Start()
+
+	// commands of the REPL are run outside of actors, but they can start actors
+	// and send messages to them
+	ctx.SetMessageSender(actors)
+	ctx.SetActorRegister(actors)
+	ctx.SetTopicRegister(actors)
+	ctx.SetNameRegister(actors)
+
+	return &REPL{
+		ctx:            ctx,
+		actors:         actors,
+		initialMessage: options.InitialMessage,
+		translatorOptions: translator.Options{
+			InboxSize:       options.InboxSize,
+			InboxPolicies:   options.InboxPolicies,
+			InitialState:    context.State{Name: options.InitialState},
+			FailurePolicies: options.FailurePolicies,
+			DeadLetters:     options.DeadLetters,
+			Importer: fileImporter{
+				dependencies: dependencies.Reader,
+				sources:      dependencies.Sources,
+			},
+		},
+		dependencies: dependencies,
+	}
+}
+
+// Run ...
+//
+// It reads inputs line by line until the end of the reader. An input continues
+// on the next line while it's incomplete. Results of inputs are written
+// to the writer, errors are logged by the error handler.
+func (repl *REPL) Run(reader io.Reader, writer io.Writer) error {
+	var code string
+	scanner := bufio.NewScanner(reader)
+	for {
+		currentPrompt := prompt
+		if len(code) != 0 {
+			currentPrompt = continuationPrompt
+		}
+		fmt.Fprint(writer, currentPrompt) // nolint: errcheck, gosec
+
+		if !scanner.Scan() {
+			break
+		}
+
+		code += scanner.Text() + "\n"
+		if len(strings.TrimSpace(code)) == 0 {
+			code = ""
+			continue
+		}
+
+		output, err := repl.Evaluate(code)
+		if err == errIncompleteInput {
+			continue
+		}
+
+		code = ""
+		if err != nil {
+			repl.dependencies.Runtime.ErrorHandler.LogError(err)
+			continue
+		}
+		if len(output) != 0 {
+			fmt.Fprintln(writer, output) // nolint: errcheck, gosec
+		}
+	}
+	fmt.Fprintln(writer) // nolint: errcheck, gosec
+
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "unable to read the input")
+	}
+
+	return nil
+}
+
+// Evaluate ...
+//
+// It evaluates the single definition or command. The output contains the result
+// of the command formatted by the str function; it's empty for definitions
+// and nil results. The incomplete input (i.e. ended unexpectedly) is
+// reported by the special error, so it can be continued.
+func (repl *REPL) Evaluate(code string) (output string, err error) {
+	statement, err := repl.parseStatement(code)
+	if err != nil {
+		return "", err
+	}
+
+	if statement.Definition != nil {
+		return "", repl.define(statement.Definition)
+	}
+
+	return repl.execute(statement.Command)
+}
+
+func (repl *REPL) parseStatement(code string) (*parser.Statement, error) {
+	filename := "input#" + strconv.Itoa(repl.inputCount+1)
+	statement := new(parser.Statement)
+	err := parser.ParseFileToAST(filename, code, statement)
+	parsingErr, isParsingErr := errors.Cause(err).(participle.Error)
+	// the input that ended unexpectedly may continue on the next line; the check
+	// of blocks is required, because the when keyword alone is a valid identifier
+	if isParsingErr && parsingErr.Position().Offset >= len(code) || hasUnclosedBlocks(code) {
+		return nil, errIncompleteInput
+	}
+
+	repl.inputCount++
+	if repl.dependencies.Sources != nil {
+		repl.dependencies.Sources.AddSource(filename, code)
+	}
+
+	if err != nil {
+		if isParsingErr {
+			err = positionParsingError(parsingErr)
+		}
+
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+func hasUnclosedBlocks(code string) bool {
+	tokens, err := lexer.ConsumeAll(lexer.Lex(strings.NewReader(code)))
+	if err != nil {
+		return false
+	}
+
+	var openedBlockCount int
+	for _, token := range tokens {
+		switch {
+		case token.Type == scanner.Ident:
+			if _, ok := blockKeywords[token.Value]; ok {
+				openedBlockCount++
+			}
+		case token.Value == ";":
+			openedBlockCount--
+		}
+	}
+
+	return openedBlockCount > 0
+}
+
+func (repl *REPL) define(definition *parser.Definition) error {
+	program := &parser.Program{Definitions: []*parser.Definition{definition}}
+	definitions, initialFactories, err := translator.TranslateProgram(
+		program,
+		repl.ctx.ValuesNames(),
+		repl.translatorOptions,
+		repl.dependencies.Runtime,
+	)
+	if err != nil {
+		return err
+	}
+
+	for name, definition := range definitions {
+		// bind functions to the persistent context
+		if function, ok := definition.(runtime.Function); ok {
+			definitions[name] = function.WithClosure(repl.ctx)
+		}
+	}
+	context.SetValues(repl.ctx, definitions)
+
+	for _, factory := range initialFactories {
+		actor := factory.CreateActor()
+		repl.actors.RegisterActor(actor, nil)
+		actor.SendMessage(context.Message{Name: repl.initialMessage})
+	}
+
+	return nil
+}
+
+func (repl *REPL) execute(command *parser.Command) (output string, err error) {
+	if command.Defer || command.Return {
+		err := errors.New("the defer and return commands are allowed only in functions and actors")
+		return "", runtime.NewPositionedError(err, translator.TranslatePosition(command.Pos))
+	}
+
+	translatedCommand, err := translator.TranslateCommand(command, repl.ctx.ValuesNames())
+	if err != nil {
+		return "", errors.Wrap(err, "unable to translate the command")
+	}
+
+	result, err := translatedCommand.Run(repl.ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to run the command")
+	}
+	if _, ok := result.(types.Nil); ok {
+		return "", nil
+	}
+
+	return repl.format(result)
+}
+
+func (repl *REPL) format(value interface{}) (string, error) {
+	function, ok := repl.ctx.Value(formattingFunctionName)
+	if !ok {
+		return "", errors.Errorf("unknown function %s", formattingFunctionName)
+	}
+
+	text, err := expressions.CallValue(repl.ctx, function, []interface{}{value})
+	if err != nil {
+		return "", errors.Wrap(err, "unable to format the result")
+	}
+
+	textPair, ok := text.(*types.Pair)
+	if !ok {
+		return "", errors.Errorf(
+			"the function %s returned %T instead of a string",
+			formattingFunctionName,
+			text,
+		)
+	}
+
+	return textPair.Text()
+}
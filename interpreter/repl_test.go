@@ -0,0 +1,239 @@
+package interpreter
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/builtin"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestREPL_Evaluate(test *testing.T) {
+	type input struct {
+		code       string
+		wantOutput string
+		wantErr    assert.ErrorAssertionFunc
+	}
+
+	for _, testData := range []struct {
+		name   string
+		inputs []input
+	}{
+		{
+			name: "success with expressions",
+			inputs: []input{
+				{code: "2 + 3", wantOutput: "5", wantErr: assert.NoError},
+				{code: "[1, 2]", wantOutput: "[1,2]", wantErr: assert.NoError},
+				{code: "{one: 1}", wantOutput: `{"one":1}`, wantErr: assert.NoError},
+				{code: "nil", wantOutput: "", wantErr: assert.NoError},
+			},
+		},
+		{
+			name: "success with values",
+			inputs: []input{
+				{code: "let number = 23", wantOutput: "23", wantErr: assert.NoError},
+				{code: "number + 1", wantOutput: "24", wantErr: assert.NoError},
+			},
+		},
+		{
+			name: "success with functions",
+			inputs: []input{
+				{code: "let number = 23", wantOutput: "23", wantErr: assert.NoError},
+				{code: "func add(value) value + number;", wantOutput: "", wantErr: assert.NoError},
+				{code: "let number = 42", wantOutput: "42", wantErr: assert.NoError},
+				{code: "add(1)", wantOutput: "43", wantErr: assert.NoError},
+			},
+		},
+		{
+			name: "success with an incomplete input",
+			inputs: []input{
+				{code: "[1,\n", wantOutput: "", wantErr: isIncompleteInput},
+				{code: "[1,\n2]\n", wantOutput: "[1,2]", wantErr: assert.NoError},
+				{code: "let number = when\n", wantOutput: "", wantErr: isIncompleteInput},
+				{code: "let number = when\n=> 1\n23\n;\n", wantOutput: "23", wantErr: assert.NoError},
+			},
+		},
+		{
+			name: "error on parsing",
+			inputs: []input{
+				{code: "2 +", wantOutput: "", wantErr: assert.Error},
+			},
+		},
+		{
+			name: "error on translation",
+			inputs: []input{
+				{code: "unknown", wantOutput: "", wantErr: assert.Error},
+				{code: "func test() unknown;", wantOutput: "", wantErr: assert.Error},
+			},
+		},
+		{
+			name: "error with setting of the state",
+			inputs: []input{
+				{code: "when => 1 set test(); ;", wantOutput: "", wantErr: assert.Error},
+			},
+		},
+		{
+			name: "error with the return command",
+			inputs: []input{
+				{code: "return", wantOutput: "", wantErr: assert.Error},
+			},
+		},
+		{
+			name: "error on running",
+			inputs: []input{
+				{code: "1 / []", wantOutput: "", wantErr: assert.Error},
+			},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			ctx := context.NewDefaultContext()
+			context.SetValues(ctx, builtin.Values)
+
+			errorHandler := new(MockErrorHandler)
+			repl := NewREPL(ctx, Options{}, Dependencies{
+				Runtime: runtime.Dependencies{WaitGroup: new(sync.WaitGroup), ErrorHandler: errorHandler},
+			})
+			for _, input := range testData.inputs {
+				gotOutput, err := repl.Evaluate(input.code)
+
+				assert.Equal(test, input.wantOutput, gotOutput)
+				input.wantErr(test, err)
+			}
+
+			mock.AssertExpectationsForObjects(test, errorHandler)
+		})
+	}
+}
+
+func TestREPL_Evaluate_withActors(test *testing.T) {
+	results := make(chan float64, 2)
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, builtin.Values)
+	ctx.SetValue("test", func(value float64) (float64, error) {
+		results <- value
+		return value, nil
+	})
+
+	errorHandler := new(MockErrorHandler)
+	repl := NewREPL(ctx, Options{
+		InboxSize:      1,
+		InitialState:   "__initialization__",
+		InitialMessage: "__initialize__",
+	}, Dependencies{
+		Runtime: runtime.Dependencies{WaitGroup: new(sync.WaitGroup), ErrorHandler: errorHandler},
+	})
+	for _, step := range []struct {
+		codes      []string
+		wantResult float64
+	}{
+		{
+			codes: []string{
+				"let number = 5",
+				`actor Main()
+					state __initialization__()
+						message __initialize__()
+							test(number)
+						;
+					;
+				;`,
+			},
+			wantResult: 5,
+		},
+		{
+			codes: []string{
+				`class Counter()
+					state __initialization__()
+						message inc(value)
+							test(value + number)
+						;
+					;
+				;`,
+				"start Counter() as counter",
+				// it should be visible to the already started actor
+				"let number = 10",
+				`send [whereis("counter")].inc(2)`,
+			},
+			wantResult: 12,
+		},
+	} {
+		for _, code := range step.codes {
+			_, err := repl.Evaluate(code)
+			require.NoError(test, err)
+		}
+
+		select {
+		case result := <-results:
+			assert.Equal(test, step.wantResult, result)
+		case <-time.After(time.Second):
+			test.Fatal("the actor hasn't processed the message")
+		}
+	}
+	mock.AssertExpectationsForObjects(test, errorHandler)
+}
+
+func TestREPL_Run(test *testing.T) {
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, builtin.Values)
+
+	errorHandler := new(MockErrorHandler)
+	errorHandler.On("LogError", mock.MatchedBy(func(err error) bool {
+		return strings.Contains(err.Error(), "unknown identifier unknown")
+	})).Return()
+
+	repl := NewREPL(ctx, Options{}, Dependencies{
+		Runtime: runtime.Dependencies{WaitGroup: new(sync.WaitGroup), ErrorHandler: errorHandler},
+	})
+	reader := strings.NewReader("let number = 2\n\nunknown\n[\nnumber,\n3]\n")
+	var writer bytes.Buffer
+	err := repl.Run(reader, &writer)
+
+	mock.AssertExpectationsForObjects(test, errorHandler)
+	assert.Equal(test, "> 2\n> > > ... ... [2,3]\n> \n", writer.String())
+	assert.NoError(test, err)
+}
+
+func TestHasUnclosedBlocks(test *testing.T) {
+	for _, testData := range []struct {
+		name string
+		code string
+		want assert.BoolAssertionFunc
+	}{
+		{
+			name: "without blocks",
+			code: "2 + 3",
+			want: assert.False,
+		},
+		{
+			name: "with closed blocks",
+			code: "func test() when => 1 2; ;",
+			want: assert.False,
+		},
+		{
+			name: "with unclosed blocks",
+			code: "func test() when => 1 2;",
+			want: assert.True,
+		},
+		{
+			name: "with keywords in strings",
+			code: `"when"`,
+			want: assert.False,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := hasUnclosedBlocks(testData.code)
+
+			testData.want(test, got)
+		})
+	}
+}
+
+func isIncompleteInput(test assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+	return assert.Equal(test, errIncompleteInput, err, msgAndArgs...)
+}
@@ -0,0 +1,69 @@
+package interpreter
+
+import (
+	"sync"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+type globalValueGroup struct {
+	locker sync.RWMutex
+	values context.DefaultValueStore
+}
+
+// the global values are shared by all copies of the store, so the values defined
+// in the REPL are visible to the already started actors; the values set to copies
+// of the store are local to them
+//
+// the store with the nil local values is the root one, its values are global
+type sharedValueStore struct {
+	global *globalValueGroup
+	local  context.DefaultValueStore
+}
+
+func newSharedValueStore(values context.ValueStore) sharedValueStore {
+	global := &globalValueGroup{values: make(context.DefaultValueStore)}
+	for _, name := range values.ValuesNames().ToSlice() {
+		global.values[name.(string)], _ = values.Value(name.(string))
+	}
+
+	return sharedValueStore{global: global}
+}
+
+func (store sharedValueStore) ValuesNames() mapset.Set {
+	store.global.locker.RLock()
+	defer store.global.locker.RUnlock()
+
+	return store.global.values.ValuesNames().Union(store.local.ValuesNames())
+}
+
+func (store sharedValueStore) Value(name string) (value interface{}, ok bool) {
+	if value, ok := store.local.Value(name); ok {
+		return value, true
+	}
+
+	store.global.locker.RLock()
+	defer store.global.locker.RUnlock()
+
+	return store.global.values.Value(name)
+}
+
+func (store sharedValueStore) SetValue(name string, value interface{}) {
+	if store.local != nil {
+		store.local.SetValue(name, value)
+		return
+	}
+
+	store.global.locker.Lock()
+	defer store.global.locker.Unlock()
+
+	store.global.values.SetValue(name, value)
+}
+
+func (store sharedValueStore) Copy() context.CopyableValueStore {
+	return sharedValueStore{
+		global: store.global,
+		local:  store.local.Copy().(context.DefaultValueStore),
+	}
+}
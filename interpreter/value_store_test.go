@@ -0,0 +1,73 @@
+package interpreter
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestSharedValueStore(test *testing.T) {
+	store := newSharedValueStore(context.DefaultValueStore{"one": 1})
+	storeCopy := store.Copy()
+	storeCopy.SetValue("two", 2)
+	store.SetValue("three", 3)
+	storeCopyCopy := storeCopy.Copy()
+	storeCopyCopy.SetValue("four", 4)
+
+	assert.Equal(test, mapset.NewSet("one", "three"), store.ValuesNames())
+	assert.Equal(test, mapset.NewSet("one", "two", "three"), storeCopy.ValuesNames())
+	assert.Equal(test, mapset.NewSet("one", "two", "three", "four"), storeCopyCopy.ValuesNames())
+
+	for _, testData := range []struct {
+		name      string
+		store     context.ValueStore
+		valueName string
+		wantValue interface{}
+		wantOk    assert.BoolAssertionFunc
+	}{
+		{
+			name:      "global value in the root store",
+			store:     store,
+			valueName: "three",
+			wantValue: 3,
+			wantOk:    assert.True,
+		},
+		{
+			name:      "global value in the copy",
+			store:     storeCopyCopy,
+			valueName: "three",
+			wantValue: 3,
+			wantOk:    assert.True,
+		},
+		{
+			name:      "local value in the copy",
+			store:     storeCopyCopy,
+			valueName: "two",
+			wantValue: 2,
+			wantOk:    assert.True,
+		},
+		{
+			name:      "local value of the copy in the root store",
+			store:     store,
+			valueName: "two",
+			wantValue: nil,
+			wantOk:    assert.False,
+		},
+		{
+			name:      "local value of the copy in the source of the copy",
+			store:     storeCopy,
+			valueName: "four",
+			wantValue: nil,
+			wantOk:    assert.False,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			gotValue, gotOk := testData.store.Value(testData.valueName)
+
+			assert.Equal(test, testData.wantValue, gotValue)
+			testData.wantOk(test, gotOk)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"reflect"
+
+	"github.com/alecthomas/participle/lexer"
+)
+
+var (
+	positionType = reflect.TypeOf(lexer.Position{})
+)
+
+// ClearPositions ...
+//
+// It recursively resets all positions in the passed AST to zero values. It's useful
+// for comparison of AST nodes regardless of their positions.
+//
+// The passed root value should be a pointer.
+func ClearPositions(rootValue interface{}) interface{} {
+	clearPositions(reflect.ValueOf(rootValue))
+	return rootValue
+}
+
+func clearPositions(value reflect.Value) {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !value.IsNil() {
+			clearPositions(value.Elem())
+		}
+	case reflect.Slice:
+		for index := 0; index < value.Len(); index++ {
+			clearPositions(value.Index(index))
+		}
+	case reflect.Struct:
+		for index := 0; index < value.NumField(); index++ {
+			field := value.Field(index)
+			if !field.CanSet() {
+				continue
+			}
+
+			if field.Type() == positionType {
+				field.Set(reflect.Zero(positionType))
+				continue
+			}
+
+			clearPositions(field)
+		}
+	}
+}
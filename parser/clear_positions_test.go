@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/alecthomas/participle/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClearPositions(test *testing.T) {
+	position := lexer.Position{Filename: "test.tt", Offset: 5, Line: 2, Column: 3}
+	for _, testData := range []struct {
+		name      string
+		rootValue interface{}
+		want      interface{}
+	}{
+		{
+			name:      "nil",
+			rootValue: (*Atom)(nil),
+			want:      (*Atom)(nil),
+		},
+		{
+			name:      "pointer to a structure",
+			rootValue: &Atom{IntegerNumber: pointer.ToInt64(23), Pos: position},
+			want:      &Atom{IntegerNumber: pointer.ToInt64(23)},
+		},
+		{
+			name: "nested structures",
+			rootValue: &Command{
+				Expression: &Expression{
+					ListConstruction: &ListConstruction{
+						NilCoalescing: &NilCoalescing{Pos: position},
+						Pos:           position,
+					},
+				},
+				Pos: position,
+			},
+			want: &Command{
+				Expression: &Expression{
+					ListConstruction: &ListConstruction{NilCoalescing: &NilCoalescing{}},
+				},
+			},
+		},
+		{
+			name: "slice",
+			rootValue: &ExpressionGroup{
+				Expressions: []*Expression{
+					{ListConstruction: &ListConstruction{Pos: position}},
+					{ListConstruction: &ListConstruction{Pos: position}},
+				},
+			},
+			want: &ExpressionGroup{
+				Expressions: []*Expression{
+					{ListConstruction: &ListConstruction{}},
+					{ListConstruction: &ListConstruction{}},
+				},
+			},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := ClearPositions(testData.rootValue)
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
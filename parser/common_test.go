@@ -94,6 +94,7 @@ func TestParseToAST_withCommon(test *testing.T) {
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			err := ParseToAST(testData.args.code, testData.args.ast)
+			ClearPositions(testData.args.ast)
 
 			assert.Equal(test, testData.wantAST, testData.args.ast)
 			testData.wantErr(test, err)
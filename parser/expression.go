@@ -23,6 +23,10 @@
 
 package parser
 
+import (
+	"github.com/alecthomas/participle/lexer"
+)
+
 // Expression ...
 type Expression struct {
 	ListConstruction *ListConstruction `parser:"@@"`
@@ -33,6 +37,8 @@ type ListConstruction struct {
 	NilCoalescing    *NilCoalescing    `parser:"@@"`
 	Operation        string            `parser:"[ @\":\""`
 	ListConstruction *ListConstruction `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // NilCoalescing ...
@@ -40,6 +46,8 @@ type NilCoalescing struct {
 	Disjunction   *Disjunction   `parser:"@@"`
 	Operation     string         `parser:"[ @( \"?\" \"?\" )"`
 	NilCoalescing *NilCoalescing `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Disjunction ...
@@ -47,6 +55,8 @@ type Disjunction struct {
 	Conjunction *Conjunction `parser:"@@"`
 	Operation   string       `parser:"[ @( \"|\" \"|\" )"`
 	Disjunction *Disjunction `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Conjunction ...
@@ -54,6 +64,8 @@ type Conjunction struct {
 	Equality    *Equality    `parser:"@@"`
 	Operation   string       `parser:"[ @( \"&\" \"&\" )"`
 	Conjunction *Conjunction `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Equality ...
@@ -61,6 +73,8 @@ type Equality struct {
 	Comparison *Comparison `parser:"@@"`
 	Operation  string      `parser:"[ @( \"=\" \"=\" | \"!\" \"=\" )"`
 	Equality   *Equality   `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Comparison ...
@@ -68,6 +82,8 @@ type Comparison struct {
 	BitwiseDisjunction *BitwiseDisjunction `parser:"@@"`
 	Operation          string              `parser:"[ @( \"<\" \"=\" | \"<\" | \">\" \"=\" | \">\" )"`
 	Comparison         *Comparison         `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // BitwiseDisjunction ...
@@ -75,6 +91,8 @@ type BitwiseDisjunction struct {
 	BitwiseExclusiveDisjunction *BitwiseExclusiveDisjunction `parser:"@@"`
 	Operation                   string                       `parser:"[ @\"|\""`
 	BitwiseDisjunction          *BitwiseDisjunction          `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // BitwiseExclusiveDisjunction ...
@@ -82,6 +100,8 @@ type BitwiseExclusiveDisjunction struct {
 	BitwiseConjunction          *BitwiseConjunction          `parser:"@@"`
 	Operation                   string                       `parser:"[ @\"^\""`
 	BitwiseExclusiveDisjunction *BitwiseExclusiveDisjunction `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // BitwiseConjunction ...
@@ -89,6 +109,8 @@ type BitwiseConjunction struct {
 	Shift              *Shift              `parser:"@@"`
 	Operation          string              `parser:"[ @\"&\""`
 	BitwiseConjunction *BitwiseConjunction `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Shift ...
@@ -96,6 +118,8 @@ type Shift struct {
 	Addition  *Addition `parser:"@@"`
 	Operation string    `parser:"[ @( \"<\" \"<\" | \">\" \">\" [ \">\" ] )"`
 	Shift     *Shift    `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Addition ...
@@ -103,6 +127,8 @@ type Addition struct {
 	Multiplication *Multiplication `parser:"@@"`
 	Operation      string          `parser:"[ @( \"+\" | \"-\" )"`
 	Addition       *Addition       `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Multiplication ...
@@ -110,6 +136,8 @@ type Multiplication struct {
 	Unary          *Unary          `parser:"@@"`
 	Operation      string          `parser:"[ @( \"*\" | \"/\" | \"%\" )"`
 	Multiplication *Multiplication `parser:"@@ ]"`
+
+	Pos lexer.Position
 }
 
 // Unary ...
@@ -123,6 +151,8 @@ type Unary struct {
 type Accessor struct {
 	Atom *Atom          `parser:"@@"`
 	Keys []*AccessorKey `parser:"{ @@ }"`
+
+	Pos lexer.Position
 }
 
 // AccessorKey ...
@@ -139,10 +169,14 @@ type Atom struct {
 	String                *string                `parser:"| @String | @RawString"`
 	ListDefinition        *ListDefinition        `parser:"| @@"`
 	HashTableDefinition   *HashTableDefinition   `parser:"| @@"`
+	Start                 *StartCommand          `parser:"| @@"`
+	Lambda                *Lambda                `parser:"| @@"`
 	FunctionCall          *FunctionCall          `parser:"| @@"`
 	ConditionalExpression *ConditionalExpression `parser:"| @@"`
 	Identifier            *string                `parser:"| @Ident"`
 	Expression            *Expression            `parser:"| \"(\" @@ \")\""`
+
+	Pos lexer.Position
 }
 
 // ListDefinition ...
@@ -162,6 +196,12 @@ type HashTableEntry struct {
 	Value      *Expression `parser:"\":\" @@"`
 }
 
+// Lambda ...
+type Lambda struct {
+	Parameters *IdentifierGroup `parser:"\"func\" \"(\" @@ \")\""`
+	Commands   []*Command       `parser:"{ @@ } \";\""`
+}
+
 // FunctionCall ...
 type FunctionCall struct {
 	Name      string           `parser:"@Ident"`
@@ -211,6 +211,30 @@ func TestParseToAST_withExpression(test *testing.T) {
 			wantAST: &Atom{FunctionCall: &FunctionCall{Name: "test", Arguments: &ExpressionGroup{}}},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Atom/start/identifier",
+			args: args{"start Test(12)", new(Atom)},
+			wantAST: &Atom{
+				Start: &StartCommand{
+					Name: pointer.ToString("Test"),
+					Arguments: &ExpressionGroup{[]*Expression{
+						SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(12)).(*Expression),
+					}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Atom/start/expression",
+			args: args{"start [test]()", new(Atom)},
+			wantAST: &Atom{
+				Start: &StartCommand{
+					Expression: SetInnerField(&Expression{}, "Identifier", pointer.ToString("test")).(*Expression),
+					Arguments:  &ExpressionGroup{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "Atom/function call/few arguments",
 			args: args{"test(12, 23, 42)", new(Atom)},
@@ -226,6 +250,26 @@ func TestParseToAST_withExpression(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name:    "Atom/lambda/empty",
+			args:    args{"func();", new(Atom)},
+			wantAST: &Atom{Lambda: &Lambda{Parameters: &IdentifierGroup{}}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Atom/lambda/nonempty",
+			args: args{"func(x, y) x y;", new(Atom)},
+			wantAST: &Atom{
+				Lambda: &Lambda{
+					Parameters: &IdentifierGroup{Identifiers: []string{"x", "y"}},
+					Commands: []*Command{
+						{Expression: SetInnerField(&Expression{}, "Identifier", pointer.ToString("x")).(*Expression)},
+						{Expression: SetInnerField(&Expression{}, "Identifier", pointer.ToString("y")).(*Expression)},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "Atom/conditional expression/single conditional case/nonempty",
 			args: args{"when => 12 23 42;", new(Atom)},
@@ -772,6 +816,7 @@ func TestParseToAST_withExpression(test *testing.T) {
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			err := ParseToAST(testData.args.code, testData.args.ast)
+			ClearPositions(testData.args.ast)
 
 			assert.Equal(test, testData.wantAST, testData.args.ast)
 			testData.wantErr(test, err)
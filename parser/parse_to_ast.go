@@ -1,18 +1,38 @@
 package parser
 
 import (
+	"strings"
+
 	"github.com/alecthomas/participle"
 	"github.com/pkg/errors"
 )
 
+type namedReader struct {
+	*strings.Reader
+
+	name string
+}
+
+func (reader namedReader) Name() string {
+	return reader.name
+}
+
 // ParseToAST ...
 func ParseToAST(code string, ast interface{}) error {
+	return ParseFileToAST("", code, ast)
+}
+
+// ParseFileToAST ...
+//
+// The filename is used in positions of AST nodes.
+func ParseFileToAST(filename string, code string, ast interface{}) error {
 	parser, err := participle.Build(ast)
 	if err != nil {
 		return errors.Wrap(err, "unable to build the parser")
 	}
 
-	if err := parser.ParseString(code, ast); err != nil {
+	reader := namedReader{Reader: strings.NewReader(code), name: filename}
+	if err := parser.Parse(reader, ast); err != nil {
 		return errors.Wrap(err, "unable to parse the code")
 	}
 
@@ -3,6 +3,8 @@ package parser
 import (
 	"testing"
 
+	"github.com/alecthomas/participle/lexer"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -70,9 +72,22 @@ func TestParseToAST(test *testing.T) {
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			err := ParseToAST(testData.args.code, testData.args.ast)
+			ClearPositions(testData.args.ast)
 
 			assert.Equal(test, testData.wantAST, testData.args.ast)
 			testData.wantErr(test, err)
 		})
 	}
 }
+
+func TestParseFileToAST(test *testing.T) {
+	program := new(Program)
+	err := ParseFileToAST("test.tt", "actor Main();\nactor Test() state test() message test()\n  x\n;;;", program)
+
+	assert.NoError(test, err)
+	assert.Equal(
+		test,
+		lexer.Position{Filename: "test.tt", Offset: 57, Line: 3, Column: 3},
+		program.Definitions[1].Actor.States[0].Messages[0].Commands[0].Pos,
+	)
+}
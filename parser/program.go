@@ -1,5 +1,9 @@
 package parser
 
+import (
+	"github.com/alecthomas/participle/lexer"
+)
+
 // Program ...
 type Program struct {
 	Definitions []*Definition `parser:"{ @@ }"`
@@ -9,6 +13,22 @@ type Program struct {
 type Definition struct {
 	Actor      *Actor      `parser:"@@"`
 	ActorClass *ActorClass `parser:"| @@"`
+	Function   *Function   `parser:"| @@"`
+	Import     *Import     `parser:"| @@"`
+}
+
+// Statement ...
+//
+// It's a single definition or command, e.g. an input of the REPL.
+type Statement struct {
+	Definition *Definition `parser:"@@"`
+	Command    *Command    `parser:"| @@"`
+}
+
+// Import ...
+type Import struct {
+	Path      string  `parser:"\"import\" ( @String | @RawString )"`
+	Namespace *string `parser:"[ \"as\" @Ident ]"`
 }
 
 // Actor ...
@@ -25,10 +45,19 @@ type ActorClass struct {
 	States     []*State         `parser:"{ @@ } \";\""`
 }
 
+// Function ...
+type Function struct {
+	Name       string           `parser:"\"func\" @Ident"`
+	Parameters *IdentifierGroup `parser:"\"(\" @@ \")\""`
+	Commands   []*Command       `parser:"{ @@ } \";\""`
+}
+
 // State ...
 type State struct {
 	Name       string           `parser:"\"state\" @Ident"`
 	Parameters *IdentifierGroup `parser:"\"(\" @@ \")\""`
+	Enter      []*Command       `parser:"[ \"enter\" { @@ } \";\" ]"`
+	Exit       []*Command       `parser:"[ \"exit\" { @@ } \";\" ]"`
 	Messages   []*Message       `parser:"{ @@ } \";\""`
 }
 
@@ -41,12 +70,17 @@ type Message struct {
 
 // Command ...
 type Command struct {
-	Let        *LetCommand   `parser:"@@"`
-	Start      *StartCommand `parser:"| @@"`
-	Send       *SendCommand  `parser:"| @@"`
-	Set        *SetCommand   `parser:"| @@"`
-	Return     bool          `parser:"| @\"return\""`
-	Expression *Expression   `parser:"| @@"`
+	Let        *LetCommand     `parser:"@@"`
+	Start      *StartCommand   `parser:"| @@"`
+	Send       *SendCommand    `parser:"| @@"`
+	Publish    *PublishCommand `parser:"| @@"`
+	Set        *SetCommand     `parser:"| @@"`
+	Stop       *StopCommand    `parser:"| @@"`
+	Defer      bool            `parser:"| @\"defer\""`
+	Return     bool            `parser:"| @\"return\""`
+	Expression *Expression     `parser:"| @@"`
+
+	Pos lexer.Position
 }
 
 // LetCommand ...
@@ -56,18 +90,53 @@ type LetCommand struct {
 }
 
 // StartCommand ...
+//
+// If the actor name is nil, the started actor stays unnamed.
 type StartCommand struct {
 	Name       *string          `parser:"\"start\" ( @Ident"`
 	Expression *Expression      `parser:"| \"[\" @@ \"]\" )"`
 	Arguments  *ExpressionGroup `parser:"\"(\" @@ \")\""`
+	ActorName  *string          `parser:"[ \"as\" @Ident ]"`
 }
 
 // SendCommand ...
+//
+// If the priority is nil, the default one is used.
 type SendCommand struct {
-	Name      string           `parser:"\"send\" @Ident"`
+	Priority  *Expression      `parser:"\"send\" [ \"(\" @@ \")\" ]"`
+	Receiver  *Receiver        `parser:"[ @@ \".\" ]"`
+	Name      string           `parser:"@Ident"`
 	Arguments *ExpressionGroup `parser:"\"(\" @@ \")\""`
 }
 
+// Receiver ...
+type Receiver struct {
+	Name       *string     `parser:"@Ident"`
+	Expression *Expression `parser:"| \"[\" @@ \"]\""`
+}
+
+// PublishCommand ...
+type PublishCommand struct {
+	Topic     *Topic           `parser:"\"publish\" @@ \".\""`
+	Name      string           `parser:"@Ident"`
+	Arguments *ExpressionGroup `parser:"\"(\" @@ \")\""`
+}
+
+// Topic ...
+//
+// The name is used as the topic name itself, not as a variable name.
+type Topic struct {
+	Name       *string     `parser:"@Ident"`
+	Expression *Expression `parser:"| \"[\" @@ \"]\""`
+}
+
+// StopCommand ...
+//
+// If the actor is nil, the command stops the current actor.
+type StopCommand struct {
+	Actor *Expression `parser:"\"stop\" \"(\" [ @@ ] \")\""`
+}
+
 // SetCommand ...
 type SetCommand struct {
 	Name      string           `parser:"\"set\" @Ident"`
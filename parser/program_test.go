@@ -67,6 +67,20 @@ func TestParseToAST_withProgram(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Command/start/actor name",
+			args: args{`start Fork("left") as fork_left`, new(Command)},
+			wantAST: &Command{
+				Start: &StartCommand{
+					Name: pointer.ToString("Fork"),
+					Arguments: &ExpressionGroup{[]*Expression{
+						SetInnerField(&Expression{}, "String", pointer.ToString("left")).(*Expression),
+					}},
+					ActorName: pointer.ToString("fork_left"),
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "Command/send/no arguments",
 			args:    args{"send test()", new(Command)},
@@ -88,6 +102,110 @@ func TestParseToAST_withProgram(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Command/send/receiver/identifier",
+			args: args{"send receiver.test(12)", new(Command)},
+			wantAST: &Command{
+				Send: &SendCommand{
+					Receiver: &Receiver{Name: pointer.ToString("receiver")},
+					Name:     "test",
+					Arguments: &ExpressionGroup{[]*Expression{
+						SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(12)).(*Expression),
+					}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Command/send/receiver/expression",
+			args: args{"send [receivers[0]].test()", new(Command)},
+			wantAST: &Command{
+				Send: &SendCommand{
+					Receiver: &Receiver{
+						Expression: SetInnerField(&Expression{}, "Accessor", &Accessor{
+							Atom: &Atom{Identifier: pointer.ToString("receivers")},
+							Keys: []*AccessorKey{
+								{
+									Expression: SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(
+										0,
+									)).(*Expression),
+								},
+							},
+						}).(*Expression),
+					},
+					Name:      "test",
+					Arguments: &ExpressionGroup{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Command/send/priority",
+			args: args{"send (10) receiver.test()", new(Command)},
+			wantAST: &Command{
+				Send: &SendCommand{
+					Priority:  SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(10)).(*Expression),
+					Receiver:  &Receiver{Name: pointer.ToString("receiver")},
+					Name:      "test",
+					Arguments: &ExpressionGroup{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Command/send/priority/broadcast",
+			args: args{"send (-1) test(12)", new(Command)},
+			wantAST: &Command{
+				Send: &SendCommand{
+					Priority: SetInnerField(&Expression{}, "Unary", &Unary{
+						Operation: "-",
+						Unary:     SetInnerField(&Unary{}, "IntegerNumber", pointer.ToInt64(1)).(*Unary),
+					}).(*Expression),
+					Name: "test",
+					Arguments: &ExpressionGroup{[]*Expression{
+						SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(12)).(*Expression),
+					}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Command/publish/topic/identifier",
+			args: args{"publish topic.test(12)", new(Command)},
+			wantAST: &Command{
+				Publish: &PublishCommand{
+					Topic: &Topic{Name: pointer.ToString("topic")},
+					Name:  "test",
+					Arguments: &ExpressionGroup{[]*Expression{
+						SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(12)).(*Expression),
+					}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Command/publish/topic/expression",
+			args: args{"publish [topics[0]].test()", new(Command)},
+			wantAST: &Command{
+				Publish: &PublishCommand{
+					Topic: &Topic{
+						Expression: SetInnerField(&Expression{}, "Accessor", &Accessor{
+							Atom: &Atom{Identifier: pointer.ToString("topics")},
+							Keys: []*AccessorKey{
+								{
+									Expression: SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(
+										0,
+									)).(*Expression),
+								},
+							},
+						}).(*Expression),
+					},
+					Name:      "test",
+					Arguments: &ExpressionGroup{},
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "Command/set/no arguments",
 			args:    args{"set test()", new(Command)},
@@ -109,6 +227,28 @@ func TestParseToAST_withProgram(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name:    "Command/stop/self",
+			args:    args{"stop()", new(Command)},
+			wantAST: &Command{Stop: &StopCommand{}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Command/stop/actor",
+			args: args{"stop(test)", new(Command)},
+			wantAST: &Command{
+				Stop: &StopCommand{
+					Actor: SetInnerField(&Expression{}, "Identifier", pointer.ToString("test")).(*Expression),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Command/defer",
+			args:    args{"defer", new(Command)},
+			wantAST: &Command{Defer: true},
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "Command/return",
 			args:    args{"return", new(Command)},
@@ -178,10 +318,31 @@ func TestParseToAST_withProgram(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "State/nonempty/hooks",
+			args: args{
+				"state test() enter send one(); exit send two(); message three();;",
+				new(State),
+			},
+			wantAST: &State{
+				Name:       "test",
+				Parameters: &IdentifierGroup{},
+				Enter:      []*Command{{Send: &SendCommand{Name: "one", Arguments: &ExpressionGroup{}}}},
+				Exit:       []*Command{{Send: &SendCommand{Name: "two", Arguments: &ExpressionGroup{}}}},
+				Messages:   []*Message{{"three", &IdentifierGroup{}, nil}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "State/nonempty/empty hooks",
+			args:    args{"state test() enter; exit;;", new(State)},
+			wantAST: &State{Name: "test", Parameters: &IdentifierGroup{}},
+			wantErr: assert.NoError,
+		},
 		{
 			name:    "State/empty",
 			args:    args{"state test();", new(State)},
-			wantAST: &State{"test", &IdentifierGroup{}, nil},
+			wantAST: &State{Name: "test", Parameters: &IdentifierGroup{}},
 			wantErr: assert.NoError,
 		},
 		{
@@ -190,7 +351,10 @@ func TestParseToAST_withProgram(test *testing.T) {
 			wantAST: &Actor{
 				Name:       "Main",
 				Parameters: &IdentifierGroup{},
-				States:     []*State{{"one", &IdentifierGroup{}, nil}, {"two", &IdentifierGroup{}, nil}},
+				States: []*State{
+					{Name: "one", Parameters: &IdentifierGroup{}},
+					{Name: "two", Parameters: &IdentifierGroup{}},
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -200,7 +364,10 @@ func TestParseToAST_withProgram(test *testing.T) {
 			wantAST: &Actor{
 				Name:       "Main",
 				Parameters: &IdentifierGroup{Identifiers: []string{"x", "y", "z"}},
-				States:     []*State{{"one", &IdentifierGroup{}, nil}, {"two", &IdentifierGroup{}, nil}},
+				States: []*State{
+					{Name: "one", Parameters: &IdentifierGroup{}},
+					{Name: "two", Parameters: &IdentifierGroup{}},
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -216,7 +383,10 @@ func TestParseToAST_withProgram(test *testing.T) {
 			wantAST: &ActorClass{
 				Name:       "Main",
 				Parameters: &IdentifierGroup{},
-				States:     []*State{{"one", &IdentifierGroup{}, nil}, {"two", &IdentifierGroup{}, nil}},
+				States: []*State{
+					{Name: "one", Parameters: &IdentifierGroup{}},
+					{Name: "two", Parameters: &IdentifierGroup{}},
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -226,7 +396,10 @@ func TestParseToAST_withProgram(test *testing.T) {
 			wantAST: &ActorClass{
 				Name:       "Main",
 				Parameters: &IdentifierGroup{Identifiers: []string{"x", "y", "z"}},
-				States:     []*State{{"one", &IdentifierGroup{}, nil}, {"two", &IdentifierGroup{}, nil}},
+				States: []*State{
+					{Name: "one", Parameters: &IdentifierGroup{}},
+					{Name: "two", Parameters: &IdentifierGroup{}},
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -236,6 +409,56 @@ func TestParseToAST_withProgram(test *testing.T) {
 			wantAST: &ActorClass{"Main", &IdentifierGroup{}, nil},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Function/nonempty/no parameters",
+			args: args{"func test() send one() send two();", new(Function)},
+			wantAST: &Function{
+				Name:       "test",
+				Parameters: &IdentifierGroup{},
+				Commands: []*Command{
+					{Send: &SendCommand{Name: "one", Arguments: &ExpressionGroup{}}},
+					{Send: &SendCommand{Name: "two", Arguments: &ExpressionGroup{}}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Function/nonempty/few parameters",
+			args: args{"func test(x, y, z) send one() send two();", new(Function)},
+			wantAST: &Function{
+				Name:       "test",
+				Parameters: &IdentifierGroup{Identifiers: []string{"x", "y", "z"}},
+				Commands: []*Command{
+					{Send: &SendCommand{Name: "one", Arguments: &ExpressionGroup{}}},
+					{Send: &SendCommand{Name: "two", Arguments: &ExpressionGroup{}}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Function/empty",
+			args:    args{"func test();", new(Function)},
+			wantAST: &Function{"test", &IdentifierGroup{}, nil},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Import/without a namespace",
+			args:    args{`import "test.tt"`, new(Import)},
+			wantAST: &Import{Path: "test.tt"},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Import/with a namespace",
+			args:    args{`import "test.tt" as test`, new(Import)},
+			wantAST: &Import{Path: "test.tt", Namespace: pointer.ToString("test")},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "Import/with a raw string",
+			args:    args{"import `test.tt`", new(Import)},
+			wantAST: &Import{Path: "test.tt"},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "Definition/actor",
 			args: args{"actor Main() state one(); state two();;", new(Definition)},
@@ -243,7 +466,10 @@ func TestParseToAST_withProgram(test *testing.T) {
 				Actor: &Actor{
 					Name:       "Main",
 					Parameters: &IdentifierGroup{},
-					States:     []*State{{"one", &IdentifierGroup{}, nil}, {"two", &IdentifierGroup{}, nil}},
+					States: []*State{
+						{Name: "one", Parameters: &IdentifierGroup{}},
+						{Name: "two", Parameters: &IdentifierGroup{}},
+					},
 				},
 			},
 			wantErr: assert.NoError,
@@ -255,7 +481,44 @@ func TestParseToAST_withProgram(test *testing.T) {
 				ActorClass: &ActorClass{
 					Name:       "Main",
 					Parameters: &IdentifierGroup{},
-					States:     []*State{{"one", &IdentifierGroup{}, nil}, {"two", &IdentifierGroup{}, nil}},
+					States: []*State{
+						{Name: "one", Parameters: &IdentifierGroup{}},
+						{Name: "two", Parameters: &IdentifierGroup{}},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Definition/function",
+			args: args{"func test(x) x;", new(Definition)},
+			wantAST: &Definition{
+				Function: &Function{
+					Name:       "test",
+					Parameters: &IdentifierGroup{Identifiers: []string{"x"}},
+					Commands: []*Command{
+						{Expression: SetInnerField(&Expression{}, "Identifier", pointer.ToString("x")).(*Expression)},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Definition/import",
+			args: args{`import "test.tt" as test`, new(Definition)},
+			wantAST: &Definition{
+				Import: &Import{Path: "test.tt", Namespace: pointer.ToString("test")},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Program/nonempty/with imports",
+			args: args{`import "one.tt" import "two.tt" as two actor One();`, new(Program)},
+			wantAST: &Program{
+				Definitions: []*Definition{
+					{Import: &Import{Path: "one.tt"}},
+					{Import: &Import{Path: "two.tt", Namespace: pointer.ToString("two")}},
+					{Actor: &Actor{"One", &IdentifierGroup{}, nil}},
 				},
 			},
 			wantErr: assert.NoError,
@@ -277,9 +540,41 @@ func TestParseToAST_withProgram(test *testing.T) {
 			wantAST: new(Program),
 			wantErr: assert.NoError,
 		},
+		{
+			name: "Statement/definition",
+			args: args{"actor Test();", new(Statement)},
+			wantAST: &Statement{
+				Definition: &Definition{Actor: &Actor{"Test", &IdentifierGroup{}, nil}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Statement/command",
+			args: args{"let number = 23", new(Statement)},
+			wantAST: &Statement{
+				Command: &Command{
+					Let: &LetCommand{
+						Identifier: "number",
+						Expression: SetInnerField(&Expression{}, "IntegerNumber", pointer.ToInt64(23)).(*Expression),
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "Statement/expression starting with a keyword",
+			args: args{"actor_number", new(Statement)},
+			wantAST: &Statement{
+				Command: &Command{
+					Expression: SetInnerField(&Expression{}, "Identifier", pointer.ToString("actor_number")).(*Expression),
+				},
+			},
+			wantErr: assert.NoError,
+		},
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			err := ParseToAST(testData.args.code, testData.args.ast)
+			ClearPositions(testData.args.ast)
 
 			assert.Equal(test, testData.wantAST, testData.args.ast)
 			testData.wantErr(test, err)
@@ -8,20 +8,46 @@ import (
 
 // Actor ...
 type Actor struct {
-	states       ParameterizedStateGroup
-	currentState context.State
+	states          ParameterizedStateGroup
+	currentState    context.State
+	transitionCount int
 }
 
 // SetState ...
-func (actor *Actor) SetState(state context.State) error {
+//
+// It runs the exit hook of the current state and then the enter hook
+// of the new one.
+func (actor *Actor) SetState(ctx context.Context, state context.State) error {
 	if !actor.states.Contains(state) {
 		return newUnknownStateError(state)
 	}
 
+	if err := actor.states.ExitState(ctx, actor.currentState); err != nil {
+		return err
+	}
+
 	actor.currentState = state
+	if err := actor.states.EnterState(ctx, state); err != nil {
+		return err
+	}
+
+	actor.transitionCount++
 	return nil
 }
 
+// EnterInitialState ...
+//
+// It runs the enter hook of the initial state, so it should be called
+// on the actor starting.
+func (actor *Actor) EnterInitialState(ctx context.Context, arguments []interface{}) error {
+	return actor.states.ParameterizedEnterState(ctx, arguments, actor.currentState)
+}
+
+// HandlesMessage ...
+func (actor Actor) HandlesMessage(name string) bool {
+	return actor.states.HandlesMessage(actor.currentState, name)
+}
+
 // ProcessMessage ...
 func (actor Actor) ProcessMessage(
 	context context.Context,
@@ -68,5 +94,5 @@ func (factory ActorFactory) MarshalText() (text []byte, err error) {
 
 // CreateActor ...
 func (factory ActorFactory) CreateActor() *Actor {
-	return &Actor{factory.states, factory.initialState}
+	return &Actor{states: factory.states, currentState: factory.initialState}
 }
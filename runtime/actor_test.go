@@ -63,8 +63,8 @@ func TestActor_SetState(test *testing.T) {
 		},
 	} {
 		test.Run(testData.name, func(test *testing.T) {
-			actor := Actor{testData.fields.states, testData.fields.currentState}
-			err := actor.SetState(testData.args.state)
+			actor := Actor{states: testData.fields.states, currentState: testData.fields.currentState}
+			err := actor.SetState(new(MockContext), testData.args.state)
 
 			assert.Equal(test, testData.wantCurrentState, actor.currentState)
 			testData.wantErr(test, err)
@@ -72,6 +72,145 @@ func TestActor_SetState(test *testing.T) {
 	}
 }
 
+func TestActor_SetState_withHooks(test *testing.T) {
+	type args struct {
+		exitOptions  []loggableCommandOption
+		enterOptions []loggableCommandOption
+	}
+
+	for _, testData := range []struct {
+		name             string
+		args             args
+		wantCurrentState context.State
+		wantLog          []int
+		wantErr          assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				exitOptions:  []loggableCommandOption{withCalls()},
+				enterOptions: []loggableCommandOption{withCalls()},
+			},
+			wantCurrentState: context.State{Name: "state_1", Arguments: []interface{}{12}},
+			wantLog:          []int{0, 1, 2, 3},
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "success with a return",
+			args: args{
+				exitOptions:  []loggableCommandOption{withCustomErrOn(ErrReturn, 0)},
+				enterOptions: []loggableCommandOption{withCalls()},
+			},
+			wantCurrentState: context.State{Name: "state_1", Arguments: []interface{}{12}},
+			wantLog:          []int{0, 2, 3},
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "error with the exit hook",
+			args: args{
+				exitOptions:  []loggableCommandOption{withErrOn(0)},
+				enterOptions: nil,
+			},
+			wantCurrentState: context.State{Name: "state_0", Arguments: []interface{}{5}},
+			wantLog:          []int{0},
+			wantErr:          assert.Error,
+		},
+		{
+			name: "error with the enter hook",
+			args: args{
+				exitOptions:  []loggableCommandOption{withCalls()},
+				enterOptions: []loggableCommandOption{withErrOn(1)},
+			},
+			wantCurrentState: context.State{Name: "state_1", Arguments: []interface{}{12}},
+			wantLog:          []int{0, 1, 2, 3},
+			wantErr:          assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			exitContext := new(MockContext)
+			exitContext.On("SetValue", "one", 5).Return()
+
+			enterContext := new(MockContext)
+			enterContext.On("SetValue", "two", 12).Return()
+
+			ctx := new(MockContext)
+			ctx.On("Copy").Return(exitContext).Once()
+			if testData.args.enterOptions != nil {
+				ctx.On("Copy").Return(enterContext).Once()
+			}
+
+			var log commandLog
+			exitHook := newLoggableCommands(exitContext, &log, group(2), testData.args.exitOptions...)
+			enterHook := newLoggableCommands(enterContext, &log, group(2, 2), testData.args.enterOptions...)
+			states := StateGroup{
+				"state_0": NewParameterizedMessageGroup([]string{"one"}, nil).WithHooks(nil, exitHook),
+				"state_1": NewParameterizedMessageGroup([]string{"two"}, nil).WithHooks(enterHook, nil),
+			}
+
+			actor := Actor{
+				states:       ParameterizedStateGroup{StateGroup: states},
+				currentState: context.State{Name: "state_0", Arguments: []interface{}{5}},
+			}
+			err := actor.SetState(ctx, context.State{Name: "state_1", Arguments: []interface{}{12}})
+
+			mock.AssertExpectationsForObjects(test, ctx, exitContext)
+			if testData.args.enterOptions != nil {
+				mock.AssertExpectationsForObjects(test, enterContext)
+			}
+			checkStates(test, states)
+			assert.Equal(test, testData.wantCurrentState, actor.currentState)
+			assert.Equal(test, testData.wantLog, log.commands)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestActor_EnterInitialState(test *testing.T) {
+	hookContext := new(MockContext)
+	hookContext.On("SetValue", "one", 5).Return()
+	hookContext.On("SetValue", "two", 12).Return()
+
+	ctx := new(MockContext)
+	ctx.On("Copy").Return(hookContext)
+
+	var log commandLog
+	enterHook := newLoggableCommands(hookContext, &log, group(2), withCalls())
+	states := StateGroup{
+		"state_0": NewParameterizedMessageGroup([]string{"two"}, nil).WithHooks(enterHook, nil),
+		"state_1": NewParameterizedMessageGroup(nil, nil),
+	}
+
+	actor := Actor{
+		states:       NewParameterizedStateGroup([]string{"one"}, states),
+		currentState: context.State{Name: "state_0", Arguments: []interface{}{12}},
+	}
+	err := actor.EnterInitialState(ctx, []interface{}{5})
+
+	mock.AssertExpectationsForObjects(test, ctx, hookContext)
+	checkStates(test, states)
+	assert.Equal(test, []int{0, 1}, log.commands)
+	assert.NoError(test, err)
+}
+
+func TestActor_HandlesMessage(test *testing.T) {
+	actor := Actor{
+		states: ParameterizedStateGroup{
+			StateGroup: StateGroup{
+				"state_0": {messages: MessageGroup{"message_0": {}}},
+				"state_1": {messages: MessageGroup{"message_1": {}}},
+			},
+		},
+		currentState: context.State{Name: "state_0"},
+	}
+
+	assert.True(test, actor.HandlesMessage("message_0"))
+	assert.False(test, actor.HandlesMessage("message_1"))
+	assert.False(test, actor.HandlesMessage("message_unknown"))
+
+	actor.currentState = context.State{Name: "state_unknown"}
+	assert.False(test, actor.HandlesMessage("message_0"))
+}
+
 func TestActor_ProcessMessage(test *testing.T) {
 	type fields struct {
 		makeStates   func(context context.Context, log *commandLog) ParameterizedStateGroup
@@ -227,7 +366,7 @@ func TestActor_ProcessMessage(test *testing.T) {
 		test.Run(testData.name, func(test *testing.T) {
 			var log commandLog
 			states := testData.fields.makeStates(testData.args.context, &log)
-			actor := Actor{states, testData.fields.currentState}
+			actor := Actor{states: states, currentState: testData.fields.currentState}
 
 			err := actor.ProcessMessage(
 				testData.args.context,
@@ -0,0 +1,46 @@
+package runtime
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// Ask ...
+//
+// It sends the message to the receiver and waits for a reply until the timeout
+// elapses. It returns false if the timeout has elapsed.
+//
+// The request to the sender itself or to all actors fails immediately, because
+// the sender can't reply while it waits, and the reply to the broadcast message
+// is ambiguous.
+//
+// The reply channel has a buffer for a single reply, so the replier isn't blocked
+// even if the timeout has already elapsed.
+func Ask(
+	receiver context.MessageSender,
+	message context.Message,
+	timeout time.Duration,
+) (reply interface{}, ok bool, err error) {
+	if message.Sender != nil && receiver == message.Sender {
+		return nil, false, errors.New("unable to send the request to the sender itself")
+	}
+	if _, isGroup := receiver.(*ConcurrentActorGroup); isGroup {
+		return nil, false, errors.New("unable to broadcast the request")
+	}
+
+	replies := make(chan interface{}, 1)
+	message.Reply = replies
+	receiver.SendMessage(message)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case reply := <-replies:
+		return reply, true, nil
+	case <-timer.C:
+		return nil, false, nil
+	}
+}
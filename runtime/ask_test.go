@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestAsk(test *testing.T) {
+	receiver := new(MockContext)
+	receiver.
+		On("SendMessage", mock.MatchedBy(func(message context.Message) bool {
+			return message.Name == "test" && message.Reply != nil
+		})).
+		Return().
+		Run(func(arguments mock.Arguments) {
+			arguments.Get(0).(context.Message).Reply <- 23
+		})
+
+	gotReply, gotOk, gotErr := Ask(receiver, context.Message{Name: "test"}, time.Hour)
+
+	mock.AssertExpectationsForObjects(test, receiver)
+	assert.Equal(test, 23, gotReply)
+	assert.True(test, gotOk)
+	assert.NoError(test, gotErr)
+}
+
+func TestAsk_withTimeout(test *testing.T) {
+	receiver := new(MockContext)
+	receiver.
+		On("SendMessage", mock.MatchedBy(func(message context.Message) bool {
+			return message.Name == "test" && message.Reply != nil
+		})).
+		Return()
+
+	gotReply, gotOk, gotErr := Ask(receiver, context.Message{Name: "test"}, time.Millisecond)
+
+	mock.AssertExpectationsForObjects(test, receiver)
+	assert.Nil(test, gotReply)
+	assert.False(test, gotOk)
+	assert.NoError(test, gotErr)
+}
+
+func TestAsk_withSender(test *testing.T) {
+	receiver := new(ConcurrentActor)
+
+	message := context.Message{Name: "test", Sender: receiver}
+	gotReply, gotOk, gotErr := Ask(receiver, message, time.Hour)
+
+	assert.Nil(test, gotReply)
+	assert.False(test, gotOk)
+	assert.Error(test, gotErr)
+}
+
+func TestAsk_withGroup(test *testing.T) {
+	receiver := NewConcurrentActorGroup(context.NewDefaultContext())
+
+	gotReply, gotOk, gotErr := Ask(receiver, context.Message{Name: "test"}, time.Hour)
+
+	assert.Nil(test, gotReply)
+	assert.False(test, gotOk)
+	assert.Error(test, gotErr)
+}
@@ -10,18 +10,28 @@ import (
 	"math"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
 	"github.com/thewizardplusplus/tick-tock/runtime/types"
 	"github.com/thewizardplusplus/tick-tock/translator"
 )
 
 type lineBreakMode int
 
+type collectionItem struct {
+	key    interface{}
+	rawKey interface{}
+	value  interface{}
+}
+
+type itemHandler func(item collectionItem) (next bool, err error)
+
 const (
 	withoutLineBreak lineBreakMode = iota
 	withLineBreak
@@ -234,6 +244,12 @@ var (
 				name = "hash"
 			case runtime.ConcurrentActorFactory:
 				name = "class"
+			case *runtime.ConcurrentActor:
+				name = "actor"
+			case *runtime.Timer:
+				name = "timer"
+			case runtime.Function:
+				name = "func"
 			default:
 				return nil, errors.Errorf("unsupported type %T of the argument #0 for the function type", value)
 			}
@@ -313,7 +329,11 @@ var (
 			rand.Seed(int64(seed))
 			return types.Nil{}, nil
 		},
-		"random": func() (float64, error) {
+		"random": func(ctx context.Context) (float64, error) {
+			if actor, ok := selfActor(ctx); ok {
+				return actor.Random(), nil
+			}
+
 			return rand.Float64(), nil // nolint: gosec
 		},
 		"head": func(pair *types.Pair) (interface{}, error) {
@@ -456,6 +476,226 @@ var (
 			keys := table.Keys()
 			return types.NewPairFromSlice(keys), nil
 		},
+		"map": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (interface{}, error) {
+			var items []interface{}
+			table := make(types.HashTable)
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				result, err := callForItem(ctx, function, item)
+				if err != nil {
+					return false, err
+				}
+
+				items = append(items, result)
+				if _, ok := result.(types.Nil); !ok {
+					table[item.rawKey] = result
+				}
+
+				return true, nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to map the collection")
+			}
+
+			if _, ok := collection.(types.HashTable); ok {
+				return table, nil
+			}
+
+			return types.NewPairFromSlice(items), nil
+		},
+		"filter": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (interface{}, error) {
+			var items []interface{}
+			table := make(types.HashTable)
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				matched, err := matchItem(ctx, function, item)
+				if err != nil {
+					return false, err
+				}
+
+				if matched {
+					items = append(items, item.value)
+					table[item.rawKey] = item.value
+				}
+
+				return true, nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to filter the collection")
+			}
+
+			if _, ok := collection.(types.HashTable); ok {
+				return table, nil
+			}
+
+			return types.NewPairFromSlice(items), nil
+		},
+		"reduce": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+			initial interface{},
+		) (interface{}, error) {
+			accumulator := initial
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				arguments := []interface{}{accumulator, item.value, item.key}
+				result, err := expressions.CallValue(ctx, function, arguments)
+				if err != nil {
+					return false, errors.Wrap(err, "unable to call the function")
+				}
+
+				accumulator = result
+				return true, nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to reduce the collection")
+			}
+
+			return accumulator, nil
+		},
+		"each": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (types.Nil, error) {
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				_, err := callForItem(ctx, function, item)
+				return err == nil, err
+			})
+			if err != nil {
+				return types.Nil{}, errors.Wrap(err, "unable to iterate over the collection")
+			}
+
+			return types.Nil{}, nil
+		},
+		"sort_by": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (*types.Pair, error) {
+			var items []interface{}
+			var sortingKeys []interface{}
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				sortingKey, err := callForItem(ctx, function, item)
+				if err != nil {
+					return false, err
+				}
+
+				if _, ok := collection.(types.HashTable); ok {
+					items = append(items, item.key)
+				} else {
+					items = append(items, item.value)
+				}
+				sortingKeys = append(sortingKeys, sortingKey)
+
+				return true, nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to calculate sorting keys of the collection")
+			}
+
+			indices := make([]int, len(items))
+			for index := range indices {
+				indices[index] = index
+			}
+
+			var compareErr error
+			sort.SliceStable(indices, func(i int, j int) bool {
+				result, err := types.Compare(sortingKeys[indices[i]], sortingKeys[indices[j]])
+				if err != nil && compareErr == nil {
+					compareErr = err
+				}
+
+				return result == types.Less
+			})
+			if compareErr != nil {
+				return nil, errors.Wrap(compareErr, "unable to compare sorting keys of the collection")
+			}
+
+			var sortedItems []interface{}
+			for _, index := range indices {
+				sortedItems = append(sortedItems, items[index])
+			}
+
+			return types.NewPairFromSlice(sortedItems), nil
+		},
+		"find": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (interface{}, error) {
+			var foundItem interface{} = types.Nil{}
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				matched, err := matchItem(ctx, function, item)
+				if err != nil {
+					return false, err
+				}
+				if !matched {
+					return true, nil
+				}
+
+				if _, ok := collection.(types.HashTable); ok {
+					foundItem = item.key
+				} else {
+					foundItem = item.value
+				}
+
+				return false, nil
+			})
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to find the item in the collection")
+			}
+
+			return foundItem, nil
+		},
+		"any": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (types.Boolean, error) {
+			result := types.False
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				matched, err := matchItem(ctx, function, item)
+				if err != nil {
+					return false, err
+				}
+
+				result = types.NewBooleanFromGoBool(matched)
+				return !matched, nil
+			})
+			if err != nil {
+				return types.False, errors.Wrap(err, "unable to check items of the collection")
+			}
+
+			return result, nil
+		},
+		"all": func(
+			ctx context.Context,
+			collection interface{},
+			function interface{},
+		) (types.Boolean, error) {
+			result := types.True
+			err := iterate(collection, func(item collectionItem) (bool, error) {
+				matched, err := matchItem(ctx, function, item)
+				if err != nil {
+					return false, err
+				}
+
+				result = types.NewBooleanFromGoBool(matched)
+				return matched, nil
+			})
+			if err != nil {
+				return types.False, errors.Wrap(err, "unable to check items of the collection")
+			}
+
+			return result, nil
+		},
 		"env": func(name *types.Pair) (interface{}, error) {
 			nameText, err := name.Text()
 			if err != nil {
@@ -469,7 +709,11 @@ var (
 
 			return types.NewPairFromText(value), nil
 		},
-		"time": func() (float64, error) {
+		"time": func(ctx context.Context) (float64, error) {
+			if actor, ok := selfActor(ctx); ok {
+				return actor.Time(), nil
+			}
+
 			timestamp := time.Now().UnixNano()
 			return float64(timestamp) / 1e9, nil
 		},
@@ -477,10 +721,135 @@ var (
 			time.Sleep(time.Duration(duration * 1e9))
 			return types.Nil{}, nil
 		},
+		"send_after": func(
+			ctx context.Context,
+			delay float64,
+			receiver interface{},
+			name *types.Pair,
+			arguments *types.Pair,
+		) (*runtime.Timer, error) {
+			return startTimer(ctx, delay, 0, receiver, name, arguments)
+		},
+		"send_every": func(
+			ctx context.Context,
+			interval float64,
+			receiver interface{},
+			name *types.Pair,
+			arguments *types.Pair,
+		) (*runtime.Timer, error) {
+			if interval <= 0 {
+				return nil, errors.New("the interval should be positive")
+			}
+
+			return startTimer(ctx, interval, interval, receiver, name, arguments)
+		},
+		"cancel": func(timer *runtime.Timer) (types.Boolean, error) {
+			return types.NewBooleanFromGoBool(timer.Cancel()), nil
+		},
+		"ask": func(
+			ctx context.Context,
+			timeout float64,
+			receiver context.Actor,
+			name *types.Pair,
+			arguments *types.Pair,
+		) (interface{}, error) {
+			if timeout <= 0 {
+				return nil, errors.New("the timeout should be positive")
+			}
+
+			nameText, err := name.Text()
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to convert the message name to a string")
+			}
+
+			message := context.Message{Name: nameText, Arguments: arguments.Slice()}
+			if self, ok := ctx.Value(runtime.SelfValueName); ok {
+				message.Sender, _ = self.(context.Actor)
+
+				// the receiver can't reply while the sender occupies their shared goroutine
+				if actor, ok := self.(*runtime.ConcurrentActor); ok && actor.SharesGoroutine() {
+					return nil, errors.New("requests aren't available to actors sharing the goroutine " +
+						"(e.g. with the round-robin scheduler)")
+				}
+			}
+
+			reply, ok, err := runtime.Ask(receiver, message, time.Duration(timeout*1e9))
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to ask the actor")
+			}
+			if !ok {
+				return types.Nil{}, nil
+			}
+
+			return reply, nil
+		},
+		"reply": func(ctx context.Context, value interface{}) (types.Boolean, error) {
+			self, _ := ctx.Value(runtime.SelfValueName)
+			actor, ok := self.(*runtime.ConcurrentActor)
+			if !ok {
+				return types.False, errors.New("replies are available only inside actors")
+			}
+
+			return types.NewBooleanFromGoBool(actor.Reply(value)), nil
+		},
+		"subscribe": func(ctx context.Context, topic *types.Pair) (types.Nil, error) {
+			topicText, actor, err := prepareSubscription(ctx, topic)
+			if err != nil {
+				return types.Nil{}, err
+			}
+
+			ctx.SubscribeActor(topicText, actor)
+			return types.Nil{}, nil
+		},
+		"unsubscribe": func(ctx context.Context, topic *types.Pair) (types.Nil, error) {
+			topicText, actor, err := prepareSubscription(ctx, topic)
+			if err != nil {
+				return types.Nil{}, err
+			}
+
+			ctx.UnsubscribeActor(topicText, actor)
+			return types.Nil{}, nil
+		},
+		"whereis": func(ctx context.Context, name *types.Pair) (interface{}, error) {
+			nameText, err := name.Text()
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to convert the actor name to a string")
+			}
+
+			actor, ok := ctx.LookupActor(nameText)
+			if !ok {
+				return types.Nil{}, nil
+			}
+
+			return actor, nil
+		},
 		"exit": func(exitCode float64) (types.Nil, error) {
 			os.Exit(int(exitCode))
 			return types.Nil{}, nil
 		},
+		"restart": func(actor *runtime.ConcurrentActor) (types.Nil, error) {
+			actor.Restart()
+			return types.Nil{}, nil
+		},
+		"escalate": func(reason *types.Pair) (types.Nil, error) {
+			reasonText, err := reason.Text()
+			if err != nil {
+				return types.Nil{}, errors.Wrap(err, "unable to convert the list to a string")
+			}
+
+			return types.Nil{}, errors.New(reasonText)
+		},
+		"inbox_stats": func(actor *runtime.ConcurrentActor) (types.HashTable, error) {
+			stats := actor.InboxStats()
+			return types.HashTable{
+				"size":     float64(stats.Size),
+				"capacity": float64(stats.Capacity),
+				"accepted": float64(stats.Accepted),
+				"blocked":  float64(stats.Blocked),
+				"dropped":  float64(stats.Dropped),
+				"rejected": float64(stats.Rejected),
+			}, nil
+		},
 		"in": func(count float64) (interface{}, error) {
 			if count >= 0 {
 				return readChunk(count)
@@ -525,6 +894,13 @@ var (
 	}
 )
 
+// the values of the random and time functions are recorded by the current actor
+func selfActor(ctx context.Context) (*runtime.ConcurrentActor, bool) {
+	self, _ := ctx.Value(runtime.SelfValueName)
+	actor, ok := self.(*runtime.ConcurrentActor)
+	return actor, ok
+}
+
 func marshalToJSON(value interface{}) (string, error) {
 	var err error
 	value, err = types.GetDeepValue(value)
@@ -570,3 +946,156 @@ func print(writer io.Writer, text *types.Pair, mode lineBreakMode) (types.Nil, e
 
 	return types.Nil{}, nil
 }
+
+func iterate(collection interface{}, handler itemHandler) error {
+	switch typedCollection := collection.(type) {
+	case *types.Pair:
+		for index, value := range typedCollection.Slice() {
+			key := float64(index)
+			next, err := handler(collectionItem{key: key, rawKey: key, value: value})
+			if err != nil {
+				return errors.Wrapf(err, "unable to process the item #%d", index)
+			}
+			if !next {
+				break
+			}
+		}
+	case types.HashTable:
+		for _, rawKey := range sortHashTableKeys(typedCollection) {
+			key := rawKey
+			if keyAsString, ok := rawKey.(string); ok {
+				key = types.NewPairFromText(keyAsString)
+			}
+
+			value := typedCollection[rawKey]
+			next, err := handler(collectionItem{key: key, rawKey: rawKey, value: value})
+			if err != nil {
+				return errors.Wrapf(err, "unable to process the item with the key %v", rawKey)
+			}
+			if !next {
+				break
+			}
+		}
+	default:
+		return errors.Errorf("unsupported type %T of the collection", collection)
+	}
+
+	return nil
+}
+
+func sortHashTableKeys(table types.HashTable) []interface{} {
+	keys := make([]interface{}, 0, len(table))
+	for key := range table {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i int, j int) bool {
+		leftRank, rightRank := getKeyRank(keys[i]), getKeyRank(keys[j])
+		if leftRank != rightRank {
+			return leftRank < rightRank
+		}
+
+		switch leftKey := keys[i].(type) {
+		case float64:
+			return leftKey < keys[j].(float64)
+		case string:
+			return leftKey < keys[j].(string)
+		default:
+			return false
+		}
+	})
+
+	return keys
+}
+
+func getKeyRank(key interface{}) int {
+	switch key.(type) {
+	case types.Nil:
+		return 0
+	case float64:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func startTimer(
+	ctx context.Context,
+	delay float64,
+	interval float64,
+	receiver interface{},
+	name *types.Pair,
+	arguments *types.Pair,
+) (*runtime.Timer, error) {
+	self, _ := ctx.Value(runtime.SelfValueName)
+	actor, ok := self.(*runtime.ConcurrentActor)
+	if !ok {
+		return nil, errors.New("timers are available only inside actors")
+	}
+
+	var sender context.MessageSender
+	switch typedReceiver := receiver.(type) {
+	case types.Nil:
+		sender = ctx
+	case context.Actor:
+		sender = typedReceiver
+	default:
+		return nil, errors.Errorf("unsupported type %T of the receiver for the timer", receiver)
+	}
+
+	nameText, err := name.Text()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert the message name to a string")
+	}
+
+	message := context.Message{Name: nameText, Arguments: arguments.Slice(), Sender: actor}
+	timer := actor.StartTimer(
+		sender,
+		message,
+		time.Duration(delay*1e9),
+		time.Duration(interval*1e9),
+	)
+	return timer, nil
+}
+
+func prepareSubscription(ctx context.Context, topic *types.Pair) (string, context.Actor, error) {
+	self, _ := ctx.Value(runtime.SelfValueName)
+	actor, ok := self.(context.Actor)
+	if !ok {
+		return "", nil, errors.New("subscriptions are available only inside actors")
+	}
+
+	topicText, err := topic.Text()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "unable to convert the topic to a string")
+	}
+
+	return topicText, actor, nil
+}
+
+func callForItem(
+	ctx context.Context,
+	function interface{},
+	item collectionItem,
+) (interface{}, error) {
+	result, err := expressions.CallValue(ctx, function, []interface{}{item.value, item.key})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to call the function")
+	}
+
+	return result, nil
+}
+
+func matchItem(ctx context.Context, function interface{}, item collectionItem) (bool, error) {
+	result, err := callForItem(ctx, function, item)
+	if err != nil {
+		return false, err
+	}
+
+	matched, err := types.NewBoolean(result)
+	if err != nil {
+		return false, errors.Wrap(err, "unable to convert the function result to boolean")
+	}
+
+	return matched == types.True, nil
+}
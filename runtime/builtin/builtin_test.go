@@ -8,9 +8,11 @@ import (
 	"math/rand"
 	"os"
 	"os/exec"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thewizardplusplus/tick-tock/parser"
@@ -475,6 +477,41 @@ func TestValues(test *testing.T) {
 			wantResult: types.NewPairFromText("class"),
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "type/success/actor",
+			code: "type(actor)",
+			additionalDefinitions: context.ValueGroup{
+				"actor": func() *runtime.ConcurrentActor {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+						context.State{Name: "state_0"},
+					)
+					return runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{}).
+						CreateActor()
+				}(),
+			},
+			wantResult: types.NewPairFromText("actor"),
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "type/success/timer",
+			code: "type(timer)",
+			additionalDefinitions: context.ValueGroup{
+				"timer": runtime.NewTimer(nil, context.Message{}, 0, 0, nil),
+			},
+			wantResult: types.NewPairFromText("timer"),
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "type/success/function",
+			code: "type(function)",
+			additionalDefinitions: context.ValueGroup{
+				"function": runtime.NewFunction(nil, nil),
+			},
+			wantResult: types.NewPairFromText("func"),
+			wantErr:    assert.NoError,
+		},
 		{
 			name:       "type/error",
 			code:       "type(type)",
@@ -869,6 +906,200 @@ func TestValues(test *testing.T) {
 			wantResult: nil,
 			wantErr:    assert.Error,
 		},
+		{
+			name:       "map/success/list",
+			code:       "map([2, 3, 4], func(item, index) item * index;)",
+			wantResult: types.NewPairFromSlice([]interface{}{0.0, 3.0, 8.0}),
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "map/success/empty list",
+			code:       "map([], func(item) item * 2;)",
+			wantResult: (*types.Pair)(nil),
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "map/success/hash table",
+			code:       "map({x: 12, y: 23}, func(value) value * 2;)",
+			wantResult: types.HashTable{"x": 24.0, "y": 46.0},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "map/success/hash table/nil results",
+			code:       "map({x: 12, y: 23}, func(value) when => value > 15 value;;)",
+			wantResult: types.HashTable{"y": 23.0},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "map/success/Go function",
+			additionalDefinitions: context.ValueGroup{
+				"double": func(item float64, index float64) (float64, error) {
+					return item * 2, nil
+				},
+			},
+			code:       "map([2, 3, 4], double)",
+			wantResult: types.NewPairFromSlice([]interface{}{4.0, 6.0, 8.0}),
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "map/error/incorrect collection type",
+			code:       "map(23, func(item) item * 2;)",
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "map/error/incorrect function type",
+			code:       "map([2, 3, 4], 23)",
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "map/error/function calling",
+			code:       `map([2, 3, 4], func(item) item * "test";)`,
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "filter/success/list",
+			code:       "filter([2, 3, 4, 5], func(item) item % 2;)",
+			wantResult: types.NewPairFromSlice([]interface{}{3.0, 5.0}),
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "filter/success/hash table",
+			code:       `filter({x: 12, y: 23, z: 42}, func(value, key) key != "y";)`,
+			wantResult: types.HashTable{"x": 12.0, "z": 42.0},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "filter/error/boolean conversion",
+			code:       "filter([2, 3, 4], func(item) __neg__;)",
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "reduce/success/list",
+			code:       "reduce([2, 3, 4], func(sum, item) sum + item;, 0)",
+			wantResult: 9.0,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "reduce/success/hash table",
+			code:       `reduce({x: 12, y: 23, z: 42}, func(keys, value, key) keys + key;, "")`,
+			wantResult: types.NewPairFromText("xyz"),
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "reduce/success/empty list",
+			code:       "reduce([], func(sum, item) sum + item;, 23)",
+			wantResult: 23.0,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "reduce/error",
+			code:       `reduce([2, 3, 4], func(sum, item) sum + item;, "test")`,
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "each/success",
+			code:       "each([2, 3, 4], func(item) item * 2;)",
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "each/error",
+			code:       `each([2, 3, 4], func(item) item * "test";)`,
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "sort_by/success/list",
+			code: `sort_by(["one", "two", "three", "four"], func(item) size(item);)`,
+			wantResult: types.NewPairFromSlice([]interface{}{
+				types.NewPairFromText("one"),
+				types.NewPairFromText("two"),
+				types.NewPairFromText("four"),
+				types.NewPairFromText("three"),
+			}),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "sort_by/success/hash table",
+			code: "sort_by({x: 42, y: 12, z: 23}, func(value) value;)",
+			wantResult: types.NewPairFromSlice([]interface{}{
+				types.NewPairFromText("y"),
+				types.NewPairFromText("z"),
+				types.NewPairFromText("x"),
+			}),
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "sort_by/error/incomparable keys",
+			code:       `sort_by([2, 3, 4], func(item) when => item == 3 "test" => true item;;)`,
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "find/success/list",
+			code:       "find([2, 3, 4, 5], func(item) item > 3;)",
+			wantResult: 4.0,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "find/success/hash table",
+			code:       "find({x: 12, y: 23, z: 42}, func(value) value > 15;)",
+			wantResult: types.NewPairFromText("y"),
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "find/success/not found",
+			code:       "find([2, 3, 4], func(item) item > 5;)",
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "any/success/true",
+			code:       "any([2, 3, 4], func(item) item > 3;)",
+			wantResult: types.True,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "any/success/false",
+			code:       "any([2, 3, 4], func(item) item > 5;)",
+			wantResult: types.False,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "any/success/empty list",
+			code:       "any([], func(item) item > 5;)",
+			wantResult: types.False,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "all/success/true",
+			code:       "all({x: 12, y: 23}, func(value) value > 10;)",
+			wantResult: types.True,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "all/success/false",
+			code:       "all([2, 3, 4], func(item) item > 2;)",
+			wantResult: types.False,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "all/success/empty list",
+			code:       "all([], func(item) item > 5;)",
+			wantResult: types.True,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "all/error",
+			code:       `all([2, 3, 4], func(item) item > "test";)`,
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			ctx := context.NewDefaultContext()
@@ -1019,6 +1250,38 @@ func TestValues_random(test *testing.T) {
 	assert.InDeltaSlice(test, wantNumbers, numbers, 1e-6)
 }
 
+func TestValues_withReplayedValues(test *testing.T) {
+	actorFactory, _ := runtime.NewActorFactory(
+		"Test",
+		runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+		context.State{Name: "state_0"},
+	)
+
+	scheduler, err := runtime.NewReplayScheduler([]runtime.Record{
+		{Kind: runtime.StartRecordKind, Actor: 1000, Class: "Test"},
+		{Kind: runtime.RandomRecordKind, Actor: 1000, Value: 0.25},
+		{Kind: runtime.TimeRecordKind, Actor: 1000, Value: 23},
+	}, nil)
+	require.NoError(test, err)
+
+	dependencies := runtime.Dependencies{Recorder: scheduler}
+	actor := runtime.NewConcurrentActorFactory(actorFactory, 0, dependencies).CreateActor()
+	err = scheduler.RecordStart(actor)
+	require.NoError(test, err)
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, actor)
+
+	gotRandom, gotRandomErr := expressions.NewFunctionCall("random", nil).Evaluate(ctx)
+	gotTime, gotTimeErr := expressions.NewFunctionCall("time", nil).Evaluate(ctx)
+
+	assert.Equal(test, 0.25, gotRandom)
+	assert.NoError(test, gotRandomErr)
+	assert.Equal(test, 23.0, gotTime)
+	assert.NoError(test, gotTimeErr)
+}
+
 func TestValues_keys(test *testing.T) {
 	for _, data := range []struct {
 		name string
@@ -1175,6 +1438,430 @@ func TestValues_sleep(test *testing.T) {
 	assert.NoError(test, err)
 }
 
+func TestValues_sendAfter(test *testing.T) {
+	var waiter sync.WaitGroup
+	self := newTestActor(&waiter)
+	receiver := &recordingActor{messages: make(chan context.Message, 1)}
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, self)
+	ctx.SetValue("receiver", receiver)
+	ctx.SetValue("arguments", &types.Pair{Head: 23.0})
+
+	expression := expressions.NewFunctionCall("send_after", []expressions.Expression{
+		expressions.NewNumber(0.01),
+		expressions.NewIdentifier("receiver"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier("arguments"),
+	})
+	result, err := expression.Evaluate(ctx)
+	waiter.Wait()
+
+	if assert.NoError(test, err) {
+		require.IsType(test, (*runtime.Timer)(nil), result)
+		assert.False(test, result.(*runtime.Timer).Cancel())
+	}
+
+	wantMessage := context.Message{Name: "test", Arguments: []interface{}{23.0}, Sender: self}
+	assert.Equal(test, wantMessage, <-receiver.messages)
+}
+
+func TestValues_sendEvery(test *testing.T) {
+	var waiter sync.WaitGroup
+	self := newTestActor(&waiter)
+	receiver := &recordingActor{messages: make(chan context.Message, 10)}
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, self)
+	ctx.SetValue("receiver", receiver)
+
+	expression := expressions.NewFunctionCall("send_every", []expressions.Expression{
+		expressions.NewNumber(0.01),
+		expressions.NewIdentifier("receiver"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+	require.NoError(test, err)
+	require.IsType(test, (*runtime.Timer)(nil), result)
+
+	wantMessage := context.Message{Name: "test", Sender: self}
+	for i := 0; i < 2; i++ {
+		assert.Equal(test, wantMessage, <-receiver.messages)
+	}
+
+	assert.True(test, result.(*runtime.Timer).Cancel())
+	waiter.Wait()
+}
+
+func TestValues_sendEvery_withIncorrectInterval(test *testing.T) {
+	var waiter sync.WaitGroup
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, newTestActor(&waiter))
+
+	expression := expressions.NewFunctionCall("send_every", []expressions.Expression{
+		expressions.NewNumber(0),
+		expressions.NewIdentifier(runtime.SelfValueName),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_sendAfter_withoutActor(test *testing.T) {
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+
+	expression := expressions.NewFunctionCall("send_after", []expressions.Expression{
+		expressions.NewNumber(0.01),
+		expressions.NewIdentifier("nil"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_cancel(test *testing.T) {
+	var waiter sync.WaitGroup
+	self := newTestActor(&waiter)
+	timer := self.StartTimer(self, context.Message{Name: "test"}, time.Hour, 0)
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue("timer", timer)
+
+	expression := expressions.NewFunctionCall("cancel", []expressions.Expression{
+		expressions.NewIdentifier("timer"),
+	})
+	firstResult, firstErr := expression.Evaluate(ctx)
+	secondResult, secondErr := expression.Evaluate(ctx)
+	waiter.Wait()
+
+	assert.Equal(test, types.True, firstResult)
+	assert.NoError(test, firstErr)
+	assert.Equal(test, types.False, secondResult)
+	assert.NoError(test, secondErr)
+}
+
+func TestValues_ask(test *testing.T) {
+	var waiter sync.WaitGroup
+	self := newTestActor(&waiter)
+	receiver := &recordingActor{messages: make(chan context.Message, 1)}
+	go func() {
+		message := <-receiver.messages
+		message.Reply <- 42.0
+	}()
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, self)
+	ctx.SetValue("receiver", receiver)
+
+	expression := expressions.NewFunctionCall("ask", []expressions.Expression{
+		expressions.NewNumber(1),
+		expressions.NewIdentifier("receiver"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Equal(test, 42.0, result)
+	assert.NoError(test, err)
+}
+
+func TestValues_ask_withTimeout(test *testing.T) {
+	receiver := &recordingActor{messages: make(chan context.Message, 1)}
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue("receiver", receiver)
+	ctx.SetValue("arguments", &types.Pair{Head: 23.0})
+
+	expression := expressions.NewFunctionCall("ask", []expressions.Expression{
+		expressions.NewNumber(0.01),
+		expressions.NewIdentifier("receiver"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier("arguments"),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Equal(test, types.Nil{}, result)
+	assert.NoError(test, err)
+
+	message := <-receiver.messages
+	assert.Equal(test, "test", message.Name)
+	assert.Equal(test, []interface{}{23.0}, message.Arguments)
+	assert.Nil(test, message.Sender)
+}
+
+func TestValues_ask_withSelf(test *testing.T) {
+	var waiter sync.WaitGroup
+	self := newTestActor(&waiter)
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, self)
+
+	expression := expressions.NewFunctionCall("ask", []expressions.Expression{
+		expressions.NewNumber(1),
+		expressions.NewIdentifier(runtime.SelfValueName),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_ask_withSharedGoroutine(test *testing.T) {
+	var waiter sync.WaitGroup
+	self := newTestActor(&waiter)
+	runtime.NewRoundRobinScheduler(0).ScheduleActor(self, context.NewDefaultContext(), nil)
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, self)
+	ctx.SetValue("receiver", &recordingActor{messages: make(chan context.Message, 1)})
+
+	expression := expressions.NewFunctionCall("ask", []expressions.Expression{
+		expressions.NewNumber(1),
+		expressions.NewIdentifier("receiver"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_ask_withIncorrectTimeout(test *testing.T) {
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue("receiver", &recordingActor{messages: make(chan context.Message, 1)})
+
+	expression := expressions.NewFunctionCall("ask", []expressions.Expression{
+		expressions.NewNumber(0),
+		expressions.NewIdentifier("receiver"),
+		expressions.NewString("test"),
+		expressions.NewIdentifier(translator.EmptyListConstantName),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_reply(test *testing.T) {
+	var waiter sync.WaitGroup
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, newTestActor(&waiter))
+
+	expression := expressions.NewFunctionCall("reply", []expressions.Expression{
+		expressions.NewNumber(23),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	// the actor doesn't process a message that waits for a reply
+	assert.Equal(test, types.False, result)
+	assert.NoError(test, err)
+}
+
+func TestValues_reply_withoutActor(test *testing.T) {
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+
+	expression := expressions.NewFunctionCall("reply", []expressions.Expression{
+		expressions.NewNumber(23),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_subscribe(test *testing.T) {
+	actor := &recordingActor{messages: make(chan context.Message, 1)}
+	registry := new(runtime.TopicRegistry)
+	ctx := context.NewDefaultContext()
+	ctx.SetTopicRegister(registry)
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, actor)
+
+	expression := expressions.NewFunctionCall("subscribe", []expressions.Expression{
+		expressions.NewString("topic"),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Equal(test, types.Nil{}, result)
+	assert.NoError(test, err)
+
+	registry.PublishMessage("topic", context.Message{Name: "test"})
+	message := <-actor.messages
+	assert.Equal(test, "test", message.Name)
+}
+
+func TestValues_subscribe_withoutActor(test *testing.T) {
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+
+	expression := expressions.NewFunctionCall("subscribe", []expressions.Expression{
+		expressions.NewString("topic"),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	assert.Error(test, err)
+}
+
+func TestValues_unsubscribe(test *testing.T) {
+	actor := &recordingActor{messages: make(chan context.Message, 1)}
+	registry := new(runtime.TopicRegistry)
+	registry.SubscribeActor("topic", actor)
+
+	ctx := context.NewDefaultContext()
+	ctx.SetTopicRegister(registry)
+	context.SetValues(ctx, Values)
+	ctx.SetValue(runtime.SelfValueName, actor)
+
+	expression := expressions.NewFunctionCall("unsubscribe", []expressions.Expression{
+		expressions.NewString("topic"),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Equal(test, types.Nil{}, result)
+	assert.NoError(test, err)
+
+	registry.PublishMessage("topic", context.Message{Name: "test"})
+	assert.Empty(test, actor.messages)
+}
+
+func TestValues_whereis(test *testing.T) {
+	actor := &recordingActor{}
+	registry := new(runtime.NameRegistry)
+	registry.RegisterActorName("test", actor) // nolint: errcheck
+
+	for _, testData := range []struct {
+		name       string
+		actorName  string
+		wantResult interface{}
+	}{
+		{
+			name:       "success with a registered name",
+			actorName:  "test",
+			wantResult: actor,
+		},
+		{
+			name:       "success with an unknown name",
+			actorName:  "unknown",
+			wantResult: types.Nil{},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			ctx := context.NewDefaultContext()
+			ctx.SetNameRegister(registry)
+			context.SetValues(ctx, Values)
+
+			expression := expressions.NewFunctionCall("whereis", []expressions.Expression{
+				expressions.NewString(testData.actorName),
+			})
+			result, err := expression.Evaluate(ctx)
+
+			assert.Equal(test, testData.wantResult, result)
+			assert.NoError(test, err)
+		})
+	}
+}
+
+func TestValues_restart(test *testing.T) {
+	actorFactory, _ := runtime.NewActorFactory(
+		"Test",
+		runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+		context.State{Name: "state_0"},
+	)
+
+	var waiter sync.WaitGroup
+	dependencies := runtime.Dependencies{WaitGroup: &waiter}
+	actor := runtime.NewConcurrentActorFactory(actorFactory, 0, dependencies).CreateActor()
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue("test", actor)
+	go actor.Start(ctx, nil)
+
+	expression := expressions.NewFunctionCall("restart", []expressions.Expression{
+		expressions.NewIdentifier("test"),
+	})
+	result, err := expression.Evaluate(ctx)
+	waiter.Wait()
+
+	assert.Equal(test, types.Nil{}, result)
+	assert.NoError(test, err)
+}
+
+func TestValues_escalate(test *testing.T) {
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+
+	expression := expressions.NewFunctionCall("escalate", []expressions.Expression{
+		expressions.NewString("test"),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	assert.Nil(test, result)
+	if assert.Error(test, err) {
+		assert.Equal(test, "test", errors.Cause(err).Error())
+	}
+}
+
+func TestValues_inboxStats(test *testing.T) {
+	actorFactory, _ := runtime.NewActorFactory(
+		"Test",
+		runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+		context.State{Name: "state_0"},
+	)
+
+	var waiter sync.WaitGroup
+	dependencies := runtime.Dependencies{WaitGroup: &waiter}
+	actor := runtime.NewConcurrentActorFactory(actorFactory, 2, dependencies).
+		WithInboxPolicy(runtime.DropNewestInboxPolicy).
+		CreateActor()
+	for _, name := range []string{"one", "two", "three"} {
+		actor.SendMessage(context.Message{Name: name})
+	}
+
+	ctx := context.NewDefaultContext()
+	context.SetValues(ctx, Values)
+	ctx.SetValue("test", actor)
+
+	expression := expressions.NewFunctionCall("inbox_stats", []expressions.Expression{
+		expressions.NewIdentifier("test"),
+	})
+	result, err := expression.Evaluate(ctx)
+
+	wantResult := types.HashTable{
+		"size":     2.0,
+		"capacity": 2.0,
+		"accepted": 2.0,
+		"blocked":  0.0,
+		"dropped":  1.0,
+		"rejected": 0.0,
+	}
+	assert.Equal(test, wantResult, result)
+	assert.NoError(test, err)
+}
+
 // based on https://talks.golang.org/2014/testing.slide#23 by Andrew Gerrand
 func TestValues_exit(test *testing.T) {
 	if os.Getenv("EXIT_TEST") == "TRUE" {
@@ -1567,3 +2254,23 @@ func TestValues_output(test *testing.T) {
 		})
 	}
 }
+
+type recordingActor struct {
+	messages chan context.Message
+}
+
+func (actor *recordingActor) SendMessage(message context.Message) {
+	actor.messages <- message
+}
+
+func (actor *recordingActor) Start(context context.Context, arguments []interface{}) {}
+
+func newTestActor(waiter *sync.WaitGroup) *runtime.ConcurrentActor {
+	actorFactory, _ := runtime.NewActorFactory(
+		"Test",
+		runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+		context.State{Name: "state_0"},
+	)
+	dependencies := runtime.Dependencies{WaitGroup: waiter}
+	return runtime.NewConcurrentActorFactory(actorFactory, 0, dependencies).CreateActor()
+}
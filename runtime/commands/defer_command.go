@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+// DeferCommand ...
+//
+// It defers the message being processed by the current actor.
+type DeferCommand struct{}
+
+// Run ...
+func (command DeferCommand) Run(ctx context.Context) (result interface{}, err error) {
+	actor, _ := ctx.Value(runtime.SelfValueName)
+	typedActor, ok := actor.(*runtime.ConcurrentActor)
+	if !ok {
+		return nil, errors.Errorf("unsupported type %T of the actor for the defer command", actor)
+	}
+
+	if err := typedActor.DeferMessage(); err != nil {
+		return nil, errors.Wrap(err, "unable to defer the message")
+	}
+
+	return types.Nil{}, nil
+}
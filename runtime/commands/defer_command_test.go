@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestDeferCommand(test *testing.T) {
+	type args struct {
+		context context.Context
+	}
+
+	actorFactory, _ := runtime.NewActorFactory(
+		"Test",
+		runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+		context.State{Name: "state_0"},
+	)
+	dependencies := runtime.Dependencies{WaitGroup: new(sync.WaitGroup)}
+	concurrentActorFactory := runtime.NewConcurrentActorFactory(actorFactory, 1, dependencies)
+
+	for _, testData := range []struct {
+		name       string
+		args       args
+		wantResult interface{}
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "error without a message",
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.
+						On("Value", runtime.SelfValueName).
+						Return(concurrentActorFactory.CreateActor(), true)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an incorrect type",
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(nil, false)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			gotResult, gotErr := DeferCommand{}.Run(testData.args.context)
+
+			mock.AssertExpectationsForObjects(test, testData.args.context)
+			assert.Equal(test, testData.wantResult, gotResult)
+			testData.wantErr(test, gotErr)
+		})
+	}
+}
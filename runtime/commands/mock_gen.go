@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
 )
@@ -15,6 +16,16 @@ type Context interface {
 	context.Context
 }
 
+//go:generate mockery --name=Actor --inpackage --case=underscore --testonly
+
+// Actor ...
+//
+// It's used only for mock generating.
+//
+type Actor interface {
+	context.Actor
+}
+
 //go:generate mockery --name=Expression --inpackage --case=underscore --testonly
 
 // Expression ...
@@ -24,3 +35,13 @@ type Context interface {
 type Expression interface {
 	expressions.Expression
 }
+
+//go:generate mockery --name=Command --inpackage --case=underscore --testonly
+
+// Command ...
+//
+// It's used only for mock generating.
+//
+type Command interface {
+	runtime.Command
+}
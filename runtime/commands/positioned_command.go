@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// PositionedCommand ...
+//
+// It binds the command to its position in the source code for error reporting.
+type PositionedCommand struct {
+	command  runtime.Command
+	position runtime.Position
+}
+
+// NewPositionedCommand ...
+func NewPositionedCommand(command runtime.Command, position runtime.Position) PositionedCommand {
+	return PositionedCommand{command, position}
+}
+
+// Run ...
+func (command PositionedCommand) Run(context context.Context) (result interface{}, err error) {
+	result, err = command.command.Run(context)
+	if err != nil {
+		return nil, runtime.NewPositionedError(err, command.position)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestPositionedCommand(test *testing.T) {
+	type fields struct {
+		command runtime.Command
+	}
+
+	for _, testData := range []struct {
+		name         string
+		fields       fields
+		wantResult   interface{}
+		wantPosition runtime.Position
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				command: func() runtime.Command {
+					command := new(MockCommand)
+					command.On("Run", mock.AnythingOfType("*commands.MockContext")).Return(2.3, nil)
+
+					return command
+				}(),
+			},
+			wantResult:   2.3,
+			wantPosition: runtime.Position{},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				command: func() runtime.Command {
+					command := new(MockCommand)
+					command.
+						On("Run", mock.AnythingOfType("*commands.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return command
+				}(),
+			},
+			wantResult:   nil,
+			wantPosition: runtime.Position{Filename: "test.tt", Line: 12, Column: 23},
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error with the return",
+			fields: fields{
+				command: func() runtime.Command {
+					command := new(MockCommand)
+					command.
+						On("Run", mock.AnythingOfType("*commands.MockContext")).
+						Return(nil, runtime.ErrReturn)
+
+					return command
+				}(),
+			},
+			wantResult:   nil,
+			wantPosition: runtime.Position{Filename: "test.tt", Line: 12, Column: 23},
+			wantErr: func(test assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.Equal(test, runtime.ErrReturn, errors.Cause(err), msgAndArgs...)
+			},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			position := runtime.Position{Filename: "test.tt", Line: 12, Column: 23}
+			context := new(MockContext)
+			gotResult, gotErr := NewPositionedCommand(testData.fields.command, position).Run(context)
+
+			gotPosition, _ := runtime.FindPosition(gotErr)
+
+			mock.AssertExpectationsForObjects(test, testData.fields.command, context)
+			assert.Equal(test, testData.wantResult, gotResult)
+			assert.Equal(test, testData.wantPosition, gotPosition)
+			testData.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+// PublishCommand ...
+//
+// The topic should be evaluated to a string.
+type PublishCommand struct {
+	topic     expressions.Expression
+	name      string
+	arguments []expressions.Expression
+}
+
+// NewPublishCommand ...
+func NewPublishCommand(
+	topic expressions.Expression,
+	name string,
+	arguments []expressions.Expression,
+) PublishCommand {
+	return PublishCommand{topic: topic, name: name, arguments: arguments}
+}
+
+// Run ...
+func (command PublishCommand) Run(ctx context.Context) (result interface{}, err error) {
+	topic, err := command.topic.Evaluate(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to evaluate the topic for the publish command")
+	}
+
+	typedTopic, ok := topic.(*types.Pair)
+	if !ok {
+		return nil, errors.Errorf("unsupported type %T of the topic for the publish command", topic)
+	}
+
+	topicText, err := typedTopic.Text()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert the topic for the publish command to a string")
+	}
+
+	var arguments []interface{}
+	for index, argument := range command.arguments {
+		result, err := argument.Evaluate(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"unable to evaluate the argument #%d for the publish command",
+				index,
+			)
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	message := context.Message{Name: command.name, Arguments: arguments}
+	if self, ok := ctx.Value(runtime.SelfValueName); ok {
+		message.Sender, _ = self.(context.Actor)
+	}
+
+	ctx.PublishMessage(topicText, message)
+
+	return types.Nil{}, nil
+}
@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestPublishCommand(test *testing.T) {
+	type fields struct {
+		topic     expressions.Expression
+		name      string
+		arguments []expressions.Expression
+	}
+	type args struct {
+		context context.Context
+	}
+
+	for _, testData := range []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult interface{}
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without arguments",
+			fields: fields{
+				topic:     expressions.NewString("topic"),
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					message := context.Message{Name: "test"}
+
+					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
+					context.On("PublishMessage", "topic", message).Return()
+
+					return context
+				}(),
+			},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with arguments",
+			fields: fields{
+				topic: expressions.NewString("topic"),
+				name:  "test",
+				arguments: func() []expressions.Expression {
+					expressionOne := new(MockExpression)
+					expressionOne.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(2.3, nil)
+
+					expressionTwo := new(MockExpression)
+					expressionTwo.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(4.2, nil)
+
+					return []expressions.Expression{expressionOne, expressionTwo}
+				}(),
+			},
+			args: args{
+				context: func() context.Context {
+					message := context.Message{
+						Name:      "test",
+						Arguments: []interface{}{2.3, 4.2},
+					}
+
+					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
+					context.On("PublishMessage", "topic", message).Return()
+
+					return context
+				}(),
+			},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the sender",
+			fields: fields{
+				topic:     expressions.NewString("topic"),
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					self := new(MockActor)
+					message := context.Message{Name: "test", Sender: self}
+
+					context := new(MockContext)
+					context.On("Value", "self").Return(self, true)
+					context.On("PublishMessage", "topic", message).Return()
+
+					return context
+				}(),
+			},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with topic evaluation",
+			fields: fields{
+				topic: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return expression
+				}(),
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an incorrect topic type",
+			fields: fields{
+				topic: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(23, nil)
+
+					return expression
+				}(),
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with topic conversion",
+			fields: fields{
+				topic: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(types.NewPairFromSlice([]interface{}{types.Nil{}}), nil)
+
+					return expression
+				}(),
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with argument evaluation",
+			fields: fields{
+				topic: expressions.NewString("topic"),
+				name:  "test",
+				arguments: func() []expressions.Expression {
+					expressionOne := new(MockExpression)
+					expressionOne.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(2.3, nil)
+
+					expressionTwo := new(MockExpression)
+					expressionTwo.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return []expressions.Expression{expressionOne, expressionTwo}
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			gotResult, gotErr := NewPublishCommand(
+				testData.fields.topic,
+				testData.fields.name,
+				testData.fields.arguments,
+			).
+				Run(testData.args.context)
+
+			if _, ok := testData.fields.topic.(*MockExpression); ok {
+				mock.AssertExpectationsForObjects(test, testData.fields.topic)
+			}
+			for _, argument := range testData.fields.arguments {
+				mock.AssertExpectationsForObjects(test, argument)
+			}
+			mock.AssertExpectationsForObjects(test, testData.args.context)
+			assert.Equal(test, testData.wantResult, gotResult)
+			testData.wantErr(test, gotErr)
+		})
+	}
+}
@@ -1,25 +1,60 @@
 package commands
 
 import (
+	"math"
+
 	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
 	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 // SendCommand ...
+//
+// If the receiver is nil, the message is sent to all actors. If the priority is nil,
+// the default one is used.
 type SendCommand struct {
+	receiver  expressions.Expression
 	name      string
 	arguments []expressions.Expression
+	priority  expressions.Expression
 }
 
 // NewSendCommand ...
-func NewSendCommand(name string, arguments []expressions.Expression) SendCommand {
-	return SendCommand{name, arguments}
+func NewSendCommand(
+	receiver expressions.Expression,
+	name string,
+	arguments []expressions.Expression,
+) SendCommand {
+	return SendCommand{receiver: receiver, name: name, arguments: arguments}
+}
+
+// WithPriority ...
+//
+// The priority should be evaluated to an integer number.
+func (command SendCommand) WithPriority(priority expressions.Expression) SendCommand {
+	command.priority = priority
+	return command
 }
 
 // Run ...
 func (command SendCommand) Run(ctx context.Context) (result interface{}, err error) {
+	var sender context.MessageSender = ctx
+	if command.receiver != nil {
+		receiver, err := command.receiver.Evaluate(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to evaluate the receiver for the send command")
+		}
+
+		typedReceiver, ok := receiver.(context.Actor)
+		if !ok {
+			return nil, errors.Errorf("unsupported type %T of the receiver for the send command", receiver)
+		}
+
+		sender = typedReceiver
+	}
+
 	var arguments []interface{}
 	for index, argument := range command.arguments {
 		result, err := argument.Evaluate(ctx)
@@ -34,10 +69,40 @@ func (command SendCommand) Run(ctx context.Context) (result interface{}, err err
 		arguments = append(arguments, result)
 	}
 
-	ctx.SendMessage(context.Message{
-		Name:      command.name,
-		Arguments: arguments,
-	})
+	priority, err := command.evaluatePriority(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	message := context.Message{Name: command.name, Arguments: arguments, Priority: priority}
+	if self, ok := ctx.Value(runtime.SelfValueName); ok {
+		message.Sender, _ = self.(context.Actor)
+	}
+
+	if err := runtime.TrySendMessage(sender, message); err != nil {
+		return nil, errors.Wrapf(err, "unable to send the message %s", command.name)
+	}
 
 	return types.Nil{}, nil
 }
+
+func (command SendCommand) evaluatePriority(ctx context.Context) (int, error) {
+	if command.priority == nil {
+		return 0, nil
+	}
+
+	priority, err := command.priority.Evaluate(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "unable to evaluate the priority for the send command")
+	}
+
+	typedPriority, ok := priority.(float64)
+	if !ok {
+		return 0, errors.Errorf("unsupported type %T of the priority for the send command", priority)
+	}
+	if typedPriority != math.Trunc(typedPriority) {
+		return 0, errors.Errorf("the priority %g for the send command isn't an integer", typedPriority)
+	}
+
+	return int(typedPriority), nil
+}
@@ -13,8 +13,11 @@ import (
 
 func TestSendCommand(test *testing.T) {
 	type fields struct {
-		name      string
-		arguments []expressions.Expression
+		receiver    interface{}
+		receiverErr error
+		name        string
+		arguments   []expressions.Expression
+		priority    expressions.Expression
 	}
 	type args struct {
 		context context.Context
@@ -38,6 +41,7 @@ func TestSendCommand(test *testing.T) {
 					message := context.Message{Name: "test"}
 
 					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
 					context.On("SendMessage", message).Return()
 
 					return context
@@ -68,6 +72,7 @@ func TestSendCommand(test *testing.T) {
 					}
 
 					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
 					context.On("SendMessage", message).Return()
 
 					return context
@@ -76,6 +81,191 @@ func TestSendCommand(test *testing.T) {
 			wantResult: types.Nil{},
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "success with the sender",
+			fields: fields{
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					self := new(MockActor)
+					message := context.Message{Name: "test", Sender: self}
+
+					context := new(MockContext)
+					context.On("Value", "self").Return(self, true)
+					context.On("SendMessage", message).Return()
+
+					return context
+				}(),
+			},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the receiver",
+			fields: fields{
+				receiver: func() context.Actor {
+					message := context.Message{
+						Name:      "test",
+						Arguments: []interface{}{2.3, 4.2},
+					}
+
+					actor := new(MockActor)
+					actor.On("SendMessage", message).Return()
+
+					return actor
+				}(),
+				name: "test",
+				arguments: func() []expressions.Expression {
+					expressionOne := new(MockExpression)
+					expressionOne.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(2.3, nil)
+
+					expressionTwo := new(MockExpression)
+					expressionTwo.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(4.2, nil)
+
+					return []expressions.Expression{expressionOne, expressionTwo}
+				}(),
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
+
+					return context
+				}(),
+			},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the priority",
+			fields: fields{
+				name:      "test",
+				arguments: nil,
+				priority: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(-10.0, nil)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: func() context.Context {
+					message := context.Message{Name: "test", Priority: -10}
+
+					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
+					context.On("SendMessage", message).Return()
+
+					return context
+				}(),
+			},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with sending",
+			fields: fields{
+				receiver:  failableActor{MockActor: new(MockActor), err: iotest.ErrTimeout},
+				name:      "test",
+				arguments: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", "self").Return(nil, false)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with receiver evaluation",
+			fields: fields{
+				receiverErr: iotest.ErrTimeout,
+				name:        "test",
+				arguments:   []expressions.Expression{new(MockExpression)},
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an incorrect receiver type",
+			fields: fields{
+				receiver:  2.3,
+				name:      "test",
+				arguments: []expressions.Expression{new(MockExpression)},
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with priority evaluation",
+			fields: fields{
+				name:      "test",
+				arguments: nil,
+				priority: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an incorrect priority type",
+			fields: fields{
+				name:      "test",
+				arguments: nil,
+				priority: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(types.Nil{}, nil)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with a fractional priority",
+			fields: fields{
+				name:      "test",
+				arguments: nil,
+				priority: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(2.3, nil)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
 		{
 			name: "error",
 			fields: fields{
@@ -99,15 +289,47 @@ func TestSendCommand(test *testing.T) {
 		},
 	} {
 		test.Run(testData.name, func(test *testing.T) {
-			gotResult, gotErr := NewSendCommand(testData.fields.name, testData.fields.arguments).
-				Run(testData.args.context)
+			var receiver expressions.Expression
+			if testData.fields.receiver != nil || testData.fields.receiverErr != nil {
+				expression := new(MockExpression)
+				expression.
+					On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+					Return(testData.fields.receiver, testData.fields.receiverErr)
+
+				receiver = expression
+			}
+
+			command := NewSendCommand(receiver, testData.fields.name, testData.fields.arguments)
+			if testData.fields.priority != nil {
+				command = command.WithPriority(testData.fields.priority)
+			}
+			gotResult, gotErr := command.Run(testData.args.context)
 
 			mock.AssertExpectationsForObjects(test, testData.args.context)
+			if receiver != nil {
+				mock.AssertExpectationsForObjects(test, receiver)
+			}
+			if actor, ok := testData.fields.receiver.(*MockActor); ok {
+				mock.AssertExpectationsForObjects(test, actor)
+			}
 			for _, argument := range testData.fields.arguments {
 				mock.AssertExpectationsForObjects(test, argument)
 			}
+			if testData.fields.priority != nil {
+				mock.AssertExpectationsForObjects(test, testData.fields.priority)
+			}
 			assert.Equal(test, testData.wantResult, gotResult)
 			testData.wantErr(test, gotErr)
 		})
 	}
 }
+
+type failableActor struct {
+	*MockActor
+
+	err error
+}
+
+func (actor failableActor) TrySendMessage(message context.Message) error {
+	return actor.err
+}
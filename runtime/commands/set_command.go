@@ -34,7 +34,7 @@ func (command SetCommand) Run(ctx context.Context) (result interface{}, err erro
 		arguments = append(arguments, result)
 	}
 
-	if err := ctx.SetState(context.State{
+	if err := ctx.SetState(ctx, context.State{
 		Name:      command.name,
 		Arguments: arguments,
 	}); err != nil {
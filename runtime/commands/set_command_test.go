@@ -38,7 +38,7 @@ func TestSetCommand(test *testing.T) {
 					state := context.State{Name: "test"}
 
 					context := new(MockContext)
-					context.On("SetState", state).Return(nil)
+					context.On("SetState", context, state).Return(nil)
 
 					return context
 				}(),
@@ -68,7 +68,7 @@ func TestSetCommand(test *testing.T) {
 					}
 
 					context := new(MockContext)
-					context.On("SetState", state).Return(nil)
+					context.On("SetState", context, state).Return(nil)
 
 					return context
 				}(),
@@ -119,7 +119,7 @@ func TestSetCommand(test *testing.T) {
 					}
 
 					context := new(MockContext)
-					context.On("SetState", state).Return(iotest.ErrTimeout)
+					context.On("SetState", context, state).Return(iotest.ErrTimeout)
 
 					return context
 				}(),
@@ -5,13 +5,15 @@ import (
 	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
-	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 // StartCommand ...
+//
+// If the name is empty, the started actor stays unnamed.
 type StartCommand struct {
 	actorFactory expressions.Expression
 	arguments    []expressions.Expression
+	name         string
 }
 
 // NewStartCommand ...
@@ -19,12 +21,20 @@ func NewStartCommand(
 	actorFactory expressions.Expression,
 	arguments []expressions.Expression,
 ) StartCommand {
-	return StartCommand{actorFactory, arguments}
+	return StartCommand{actorFactory: actorFactory, arguments: arguments}
+}
+
+// WithName ...
+//
+// The name should be unique among running actors.
+func (command StartCommand) WithName(name string) StartCommand {
+	command.name = name
+	return command
 }
 
 // Run ...
-func (command StartCommand) Run(context context.Context) (result interface{}, err error) {
-	actorFactory, err := command.actorFactory.Evaluate(context)
+func (command StartCommand) Run(ctx context.Context) (result interface{}, err error) {
+	actorFactory, err := command.actorFactory.Evaluate(ctx)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to evaluate the actor class for the start command")
 	}
@@ -39,7 +49,7 @@ func (command StartCommand) Run(context context.Context) (result interface{}, er
 
 	var arguments []interface{}
 	for index, argument := range command.arguments {
-		result, err := argument.Evaluate(context)
+		result, err := argument.Evaluate(ctx)
 		if err != nil {
 			return nil, errors.Wrapf(
 				err,
@@ -52,7 +62,17 @@ func (command StartCommand) Run(context context.Context) (result interface{}, er
 	}
 
 	actor := typedActorFactory.CreateActor()
-	context.RegisterActor(actor, arguments)
+	if self, ok := ctx.Value(runtime.SelfValueName); ok {
+		if parent, ok := self.(context.Actor); ok {
+			actor.SetParent(parent)
+		}
+	}
+	if command.name != "" {
+		if err := ctx.RegisterActorName(command.name, actor); err != nil {
+			return nil, errors.Wrap(err, "unable to name the actor for the start command")
+		}
+	}
+	ctx.RegisterActor(actor, arguments)
 
-	return types.Nil{}, nil
+	return actor, nil
 }
@@ -11,13 +11,13 @@ import (
 	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
-	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 func TestStartCommand(test *testing.T) {
 	type fields struct {
 		actorFactory expressions.Expression
 		arguments    []expressions.Expression
+		name         string
 	}
 	type args struct {
 		context context.Context
@@ -61,14 +61,16 @@ func TestStartCommand(test *testing.T) {
 					concurrentActorFactory :=
 						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
 					wantActor := concurrentActorFactory.CreateActor()
-					cleanInbox(&wantActor)
+					cleanActor(wantActor)
 
 					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(nil, false)
 					context.
 						On(
 							"RegisterActor",
-							mock.MatchedBy(func(gotActor runtime.ConcurrentActor) bool {
-								cleanInbox(&gotActor)
+							mock.MatchedBy(func(gotActor *runtime.ConcurrentActor) bool {
+								cleanActor(gotActor)
+
 								return reflect.DeepEqual(wantActor, gotActor)
 							}),
 							[]interface{}(nil),
@@ -78,8 +80,20 @@ func TestStartCommand(test *testing.T) {
 					return context
 				}(),
 			},
-			wantResult: types.Nil{},
-			wantErr:    assert.NoError,
+			wantResult: func() *runtime.ConcurrentActor {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+					context.State{Name: "state_0"},
+				)
+				concurrentActorFactory :=
+					runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+				actor := concurrentActorFactory.CreateActor()
+				cleanActor(actor)
+
+				return actor
+			}(),
+			wantErr: assert.NoError,
 		},
 		{
 			name: "success with arguments",
@@ -124,14 +138,16 @@ func TestStartCommand(test *testing.T) {
 					concurrentActorFactory :=
 						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
 					wantActor := concurrentActorFactory.CreateActor()
-					cleanInbox(&wantActor)
+					cleanActor(wantActor)
 
 					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(nil, false)
 					context.
 						On(
 							"RegisterActor",
-							mock.MatchedBy(func(gotActor runtime.ConcurrentActor) bool {
-								cleanInbox(&gotActor)
+							mock.MatchedBy(func(gotActor *runtime.ConcurrentActor) bool {
+								cleanActor(gotActor)
+
 								return reflect.DeepEqual(wantActor, gotActor)
 							}),
 							[]interface{}{2.3, 4.2},
@@ -141,8 +157,150 @@ func TestStartCommand(test *testing.T) {
 					return context
 				}(),
 			},
-			wantResult: types.Nil{},
-			wantErr:    assert.NoError,
+			wantResult: func() *runtime.ConcurrentActor {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+					context.State{Name: "state_0"},
+				)
+				concurrentActorFactory :=
+					runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+				actor := concurrentActorFactory.CreateActor()
+				cleanActor(actor)
+
+				return actor
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a parent",
+			fields: fields{
+				actorFactory: func() expressions.Expression {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+						context.State{Name: "state_0"},
+					)
+					concurrentActorFactory :=
+						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(concurrentActorFactory, nil)
+
+					return expression
+				}(),
+				arguments: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+						context.State{Name: "state_0"},
+					)
+					concurrentActorFactory :=
+						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+					wantActor := concurrentActorFactory.CreateActor()
+					wantActor.SetParent(new(MockActor))
+					cleanActor(wantActor)
+
+					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(new(MockActor), true)
+					context.
+						On(
+							"RegisterActor",
+							mock.MatchedBy(func(gotActor *runtime.ConcurrentActor) bool {
+								cleanActor(gotActor)
+
+								return reflect.DeepEqual(wantActor, gotActor)
+							}),
+							[]interface{}(nil),
+						).
+						Return()
+
+					return context
+				}(),
+			},
+			wantResult: func() *runtime.ConcurrentActor {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+					context.State{Name: "state_0"},
+				)
+				concurrentActorFactory :=
+					runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+				actor := concurrentActorFactory.CreateActor()
+				actor.SetParent(new(MockActor))
+				cleanActor(actor)
+
+				return actor
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a name",
+			fields: fields{
+				actorFactory: func() expressions.Expression {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+						context.State{Name: "state_0"},
+					)
+					concurrentActorFactory :=
+						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(concurrentActorFactory, nil)
+
+					return expression
+				}(),
+				arguments: nil,
+				name:      "test",
+			},
+			args: args{
+				context: func() context.Context {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+						context.State{Name: "state_0"},
+					)
+					concurrentActorFactory :=
+						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+					wantActor := concurrentActorFactory.CreateActor()
+					cleanActor(wantActor)
+
+					isWantActor := mock.MatchedBy(func(gotActor *runtime.ConcurrentActor) bool {
+						cleanActor(gotActor)
+
+						return reflect.DeepEqual(wantActor, gotActor)
+					})
+
+					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(nil, false)
+					context.On("RegisterActorName", "test", isWantActor).Return(nil)
+					context.On("RegisterActor", isWantActor, []interface{}(nil)).Return()
+
+					return context
+				}(),
+			},
+			wantResult: func() *runtime.ConcurrentActor {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+					context.State{Name: "state_0"},
+				)
+				concurrentActorFactory :=
+					runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+				actor := concurrentActorFactory.CreateActor()
+				cleanActor(actor)
+
+				return actor
+			}(),
+			wantErr: assert.NoError,
 		},
 		{
 			name: "error with actor class evaluation",
@@ -216,11 +374,52 @@ func TestStartCommand(test *testing.T) {
 			wantResult: nil,
 			wantErr:    assert.Error,
 		},
+		{
+			name: "error with a taken name",
+			fields: fields{
+				actorFactory: func() expressions.Expression {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}, "state_1": {}}},
+						context.State{Name: "state_0"},
+					)
+					concurrentActorFactory :=
+						runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(concurrentActorFactory, nil)
+
+					return expression
+				}(),
+				arguments: nil,
+				name:      "test",
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(nil, false)
+					context.
+						On("RegisterActorName", "test", mock.AnythingOfType("*runtime.ConcurrentActor")).
+						Return(iotest.ErrTimeout)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			gotResult, gotErr := NewStartCommand(testData.fields.actorFactory, testData.fields.arguments).
+				WithName(testData.fields.name).
 				Run(testData.args.context)
 
+			if gotActor, ok := gotResult.(*runtime.ConcurrentActor); ok {
+				cleanActor(gotActor)
+			}
+
 			mock.AssertExpectationsForObjects(test, testData.args.context)
 			assert.Equal(test, testData.wantResult, gotResult)
 			testData.wantErr(test, gotErr)
@@ -228,7 +427,10 @@ func TestStartCommand(test *testing.T) {
 	}
 }
 
-func cleanInbox(actor *runtime.ConcurrentActor) {
+func cleanActor(actor *runtime.ConcurrentActor) {
+	idField := reflect.ValueOf(actor).Elem().FieldByName("id")
+	*(*int)(unsafe.Pointer(idField.UnsafeAddr())) = 0
+
 	inboxField := reflect.ValueOf(actor).Elem().FieldByName("inbox")
 	*(*chan string)(unsafe.Pointer(inboxField.UnsafeAddr())) = nil
 }
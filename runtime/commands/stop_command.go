@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+// StopCommand ...
+//
+// If the actor is nil, the current actor is stopped.
+type StopCommand struct {
+	actor expressions.Expression
+}
+
+// NewStopCommand ...
+func NewStopCommand(actor expressions.Expression) StopCommand {
+	return StopCommand{actor}
+}
+
+// Run ...
+func (command StopCommand) Run(ctx context.Context) (result interface{}, err error) {
+	var actor interface{}
+	if command.actor != nil {
+		actor, err = command.actor.Evaluate(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to evaluate the actor for the stop command")
+		}
+	} else {
+		actor, _ = ctx.Value(runtime.SelfValueName)
+	}
+
+	typedActor, ok := actor.(*runtime.ConcurrentActor)
+	if !ok {
+		return nil, errors.Errorf("unsupported type %T of the actor for the stop command", actor)
+	}
+
+	typedActor.Stop()
+
+	return types.Nil{}, nil
+}
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestStopCommand(test *testing.T) {
+	type fields struct {
+		actor expressions.Expression
+	}
+	type args struct {
+		context context.Context
+	}
+
+	actorFactory, _ := runtime.NewActorFactory(
+		"Test",
+		runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+		context.State{Name: "state_0"},
+	)
+	dependencies := runtime.Dependencies{WaitGroup: new(sync.WaitGroup)}
+	concurrentActorFactory := runtime.NewConcurrentActorFactory(actorFactory, 1, dependencies)
+	currentActor := concurrentActorFactory.CreateActor()
+	referencedActor := concurrentActorFactory.CreateActor()
+
+	for _, testData := range []struct {
+		name         string
+		fields       fields
+		args         args
+		stoppedActor *runtime.ConcurrentActor
+		wantResult   interface{}
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the current actor",
+			fields: fields{
+				actor: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(currentActor, true)
+
+					return context
+				}(),
+			},
+			stoppedActor: currentActor,
+			wantResult:   types.Nil{},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "success with the referenced actor",
+			fields: fields{
+				actor: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(referencedActor, nil)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			stoppedActor: referencedActor,
+			wantResult:   types.Nil{},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "error without the current actor",
+			fields: fields{
+				actor: nil,
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", runtime.SelfValueName).Return(nil, false)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with actor evaluation",
+			fields: fields{
+				actor: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*commands.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an incorrect actor type",
+			fields: fields{
+				actor: func() expressions.Expression {
+					expression := new(MockExpression)
+					expression.On("Evaluate", mock.AnythingOfType("*commands.MockContext")).Return(23, nil)
+
+					return expression
+				}(),
+			},
+			args: args{
+				context: new(MockContext),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			gotResult, gotErr := NewStopCommand(testData.fields.actor).Run(testData.args.context)
+
+			if testData.fields.actor != nil {
+				mock.AssertExpectationsForObjects(test, testData.fields.actor)
+			}
+			mock.AssertExpectationsForObjects(test, testData.args.context)
+			assert.Equal(test, testData.wantResult, gotResult)
+			testData.wantErr(test, gotErr)
+
+			if testData.stoppedActor != nil {
+				stats := testData.stoppedActor.InboxStats()
+				assert.Equal(test, 1, stats.Size)
+			}
+		})
+	}
+}
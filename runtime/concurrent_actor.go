@@ -1,57 +1,588 @@
 package runtime
 
 import (
+	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/pkg/errors"
 	syncutils "github.com/thewizardplusplus/go-sync-utils"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
-type inbox chan context.Message
+// ...
+const (
+	SelfValueName   = "self"
+	SenderValueName = "sender"
+
+	ChildFailedMessageName = "__child_failed__"
+	RestartMessageName     = "__restart__"
+	StopMessageName        = "__stop__"
+	TerminateMessageName   = "__terminate__"
+)
+
+// nolint: gochecknoglobals
+var (
+	lastActorID int64
+)
 
 // Dependencies ...
+//
+// The Recorder and Tracer fields are optional.
 type Dependencies struct {
 	syncutils.WaitGroup
 	ErrorHandler
+	Recorder
+	Tracer
 }
 
 // ConcurrentActor ...
 type ConcurrentActor struct {
-	innerActor   *Actor
-	inbox        inbox
-	dependencies Dependencies
+	id            int
+	className     string
+	innerActor    *Actor
+	initialState  context.State
+	inbox         *inbox
+	inboxPolicy   InboxPolicy
+	values        context.ValueGroup
+	failurePolicy FailurePolicy
+	deadLetters   DeadLetterMode
+	parent        context.Actor
+	stopped       bool
+	dependencies  Dependencies
+
+	currentMessage   *context.Message
+	deferredMessages []context.Message
+	replayedMessages []context.Message
+
+	timers timerGroup
+
+	// they are set by the scheduler of the actor
+	random          *rand.Rand
+	sharesGoroutine bool
+}
+
+// ID ...
+func (actor *ConcurrentActor) ID() int {
+	return actor.id
+}
+
+// String ...
+func (actor *ConcurrentActor) String() string {
+	return fmt.Sprintf("<actor %s#%d>", actor.className, actor.id)
+}
+
+// MarshalText ...
+func (actor *ConcurrentActor) MarshalText() (text []byte, err error) {
+	return []byte(actor.String()), nil
+}
+
+// SetParent ...
+//
+// The parent is notified about failures of the actor with the supervise policy.
+// It should be set before the actor start.
+func (actor *ConcurrentActor) SetParent(parent context.Actor) {
+	actor.parent = parent
+}
+
+// Restart ...
+//
+// It resets the actor to its initial state and resumes processing of messages,
+// if the actor was stopped by its failure policy. The restart is performed
+// asynchronously in order with other messages of the actor.
+func (actor *ConcurrentActor) Restart() {
+	actor.SendMessage(context.NewControlMessage(RestartMessageName))
+}
+
+// Stop ...
+//
+// It terminates the actor after processing of all pending messages: runs
+// the __terminate__ handler, if it's defined, unregisters the actor, cancels
+// its timers and closes its inbox. The messages sent to the actor after that
+// are dropped.
+func (actor *ConcurrentActor) Stop() {
+	actor.SendMessage(context.NewControlMessage(StopMessageName))
+}
+
+// DeferMessage ...
+//
+// It puts the message being processed to the stash of the actor. The stashed
+// messages are replayed in order after the next successful state setting.
+//
+// It should be called only from the actor itself.
+func (actor *ConcurrentActor) DeferMessage() error {
+	if actor.currentMessage == nil {
+		return errors.New("no message to defer")
+	}
+
+	actor.deferredMessages = append(actor.deferredMessages, *actor.currentMessage)
+	return nil
+}
+
+// Reply ...
+//
+// It replies to the message being processed. It returns false if the message
+// doesn't wait for a reply or it was already replied.
+//
+// It should be called only from the actor itself.
+func (actor *ConcurrentActor) Reply(value interface{}) bool {
+	if actor.currentMessage == nil || actor.currentMessage.Reply == nil {
+		return false
+	}
+
+	select {
+	case actor.currentMessage.Reply <- value:
+		return true
+	default:
+		return false
+	}
+}
+
+// SharesGoroutine ...
+//
+// It returns true if the actor runs in the same goroutine with other actors
+// (e.g. with the round-robin scheduler), so it can't wait for them.
+func (actor *ConcurrentActor) SharesGoroutine() bool {
+	return actor.sharesGoroutine
+}
+
+// InboxStats ...
+//
+// The Size field is equal to the count of messages sent to the actor, but not received
+// by it yet.
+func (actor *ConcurrentActor) InboxStats() InboxStats {
+	return actor.inbox.inboxStats()
+}
+
+// Random ...
+//
+// It returns a pseudo-random number in [0.0, 1.0) from the source of the scheduler
+// of the actor, if the scheduler has one, or from the global source otherwise.
+// The number is recorded or replayed by the recorder, if it's set.
+func (actor *ConcurrentActor) Random() float64 {
+	producer := rand.Float64
+	if actor.random != nil {
+		producer = actor.random.Float64
+	}
+
+	return actor.recordValue(RandomRecordKind, producer)
+}
+
+// Time ...
+//
+// It returns the current Unix time in seconds. The time is recorded or replayed
+// by the recorder, if it's set.
+func (actor *ConcurrentActor) Time() float64 {
+	return actor.recordValue(TimeRecordKind, func() float64 {
+		return float64(time.Now().UnixNano()) / 1e9
+	})
+}
+
+// StartTimer ...
+//
+// The timer is bound to the actor and is cancelled on its stopping.
+func (actor *ConcurrentActor) StartTimer(
+	receiver context.MessageSender,
+	message context.Message,
+	delay time.Duration,
+	interval time.Duration,
+) *Timer {
+	timer := NewTimer(receiver, message, delay, interval, actor.dependencies.WaitGroup)
+	actor.timers.startTimer(timer)
+
+	return timer
 }
 
 // Start ...
-func (actor ConcurrentActor) Start(context context.Context, arguments []interface{}) {
+func (actor *ConcurrentActor) Start(context context.Context, arguments []interface{}) {
+	context = actor.prepare(context, arguments)
+	for {
+		message, ok := actor.inbox.receive()
+		if !ok || !actor.handleMessage(context, arguments, message) {
+			return
+		}
+	}
+}
+
+// it returns the context for processing of messages
+func (actor *ConcurrentActor) prepare(
+	context context.Context,
+	arguments []interface{},
+) context.Context {
 	context = context.Copy()
-	context.SetStateHolder(actor.innerActor)
+	for name, value := range actor.values {
+		context.SetValue(name, value)
+	}
+	context.SetValue(SelfValueName, actor)
+	if actor.dependencies.Tracer != nil {
+		context.SetStateHolder(tracingStateHolder{actor: actor})
+	} else {
+		context.SetStateHolder(actor.innerActor)
+	}
+
+	if err := actor.innerActor.EnterInitialState(context, arguments); err != nil {
+		actor.handleFailure(context, arguments, err)
+	}
+
+	return context
+}
+
+// it returns false if the actor is terminated
+func (actor *ConcurrentActor) handleMessage(
+	context context.Context,
+	arguments []interface{},
+	message context.Message,
+) bool {
+	defer actor.dependencies.WaitGroup.Done()
+
+	if actor.dependencies.Recorder != nil {
+		if err := actor.dependencies.Recorder.RecordMessage(actor, message); err != nil {
+			actor.dependencies.ErrorHandler.LogError(errors.Wrap(err, "unable to record the message"))
+		}
+	}
+	actor.traceMessage(DequeueEventKind, message)
+
+	switch {
+	case message.IsControl() && message.Name == StopMessageName:
+		actor.terminate(context, arguments)
+		return false
+	case message.IsControl() && message.Name == RestartMessageName:
+		actor.stopped = false
+		if err := actor.restart(context, arguments); err != nil {
+			actor.handleFailure(context, arguments, err)
+		}
+	// messages to the stopped actor are dropped
+	case !actor.stopped:
+		actor.processMessage(context, arguments, message)
+		actor.processReplayedMessages(context, arguments)
+	default:
+		actor.rejectBroadcast(message)
+	}
+
+	return true
+}
+
+// it records and traces the actor start on its registration, so the start
+// precedes the messages sent to the actor
+func (actor *ConcurrentActor) register() {
+	if actor.dependencies.Recorder != nil {
+		if err := actor.dependencies.Recorder.RecordStart(actor); err != nil {
+			actor.dependencies.ErrorHandler.LogError(
+				errors.Wrap(err, "unable to record the actor start"),
+			)
+		}
+	}
+
+	event := TraceEvent{Kind: StartEventKind, State: actor.innerActor.currentState.Name}
+	if parent, ok := actor.parent.(*ConcurrentActor); ok {
+		event.Parent = parent.id
+	}
+	actor.trace(event)
+}
+
+func (actor *ConcurrentActor) recordValue(kind RecordKind, producer func() float64) float64 {
+	if actor.dependencies.Recorder == nil {
+		return producer()
+	}
+
+	value, err := actor.dependencies.Recorder.RecordValue(actor, kind, producer)
+	if err != nil {
+		actor.dependencies.ErrorHandler.LogError(
+			errors.Wrapf(err, "unable to record the %s value", kind),
+		)
+	}
+
+	return value
+}
+
+func (actor *ConcurrentActor) traceMessage(kind TraceEventKind, message context.Message) {
+	if actor.dependencies.Tracer == nil {
+		return
+	}
+
+	// arguments that can't be marshalled aren't traced
+	arguments, _ := marshalTracedArguments(message.Arguments) // nolint: errcheck, gosec
+	event := TraceEvent{Kind: kind, Message: message.Name, Arguments: arguments}
+	if sender, ok := message.Sender.(*ConcurrentActor); ok {
+		event.Sender = sender.id
+		event.SenderClass = sender.className
+	}
+	if kind == DequeueEventKind {
+		event.State = actor.innerActor.currentState.Name
+	}
+
+	actor.trace(event)
+}
+
+func (actor *ConcurrentActor) trace(event TraceEvent) {
+	if actor.dependencies.Tracer == nil {
+		return
+	}
+
+	event.Time = time.Now()
+	event.Actor = actor.id
+	event.Class = actor.className
+	if err := actor.dependencies.Tracer.TraceEvent(event); err != nil {
+		actor.dependencies.ErrorHandler.LogError(errors.Wrap(err, "unable to trace the event"))
+	}
+}
+
+func (actor *ConcurrentActor) terminate(ctx context.Context, arguments []interface{}) {
+	if !actor.stopped {
+		actor.processMessage(ctx, arguments, context.Message{Name: TerminateMessageName})
+	}
+
+	ctx.UnregisterActor(actor)
+	actor.timers.cancelTimers()
+	actor.deferredMessages = nil
+	actor.inbox.close()
+}
 
-	for message := range actor.inbox {
-		if err := actor.innerActor.ProcessMessage(context.Copy(), arguments, message); err != nil {
+// the replayed messages are processed before the next message from the inbox
+func (actor *ConcurrentActor) processReplayedMessages(
+	context context.Context,
+	arguments []interface{},
+) {
+	for len(actor.replayedMessages) != 0 && !actor.stopped {
+		message := actor.replayedMessages[0]
+		actor.replayedMessages = actor.replayedMessages[1:]
+
+		actor.processMessage(context, arguments, message)
+	}
+
+	actor.replayedMessages = nil
+}
+
+func (actor *ConcurrentActor) processMessage(
+	context context.Context,
+	arguments []interface{},
+	message context.Message,
+) {
+	actor.currentMessage = &message
+	defer func() { actor.currentMessage = nil }()
+
+	if message.Receipt != nil && !actor.innerActor.HandlesMessage(message.Name) {
+		actor.rejectBroadcast(message)
+		return
+	}
+	if actor.isDeadLetter(message) {
+		var ok bool
+		if message, ok = actor.handleDeadLetter(context, arguments, message); !ok {
+			return
+		}
+	}
+
+	var sender interface{} = types.Nil{}
+	if message.Sender != nil {
+		sender = message.Sender
+	}
+
+	messageContext := context.Copy()
+	messageContext.SetValue(SenderValueName, sender)
+
+	transitionCount := actor.innerActor.transitionCount
+	if err := actor.innerActor.ProcessMessage(messageContext, arguments, message); err != nil {
+		actor.handleFailure(context, arguments, err)
+		return
+	}
+
+	if actor.innerActor.transitionCount != transitionCount {
+		actor.replayDeferredMessages()
+	}
+}
+
+func (actor *ConcurrentActor) replayDeferredMessages() {
+	// the deferred messages are replayed before the previously replayed ones,
+	// because they were received earlier
+	replayedMessages := append(actor.deferredMessages, actor.replayedMessages...)
+	actor.deferredMessages = nil
+	actor.replayedMessages = replayedMessages
+}
+
+func (actor *ConcurrentActor) isDeadLetter(message context.Message) bool {
+	return actor.deadLetters != IgnoreDeadLetters &&
+		!message.Broadcast &&
+		!IsSystemMessageName(message.Name) &&
+		!actor.innerActor.HandlesMessage(message.Name)
+}
+
+// the broadcast message is a dead letter, only if all its receivers don't handle it,
+// so it's reported by the last of them
+func (actor *ConcurrentActor) rejectBroadcast(message context.Message) {
+	if message.Receipt == nil || IsSystemMessageName(message.Name) {
+		return
+	}
+
+	if message.Receipt.Reject() && actor.deadLetters != IgnoreDeadLetters {
+		actor.dependencies.ErrorHandler.LogError(newBroadcastDeadLetterError(message))
+	}
+}
+
+// it returns the message that should be processed instead of the dead letter,
+// if there is such
+func (actor *ConcurrentActor) handleDeadLetter(
+	ctx context.Context,
+	arguments []interface{},
+	message context.Message,
+) (context.Message, bool) {
+	err := newDeadLetterError(actor, message)
+	switch actor.deadLetters {
+	case FailOnDeadLetters:
+		actor.handleFailure(ctx, arguments, err)
+	case ForwardDeadLetters:
+		if actor.innerActor.HandlesMessage(UnhandledMessageName) {
+			unhandledMessage := context.Message{
+				Name: UnhandledMessageName,
+				Arguments: []interface{}{
+					types.NewPairFromText(message.Name),
+					types.NewPairFromSlice(message.Arguments),
+				},
+				Sender: message.Sender,
+			}
+			return unhandledMessage, true
+		}
+
+		actor.dependencies.ErrorHandler.LogError(err)
+	default:
+		actor.dependencies.ErrorHandler.LogError(err)
+	}
+
+	return context.Message{}, false
+}
+
+func (actor *ConcurrentActor) handleFailure(
+	ctx context.Context,
+	arguments []interface{},
+	err error,
+) {
+	actor.trace(TraceEvent{Kind: ErrorEventKind, Error: err.Error()})
+
+	var format string
+	switch actor.failurePolicy {
+	case StopActorPolicy:
+		actor.stopped = true
+		format = "the actor %s is stopped"
+	case RestartActorPolicy:
+		actor.dependencies.ErrorHandler.LogError(errors.Wrapf(err, "the actor %s is restarted", actor))
+
+		// the actor failed on its restart is stopped to avoid endless restarting
+		if err := actor.restart(ctx, arguments); err != nil {
+			actor.trace(TraceEvent{Kind: ErrorEventKind, Error: err.Error()})
+
+			actor.stopped = true
+			actor.dependencies.ErrorHandler.LogError(
+				errors.Wrapf(err, "the actor %s is stopped on its restart", actor),
+			)
+		}
+
+		return
+	case IgnoreAndLogPolicy:
+		format = "the actor %s ignored the error"
+	case SupervisePolicy:
+		if actor.parent == nil {
 			actor.dependencies.ErrorHandler.HandleError(err)
+			return
 		}
 
-		actor.dependencies.WaitGroup.Done()
+		actor.stopped = true
+		actor.parent.SendMessage(context.Message{
+			Name:      ChildFailedMessageName,
+			Arguments: []interface{}{actor, types.NewPairFromText(err.Error())},
+			Sender:    actor,
+		})
+
+		format = "the actor %s is stopped and its parent is notified"
+	default:
+		actor.dependencies.ErrorHandler.HandleError(err)
+		return
 	}
+
+	actor.dependencies.ErrorHandler.LogError(errors.Wrapf(err, format, actor))
+}
+
+// it resets the actor to its initial state and runs the enter hook of the state;
+// the deferred messages are dropped, because they were deferred
+// before the restart
+func (actor *ConcurrentActor) restart(ctx context.Context, arguments []interface{}) error {
+	previousState := actor.innerActor.currentState
+	actor.innerActor.currentState = actor.initialState
+	actor.deferredMessages = nil
+	actor.replayedMessages = nil
+
+	actor.trace(TraceEvent{
+		Kind:          StateChangeEventKind,
+		State:         actor.initialState.Name,
+		PreviousState: previousState.Name,
+	})
+
+	return actor.innerActor.EnterInitialState(ctx, arguments)
 }
 
 // SendMessage ...
-func (actor ConcurrentActor) SendMessage(message context.Message) {
-	// waiter increment should call synchronously
-	// otherwise the program may end before all messages are processed
-	actor.dependencies.WaitGroup.Add(1)
+//
+// The overflow of the inbox is handled according to the inbox policy of the actor.
+// With the fail policy, the overflow error is logged.
+func (actor *ConcurrentActor) SendMessage(message context.Message) {
+	if err := actor.TrySendMessage(message); err != nil {
+		actor.dependencies.ErrorHandler.LogError(err)
+	}
+}
+
+// TrySendMessage ...
+//
+// It's similar to the SendMessage method, but returns the overflow error
+// with the fail policy instead of logging it.
+func (actor *ConcurrentActor) TrySendMessage(message context.Message) error {
+	actor.traceMessage(SendEventKind, message)
+
+	policy := actor.inboxPolicy
+	// the actor would deadlock on blocking sending to itself
+	protected := IsSystemMessageName(message.Name) || message.Sender == actor
+	if protected {
+		policy = UnboundedInboxPolicy
+	}
+
+	err := actor.inbox.send(message, policy, protected)
+	if err == errInboxFull && policy == FailInboxPolicy {
+		return newInboxOverflowError(actor, message)
+	}
+
+	return nil
+}
+
+// it traces state changes of the actor
+type tracingStateHolder struct {
+	actor *ConcurrentActor
+}
 
-	// use unbounded sending to avoid a deadlock
-	syncutils.UnboundedSend(actor.inbox, message)
+func (holder tracingStateHolder) SetState(ctx context.Context, state context.State) error {
+	previousState := holder.actor.innerActor.currentState
+	if err := holder.actor.innerActor.SetState(ctx, state); err != nil {
+		return err
+	}
+
+	holder.actor.trace(TraceEvent{
+		Kind:          StateChangeEventKind,
+		State:         state.Name,
+		PreviousState: previousState.Name,
+	})
+
+	return nil
 }
 
 // ConcurrentActorFactory ...
 type ConcurrentActorFactory struct {
 	ActorFactory
 
-	inboxSize    int
-	dependencies Dependencies
+	inboxSize     int
+	inboxPolicy   InboxPolicy
+	values        context.ValueGroup
+	failurePolicy FailurePolicy
+	deadLetters   DeadLetterMode
+	dependencies  Dependencies
 }
 
 // NewConcurrentActorFactory ...
@@ -60,47 +591,146 @@ func NewConcurrentActorFactory(
 	inboxSize int,
 	dependencies Dependencies,
 ) ConcurrentActorFactory {
-	return ConcurrentActorFactory{actorFactory, inboxSize, dependencies}
+	return ConcurrentActorFactory{
+		ActorFactory: actorFactory,
+		inboxSize:    inboxSize,
+		dependencies: dependencies,
+	}
+}
+
+// WithValues ...
+//
+// The values are set to the context of each created actor at its start. It's used to bind
+// the actor class to definitions of its module.
+func (factory ConcurrentActorFactory) WithValues(
+	values context.ValueGroup,
+) ConcurrentActorFactory {
+	factory.values = values
+	return factory
+}
+
+// WithFailurePolicy ...
+//
+// By default, the crash-process policy is used.
+func (factory ConcurrentActorFactory) WithFailurePolicy(
+	failurePolicy FailurePolicy,
+) ConcurrentActorFactory {
+	factory.failurePolicy = failurePolicy
+	return factory
+}
+
+// WithInboxPolicy ...
+//
+// By default, the unbounded policy is used.
+func (factory ConcurrentActorFactory) WithInboxPolicy(
+	inboxPolicy InboxPolicy,
+) ConcurrentActorFactory {
+	factory.inboxPolicy = inboxPolicy
+	return factory
+}
+
+// WithDeadLetterMode ...
+func (factory ConcurrentActorFactory) WithDeadLetterMode(
+	deadLetters DeadLetterMode,
+) ConcurrentActorFactory {
+	factory.deadLetters = deadLetters
+	return factory
 }
 
 // CreateActor ...
-func (factory ConcurrentActorFactory) CreateActor() ConcurrentActor {
+func (factory ConcurrentActorFactory) CreateActor() *ConcurrentActor {
+	id := int(atomic.AddInt64(&lastActorID, 1))
 	actor := factory.ActorFactory.CreateActor()
-	inbox := make(inbox, factory.inboxSize) // nolint: vetshadow
-	return ConcurrentActor{actor, inbox, factory.dependencies}
+	inbox := newInbox(factory.inboxSize, factory.dependencies.WaitGroup) // nolint: vetshadow
+	return &ConcurrentActor{
+		id:            id,
+		className:     factory.name,
+		innerActor:    actor,
+		initialState:  factory.initialState,
+		inbox:         inbox,
+		inboxPolicy:   factory.inboxPolicy,
+		values:        factory.values,
+		failurePolicy: factory.failurePolicy,
+		deadLetters:   factory.deadLetters,
+		dependencies:  factory.dependencies,
+	}
 }
 
 // ConcurrentActorGroup ...
+//
+// It also serves as the topic and name registers for its actors. Unregistered
+// actors are unsubscribed from all topics and lose all their names.
+//
+// Registered actors are started by the scheduler, by default by the goroutine one.
 type ConcurrentActorGroup struct {
-	context context.Context
-	locker  sync.RWMutex
-	actors  []context.Actor
+	TopicRegistry
+	NameRegistry
+
+	context   context.Context
+	scheduler Scheduler
+	locker    sync.RWMutex
+	actors    []context.Actor
 }
 
 // NewConcurrentActorGroup ...
 func NewConcurrentActorGroup(context context.Context) *ConcurrentActorGroup {
-	group := &ConcurrentActorGroup{context: context.Copy()}
+	group := &ConcurrentActorGroup{context: context.Copy(), scheduler: GoroutineScheduler{}}
 	group.context.SetMessageSender(group)
 	group.context.SetActorRegister(group)
+	group.context.SetTopicRegister(group)
+	group.context.SetNameRegister(group)
 
 	return group
 }
 
+// WithScheduler ...
+func (group *ConcurrentActorGroup) WithScheduler(scheduler Scheduler) *ConcurrentActorGroup {
+	group.scheduler = scheduler
+	return group
+}
+
 // RegisterActor ...
 func (group *ConcurrentActorGroup) RegisterActor(actor context.Actor, arguments []interface{}) {
 	group.locker.Lock()
 	defer group.locker.Unlock()
 
+	// the start is recorded before the scheduling, because replaying of it
+	// may change the actor ID
+	if concurrentActor, ok := actor.(*ConcurrentActor); ok {
+		concurrentActor.register()
+	}
+
 	group.actors = append(group.actors, actor)
-	go actor.Start(group.context, arguments)
+	group.scheduler.ScheduleActor(actor, group.context, arguments)
+}
+
+// UnregisterActor ...
+func (group *ConcurrentActorGroup) UnregisterActor(actor context.Actor) {
+	group.UnsubscribeActorFromAll(actor)
+	group.UnregisterActorNames(actor)
+
+	group.locker.Lock()
+	defer group.locker.Unlock()
+
+	for index, registeredActor := range group.actors {
+		if registeredActor == actor {
+			group.actors = append(group.actors[:index], group.actors[index+1:]...)
+			break
+		}
+	}
 }
 
 // SendMessage ...
 func (group *ConcurrentActorGroup) SendMessage(message context.Message) {
+	// don't hold the lock during sending, because it may block
 	group.locker.RLock()
-	defer group.locker.RUnlock()
+	actors := append([]context.Actor(nil), group.actors...)
+	group.locker.RUnlock()
+
+	message.Broadcast = true
+	message.Receipt = context.NewBroadcastReceipt(len(actors))
 
-	for _, actor := range group.actors {
+	for _, actor := range actors {
 		actor.SendMessage(message)
 	}
 }
@@ -1,20 +1,29 @@
 package runtime
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"sync"
 	"testing"
+	"testing/iotest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	syncutils "github.com/thewizardplusplus/go-sync-utils"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 func TestConcurrentActor(test *testing.T) {
 	type fields struct {
-		makeStates   func(context context.Context, log *commandLog) ParameterizedStateGroup
-		currentState context.State
-		inbox        inbox
+		makeStates    func(context context.Context, log *commandLog) ParameterizedStateGroup
+		currentState  context.State
+		inboxSize     int
+		values        context.ValueGroup
+		failurePolicy FailurePolicy
 	}
 	type args struct {
 		contextSecondCopy context.Context
@@ -23,26 +32,54 @@ func TestConcurrentActor(test *testing.T) {
 	}
 
 	for _, testData := range []struct {
-		name     string
-		fields   fields
-		args     args
-		errCount int
-		wantLog  []int
+		name           string
+		fields         fields
+		args           args
+		errCount       int
+		loggedErrCount int
+		wantLog        []int
 	}{
 		{
-			name: "success with messages (with an unbuffered inbox)",
+			name: "success with messages (with an inbox of zero size)",
 			fields: fields{
 				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
 					options := loggableCommandOptions{"message_2": {withCalls()}, "message_3": {withCalls()}}
 					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
 				},
 				currentState: context.State{Name: "state_1"},
-				inbox:        make(inbox),
 			},
 			args: args{
-				contextSecondCopy: new(MockContext),
-				arguments:         nil,
-				messages:          []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+			},
+			errCount: 0,
+			wantLog:  []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+		},
+		{
+			name: "success with messages (with values)",
+			fields: fields{
+				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
+					options := loggableCommandOptions{"message_2": {withCalls()}, "message_3": {withCalls()}}
+					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
+				},
+				currentState: context.State{Name: "state_1"},
+				values:       context.ValueGroup{"one": 23, "two": 42},
+			},
+			args: args{
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
 			},
 			errCount: 0,
 			wantLog:  []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
@@ -55,12 +92,17 @@ func TestConcurrentActor(test *testing.T) {
 					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
 				},
 				currentState: context.State{Name: "state_1"},
-				inbox:        make(inbox, 23),
+				inboxSize:    23,
 			},
 			args: args{
-				contextSecondCopy: new(MockContext),
-				arguments:         nil,
-				messages:          []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
 			},
 			errCount: 0,
 			wantLog:  []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
@@ -74,11 +116,11 @@ func TestConcurrentActor(test *testing.T) {
 					return newLoggableParameterizedStates(context, log, stateConfig, group(2), group(5), options)
 				},
 				currentState: context.State{Name: "state_1"},
-				inbox:        make(inbox),
 			},
 			args: args{
 				contextSecondCopy: func() context.Context {
 					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
 					context.On("SetValue", "one", 5).Return()
 					context.On("SetValue", "two", 12).Return()
 
@@ -107,11 +149,11 @@ func TestConcurrentActor(test *testing.T) {
 					Name:      "state_1",
 					Arguments: []interface{}{23, 42},
 				},
-				inbox: make(inbox),
 			},
 			args: args{
 				contextSecondCopy: func() context.Context {
 					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
 					context.On("SetValue", "one", 5).Return()
 					context.On("SetValue", "two", 12).Return()
 					context.On("SetValue", "two", 23).Return()
@@ -144,11 +186,11 @@ func TestConcurrentActor(test *testing.T) {
 					Name:      "state_1",
 					Arguments: []interface{}{23, 42},
 				},
-				inbox: make(inbox),
 			},
 			args: args{
 				contextSecondCopy: func() context.Context {
 					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
 					context.On("SetValue", "one", 5).Return()
 					context.On("SetValue", "two", 12).Return()
 					context.On("SetValue", "two", 23).Return()
@@ -169,6 +211,28 @@ func TestConcurrentActor(test *testing.T) {
 			errCount: 0,
 			wantLog:  []int{10, 11, 12, 13, 14},
 		},
+		{
+			name: "success with a message sender",
+			fields: fields{
+				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
+					options := loggableCommandOptions{"message_2": {withCalls()}}
+					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
+				},
+				currentState: context.State{Name: "state_1"},
+			},
+			args: args{
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, &ConcurrentActor{id: 23}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2", Sender: &ConcurrentActor{id: 23}}},
+			},
+			errCount: 0,
+			wantLog:  []int{10, 11, 12, 13, 14},
+		},
 		{
 			name: "success without messages",
 			fields: fields{
@@ -176,7 +240,6 @@ func TestConcurrentActor(test *testing.T) {
 					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), nil)
 				},
 				currentState: context.State{Name: "state_1"},
-				inbox:        make(inbox),
 			},
 			args: args{
 				contextSecondCopy: new(MockContext),
@@ -194,21 +257,120 @@ func TestConcurrentActor(test *testing.T) {
 					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
 				},
 				currentState: context.State{Name: "state_1"},
-				inbox:        make(inbox),
 			},
 			args: args{
-				contextSecondCopy: new(MockContext),
-				arguments:         nil,
-				messages:          []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
 			},
 			errCount: 2,
 			wantLog:  []int{10, 11, 12, 15, 16, 17},
 		},
+		{
+			name: "error with the supervise policy (without a parent)",
+			fields: fields{
+				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
+					options := loggableCommandOptions{"message_2": {withErrOn(2)}, "message_3": {withErrOn(2)}}
+					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
+				},
+				currentState:  context.State{Name: "state_1"},
+				failurePolicy: SupervisePolicy,
+			},
+			args: args{
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+			},
+			errCount: 2,
+			wantLog:  []int{10, 11, 12, 15, 16, 17},
+		},
+		{
+			name: "error with the stop-actor policy",
+			fields: fields{
+				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
+					options := loggableCommandOptions{"message_2": {withErrOn(2)}}
+					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
+				},
+				currentState:  context.State{Name: "state_1"},
+				failurePolicy: StopActorPolicy,
+			},
+			args: args{
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+			},
+			errCount:       0,
+			loggedErrCount: 1,
+			wantLog:        []int{10, 11, 12},
+		},
+		{
+			name: "error with the restart-actor policy",
+			fields: fields{
+				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
+					options := loggableCommandOptions{"message_2": {withErrOn(2)}, "message_0": {withCalls()}}
+					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
+				},
+				currentState:  context.State{Name: "state_1"},
+				failurePolicy: RestartActorPolicy,
+			},
+			args: args{
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_0"}},
+			},
+			errCount:       0,
+			loggedErrCount: 1,
+			wantLog:        []int{10, 11, 12, 0, 1, 2, 3, 4},
+		},
+		{
+			name: "error with the ignore-and-log policy",
+			fields: fields{
+				makeStates: func(context context.Context, log *commandLog) ParameterizedStateGroup {
+					options := loggableCommandOptions{"message_2": {withErrOn(2)}, "message_3": {withCalls()}}
+					return newLoggableParameterizedStates(context, log, group(2), group(2), group(5), options)
+				},
+				currentState:  context.State{Name: "state_1"},
+				failurePolicy: IgnoreAndLogPolicy,
+			},
+			args: args{
+				contextSecondCopy: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+					return context
+				}(),
+				arguments: nil,
+				messages:  []context.Message{{Name: "message_2"}, {Name: "message_3"}},
+			},
+			errCount:       0,
+			loggedErrCount: 1,
+			wantLog:        []int{10, 11, 12, 15, 16, 17, 18, 19},
+		},
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			var log commandLog
 			states := testData.fields.makeStates(testData.args.contextSecondCopy, &log)
-			actor := &Actor{states, testData.fields.currentState}
+			actor := &Actor{states: states, currentState: testData.fields.currentState}
 
 			var initializationWaiter sync.WaitGroup
 			var initializationWaiterOnce sync.Once
@@ -229,8 +391,29 @@ func TestConcurrentActor(test *testing.T) {
 					On("HandleError", mock.MatchedBy(func(error) bool { return true })).
 					Times(testData.errCount)
 			}
+			if testData.loggedErrCount != 0 {
+				errorHandler.
+					On("LogError", mock.MatchedBy(func(error) bool { return true })).
+					Times(testData.loggedErrCount)
+			}
+
+			concurrentActor := &ConcurrentActor{
+				innerActor:    actor,
+				initialState:  context.State{Name: "state_0"},
+				inbox:         newInbox(testData.fields.inboxSize, synchronousProcessingWaiter),
+				values:        testData.fields.values,
+				failurePolicy: testData.fields.failurePolicy,
+				dependencies: Dependencies{
+					WaitGroup:    synchronousProcessingWaiter,
+					ErrorHandler: errorHandler,
+				},
+			}
 
 			contextFirstCopy := new(MockContext)
+			for name, value := range testData.fields.values {
+				contextFirstCopy.On("SetValue", name, value).Return()
+			}
+			contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
 			contextFirstCopy.
 				On("SetStateHolder", mock.MatchedBy(func(settedActor *Actor) bool {
 					defer initializationWaiterOnce.Do(initializationWaiter.Done)
@@ -244,14 +427,6 @@ func TestConcurrentActor(test *testing.T) {
 			contextOriginal := new(MockContext)
 			contextOriginal.On("Copy").Return(contextFirstCopy)
 
-			concurrentActor := ConcurrentActor{
-				innerActor: actor,
-				inbox:      testData.fields.inbox,
-				dependencies: Dependencies{
-					WaitGroup:    synchronousProcessingWaiter,
-					ErrorHandler: errorHandler,
-				},
-			}
 			go concurrentActor.Start(contextOriginal, testData.args.arguments)
 			initializationWaiter.Wait()
 
@@ -274,6 +449,681 @@ func TestConcurrentActor(test *testing.T) {
 	}
 }
 
+func TestConcurrentActor_withSupervision(test *testing.T) {
+	var log commandLog
+	contextSecondCopy := new(MockContext)
+	contextSecondCopy.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+	options := loggableCommandOptions{"message_0": {withErrOn(1)}, "message_2": {withErrOn(0)}}
+	states := newLoggableParameterizedStates(contextSecondCopy, &log, group(2), group(2), group(2), options)
+	actor := &Actor{states: states, currentState: context.State{Name: "state_1"}}
+
+	parentWaiter := new(MockWaitGroup)
+	parentWaiter.On("Add", 1).Times(2)
+
+	parent := &ConcurrentActor{
+		inbox:        newInbox(2, parentWaiter),
+		dependencies: Dependencies{WaitGroup: parentWaiter},
+	}
+
+	processingWaiter := new(MockWaitGroup)
+	processingWaiter.On("Add", 1).Times(5)
+	processingWaiter.On("Done").Times(5)
+	processingWaiter.On("Wait").Times(1)
+
+	errorHandler := new(MockErrorHandler)
+	errorHandler.On("LogError", mock.MatchedBy(func(error) bool { return true })).Times(2)
+
+	synchronousProcessingWaiter := syncutils.MultiWaitGroup{processingWaiter, new(sync.WaitGroup)}
+	concurrentActor := &ConcurrentActor{
+		innerActor:    actor,
+		initialState:  context.State{Name: "state_0"},
+		inbox:         newInbox(5, synchronousProcessingWaiter),
+		failurePolicy: SupervisePolicy,
+		dependencies: Dependencies{
+			WaitGroup:    synchronousProcessingWaiter,
+			ErrorHandler: errorHandler,
+		},
+	}
+	concurrentActor.SetParent(parent)
+
+	contextFirstCopy := new(MockContext)
+	contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
+	contextFirstCopy.On("SetStateHolder", actor).Return()
+	contextFirstCopy.On("Copy").Return(contextSecondCopy)
+
+	contextOriginal := new(MockContext)
+	contextOriginal.On("Copy").Return(contextFirstCopy)
+
+	go concurrentActor.Start(contextOriginal, nil)
+
+	// the failed message
+	concurrentActor.SendMessage(context.Message{Name: "message_2"})
+	// the dropped message
+	concurrentActor.SendMessage(context.Message{Name: "message_2"})
+	concurrentActor.Restart()
+	// the message failed in the initial state
+	concurrentActor.SendMessage(context.Message{Name: "message_0"})
+	// the dropped message
+	concurrentActor.SendMessage(context.Message{Name: "message_0"})
+	synchronousProcessingWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(
+		test,
+		contextOriginal,
+		contextFirstCopy,
+		contextSecondCopy,
+		parentWaiter,
+		processingWaiter,
+		errorHandler,
+	)
+	assert.Equal(test, []int{4, 0, 1}, log.commands)
+	assert.True(test, concurrentActor.stopped)
+
+	var gotMessages []context.Message
+	for parent.InboxStats().Size != 0 {
+		message, _ := parent.inbox.receive()
+		gotMessages = append(gotMessages, message)
+	}
+
+	require.Len(test, gotMessages, 2)
+	for _, message := range gotMessages {
+		assert.Equal(test, ChildFailedMessageName, message.Name)
+		assert.Equal(test, concurrentActor, message.Sender)
+		require.Len(test, message.Arguments, 2)
+		assert.Equal(test, concurrentActor, message.Arguments[0])
+		assert.IsType(test, (*types.Pair)(nil), message.Arguments[1])
+	}
+}
+
+func TestConcurrentActor_withStopping(test *testing.T) {
+	var log commandLog
+	contextSecondCopy := new(MockContext)
+	contextSecondCopy.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+	options := loggableCommandOptions{"message_2": {withCalls()}}
+	states := newLoggableParameterizedStates(contextSecondCopy, &log, group(2), group(2), group(2), options)
+	states.StateGroup["state_1"].messages[TerminateMessageName] =
+		newLoggableParameterizedCommands(contextSecondCopy, &log, group(2, 100), withCalls())
+	actor := &Actor{states: states, currentState: context.State{Name: "state_1"}}
+
+	processingWaiter := new(MockWaitGroup)
+	processingWaiter.On("Add", 1).Times(3)
+	processingWaiter.On("Done").Times(3)
+	processingWaiter.On("Wait").Times(1)
+
+	synchronousProcessingWaiter := syncutils.MultiWaitGroup{processingWaiter, new(sync.WaitGroup)}
+	concurrentActor := &ConcurrentActor{
+		innerActor: actor,
+		inbox:      newInbox(3, synchronousProcessingWaiter),
+		dependencies: Dependencies{
+			WaitGroup:    synchronousProcessingWaiter,
+			ErrorHandler: new(MockErrorHandler),
+		},
+	}
+
+	contextFirstCopy := new(MockContext)
+	contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
+	contextFirstCopy.On("SetStateHolder", actor).Return()
+	contextFirstCopy.On("Copy").Return(contextSecondCopy)
+	contextFirstCopy.On("UnregisterActor", concurrentActor).Return()
+
+	contextOriginal := new(MockContext)
+	contextOriginal.On("Copy").Return(contextFirstCopy)
+
+	concurrentActor.SendMessage(context.Message{Name: "message_2"})
+	concurrentActor.Stop()
+	// the message pending at the stopping
+	concurrentActor.SendMessage(context.Message{Name: "message_2"})
+
+	go concurrentActor.Start(contextOriginal, nil)
+	synchronousProcessingWaiter.Wait()
+
+	// the message sent after the stopping
+	concurrentActor.SendMessage(context.Message{Name: "message_2"})
+
+	mock.AssertExpectationsForObjects(
+		test,
+		contextOriginal,
+		contextFirstCopy,
+		contextSecondCopy,
+		processingWaiter,
+		concurrentActor.dependencies.ErrorHandler,
+	)
+	checkStates(test, states.StateGroup)
+	assert.Equal(test, []int{4, 5, 100, 101}, log.commands)
+
+	_, isOpen := concurrentActor.inbox.receive()
+	assert.False(test, isOpen)
+}
+
+func TestConcurrentActor_withDeferring(test *testing.T) {
+	var log commandLog
+	contextSecondCopy := new(MockContext)
+	contextSecondCopy.On("SetValue", SenderValueName, types.Nil{}).Return()
+
+	actor := new(Actor)
+	concurrentActor := &ConcurrentActor{innerActor: actor}
+
+	deferCommand := new(MockCommand)
+	deferCommand.
+		On("Run", contextSecondCopy).
+		Return(types.Nil{}, nil).
+		Run(func(mock.Arguments) { concurrentActor.DeferMessage() }). // nolint: errcheck, gosec
+		Times(2)
+
+	setCommand := new(MockCommand)
+	setCommand.
+		On("Run", contextSecondCopy).
+		Return(types.Nil{}, nil).
+		Run(func(mock.Arguments) {
+			actor.SetState(contextSecondCopy, context.State{Name: "state_1"}) // nolint: errcheck, gosec
+		}).
+		Times(1)
+
+	states := StateGroup{
+		"state_0": NewParameterizedMessageGroup(nil, MessageGroup{
+			"message_0": NewParameterizedCommandGroup(nil, CommandGroup{deferCommand}),
+			"message_1": NewParameterizedCommandGroup(nil, append(
+				CommandGroup{setCommand},
+				newLoggableCommands(contextSecondCopy, &log, group(1, 10), withCalls())...,
+			)),
+		}),
+		"state_1": NewParameterizedMessageGroup(nil, MessageGroup{
+			"message_0": newLoggableParameterizedCommands(
+				contextSecondCopy,
+				&log,
+				group(1, 20),
+				withCalls(),
+			),
+			"message_2": newLoggableParameterizedCommands(
+				contextSecondCopy,
+				&log,
+				group(1, 30),
+				withCalls(),
+			),
+		}),
+	}
+	actor.states = NewParameterizedStateGroup(nil, states)
+	actor.currentState = context.State{Name: "state_0"}
+
+	processingWaiter := new(MockWaitGroup)
+	processingWaiter.On("Add", 1).Times(4)
+	processingWaiter.On("Done").Times(4)
+	processingWaiter.On("Wait").Times(1)
+
+	synchronousProcessingWaiter := syncutils.MultiWaitGroup{processingWaiter, new(sync.WaitGroup)}
+	concurrentActor.inbox = newInbox(4, synchronousProcessingWaiter)
+	concurrentActor.dependencies = Dependencies{
+		WaitGroup:    synchronousProcessingWaiter,
+		ErrorHandler: new(MockErrorHandler),
+	}
+
+	contextFirstCopy := new(MockContext)
+	contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
+	contextFirstCopy.On("SetStateHolder", actor).Return()
+	contextFirstCopy.On("Copy").Return(contextSecondCopy)
+
+	contextOriginal := new(MockContext)
+	contextOriginal.On("Copy").Return(contextFirstCopy)
+
+	// the deferred messages
+	concurrentActor.SendMessage(context.Message{Name: "message_0", Arguments: []interface{}{1}})
+	concurrentActor.SendMessage(context.Message{Name: "message_0", Arguments: []interface{}{2}})
+	// the message that sets the state
+	concurrentActor.SendMessage(context.Message{Name: "message_1"})
+	// the message processed after the replayed ones
+	concurrentActor.SendMessage(context.Message{Name: "message_2"})
+
+	go concurrentActor.Start(contextOriginal, nil)
+	synchronousProcessingWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(
+		test,
+		contextOriginal,
+		contextFirstCopy,
+		contextSecondCopy,
+		deferCommand,
+		setCommand,
+		processingWaiter,
+		concurrentActor.dependencies.ErrorHandler,
+	)
+	assert.Equal(test, []int{10, 20, 20, 30}, log.commands)
+	assert.Empty(test, concurrentActor.deferredMessages)
+	assert.Empty(test, concurrentActor.replayedMessages)
+}
+
+func TestConcurrentActor_withRestarting(test *testing.T) {
+	for _, testData := range []struct {
+		name         string
+		enterHookErr error
+		wantStopped  assert.BoolAssertionFunc
+		wantErrCount int
+	}{
+		{
+			name:         "success",
+			enterHookErr: nil,
+			wantStopped:  assert.False,
+			wantErrCount: 1,
+		},
+		{
+			name:         "error in the enter hook",
+			enterHookErr: iotest.ErrTimeout,
+			wantStopped:  assert.True,
+			wantErrCount: 2,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			enterCommand := new(MockCommand)
+			enterCommand.
+				On("Run", mock.AnythingOfType("*context.DefaultContext")).
+				Return(types.Nil{}, testData.enterHookErr).
+				Once()
+
+			failedCommand := new(MockCommand)
+			failedCommand.
+				On("Run", mock.AnythingOfType("*context.DefaultContext")).
+				Return(nil, iotest.ErrTimeout).
+				Once()
+
+			states := StateGroup{
+				"state_0": NewParameterizedMessageGroup(nil, MessageGroup{}).
+					WithHooks(CommandGroup{enterCommand}, nil),
+				"state_1": NewParameterizedMessageGroup(nil, MessageGroup{
+					"test": NewParameterizedCommandGroup(nil, CommandGroup{failedCommand}),
+				}),
+			}
+
+			errorHandler := new(MockErrorHandler)
+			errorHandler.
+				On("LogError", mock.AnythingOfType("*errors.withStack")).
+				Return().
+				Times(testData.wantErrCount)
+
+			var waiter sync.WaitGroup
+			var buffer bytes.Buffer
+			actor := &ConcurrentActor{
+				id:        23,
+				className: "Test",
+				innerActor: &Actor{
+					states:       NewParameterizedStateGroup(nil, states),
+					currentState: context.State{Name: "state_1"},
+				},
+				initialState:     context.State{Name: "state_0"},
+				inbox:            newInbox(1, &waiter),
+				failurePolicy:    RestartActorPolicy,
+				deferredMessages: []context.Message{{Name: "deferred"}},
+				dependencies: Dependencies{
+					WaitGroup:    &waiter,
+					ErrorHandler: errorHandler,
+					Tracer:       NewTraceWriter(&buffer, JSONLinesTraceFormat),
+				},
+			}
+
+			go actor.Start(context.NewDefaultContext(), nil)
+
+			actor.SendMessage(context.Message{Name: "test"})
+			waiter.Wait()
+
+			mock.AssertExpectationsForObjects(test, enterCommand, failedCommand, errorHandler)
+			assert.Equal(test, context.State{Name: "state_0"}, actor.innerActor.currentState)
+			assert.Empty(test, actor.deferredMessages)
+			testData.wantStopped(test, actor.stopped)
+			assert.Contains(
+				test,
+				buffer.String(),
+				`"event":"state_change","actor":23,"class":"Test",`+
+					`"state":"state_0","previous_state":"state_1"`,
+			)
+		})
+	}
+}
+
+func TestConcurrentActor_withControlMessageNames(test *testing.T) {
+	for _, name := range []string{RestartMessageName, StopMessageName} {
+		test.Run(name, func(test *testing.T) {
+			waitGroup := new(MockWaitGroup)
+			waitGroup.On("Done").Return().Once()
+
+			actor := &ConcurrentActor{
+				innerActor:   &Actor{currentState: context.State{Name: "state_1"}},
+				initialState: context.State{Name: "state_0"},
+				stopped:      true,
+				dependencies: Dependencies{WaitGroup: waitGroup},
+			}
+			// the ordinary message with the name of the control one
+			got := actor.handleMessage(new(MockContext), nil, context.Message{Name: name})
+
+			mock.AssertExpectationsForObjects(test, waitGroup)
+			assert.True(test, got)
+			assert.True(test, actor.stopped)
+			assert.Equal(test, context.State{Name: "state_1"}, actor.innerActor.currentState)
+		})
+	}
+}
+
+func TestConcurrentActor_DeferMessage(test *testing.T) {
+	message := context.Message{Name: "test", Arguments: []interface{}{23}}
+	actor := &ConcurrentActor{currentMessage: &message}
+	err := actor.DeferMessage()
+
+	assert.Equal(test, []context.Message{message}, actor.deferredMessages)
+	assert.NoError(test, err)
+}
+
+func TestConcurrentActor_DeferMessage_withoutMessage(test *testing.T) {
+	actor := new(ConcurrentActor)
+	err := actor.DeferMessage()
+
+	assert.Empty(test, actor.deferredMessages)
+	assert.Error(test, err)
+}
+
+func TestConcurrentActor_Reply(test *testing.T) {
+	replies := make(chan interface{}, 1)
+	actor := &ConcurrentActor{currentMessage: &context.Message{Name: "test", Reply: replies}}
+	gotFirstResult := actor.Reply(23)
+	gotSecondResult := actor.Reply(42)
+
+	assert.True(test, gotFirstResult)
+	assert.False(test, gotSecondResult)
+	assert.Equal(test, 23, <-replies)
+}
+
+func TestConcurrentActor_Reply_withoutReply(test *testing.T) {
+	for _, message := range []*context.Message{nil, {Name: "test"}} {
+		actor := &ConcurrentActor{currentMessage: message}
+		assert.False(test, actor.Reply(23))
+	}
+}
+
+func TestConcurrentActor_SendMessage_withInboxPolicies(test *testing.T) {
+	type fields struct {
+		inboxPolicy InboxPolicy
+		inbox       []string
+	}
+
+	for _, testData := range []struct {
+		name         string
+		fields       fields
+		message      string
+		wantInbox    []string
+		wantStats    InboxStats
+		wantLogError bool
+	}{
+		{
+			name:      "success with the unbounded policy",
+			fields:    fields{inboxPolicy: UnboundedInboxPolicy, inbox: []string{"one", "two"}},
+			message:   "three",
+			wantInbox: []string{"one", "two", "three"},
+			wantStats: InboxStats{Size: 3, Capacity: 2, Accepted: 3},
+		},
+		{
+			name:      "success with the drop-newest policy",
+			fields:    fields{inboxPolicy: DropNewestInboxPolicy, inbox: []string{"one", "two"}},
+			message:   "three",
+			wantInbox: []string{"one", "two"},
+			wantStats: InboxStats{Size: 2, Capacity: 2, Accepted: 2, Dropped: 1},
+		},
+		{
+			name:      "success with the drop-oldest policy",
+			fields:    fields{inboxPolicy: DropOldestInboxPolicy, inbox: []string{"one", "two"}},
+			message:   "three",
+			wantInbox: []string{"two", "three"},
+			wantStats: InboxStats{Size: 2, Capacity: 2, Accepted: 3, Dropped: 1},
+		},
+		{
+			name: "success with the drop-oldest policy and the oldest system message",
+			fields: fields{
+				inboxPolicy: DropOldestInboxPolicy,
+				inbox:       []string{RestartMessageName, "two"},
+			},
+			message:   "three",
+			wantInbox: []string{RestartMessageName, "three"},
+			wantStats: InboxStats{Size: 2, Capacity: 2, Accepted: 3, Dropped: 1},
+		},
+		{
+			name:         "success with the fail policy",
+			fields:       fields{inboxPolicy: FailInboxPolicy, inbox: []string{"one", "two"}},
+			message:      "three",
+			wantInbox:    []string{"one", "two"},
+			wantStats:    InboxStats{Size: 2, Capacity: 2, Accepted: 2, Rejected: 1},
+			wantLogError: true,
+		},
+		{
+			name:      "success with a system message",
+			fields:    fields{inboxPolicy: FailInboxPolicy, inbox: []string{"one", "two"}},
+			message:   StopMessageName,
+			wantInbox: []string{"one", "two", StopMessageName},
+			wantStats: InboxStats{Size: 3, Capacity: 2, Accepted: 3},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			waitGroup := new(MockWaitGroup)
+			waitGroup.On("Add", 1).Times(len(testData.fields.inbox) + 1)
+			if droppedCount := testData.wantStats.Dropped + testData.wantStats.Rejected; droppedCount != 0 {
+				waitGroup.On("Done").Times(droppedCount)
+			}
+
+			errorHandler := new(MockErrorHandler)
+			if testData.wantLogError {
+				errorHandler.On("LogError", mock.AnythingOfType("*errors.fundamental")).Return().Once()
+			}
+
+			actor := &ConcurrentActor{
+				className:    "Test",
+				inbox:        newInbox(len(testData.fields.inbox), waitGroup),
+				inboxPolicy:  testData.fields.inboxPolicy,
+				dependencies: Dependencies{WaitGroup: waitGroup, ErrorHandler: errorHandler},
+			}
+			for _, name := range testData.fields.inbox {
+				actor.SendMessage(context.Message{Name: name})
+			}
+			actor.SendMessage(context.Message{Name: testData.message})
+
+			gotStats := actor.InboxStats()
+			var gotInbox []string
+			for index := 0; index < gotStats.Size; index++ {
+				message, _ := actor.inbox.receive()
+				gotInbox = append(gotInbox, message.Name)
+			}
+
+			mock.AssertExpectationsForObjects(test, waitGroup, errorHandler)
+			assert.Equal(test, testData.wantInbox, gotInbox)
+			assert.Equal(test, testData.wantStats, gotStats)
+		})
+	}
+}
+
+func TestConcurrentActor_SendMessage_withBlockInboxPolicy(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(2)
+
+	actor := &ConcurrentActor{
+		className:    "Test",
+		inbox:        newInbox(1, waitGroup),
+		inboxPolicy:  BlockInboxPolicy,
+		dependencies: Dependencies{WaitGroup: waitGroup},
+	}
+	actor.SendMessage(context.Message{Name: "one"})
+
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		actor.SendMessage(context.Message{Name: "two"})
+	}()
+
+	select {
+	case <-sent:
+		require.Fail(test, "the sending isn't blocked")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	gotFirstMessage, _ := actor.inbox.receive()
+	<-sent
+	gotSecondMessage, _ := actor.inbox.receive()
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.Equal(test, context.Message{Name: "one"}, gotFirstMessage)
+	assert.Equal(test, context.Message{Name: "two"}, gotSecondMessage)
+	assert.Equal(test, InboxStats{Capacity: 1, Accepted: 2, Blocked: 1}, actor.InboxStats())
+}
+
+func TestConcurrentActor_SendMessage_withBlockInboxPolicyAndItself(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(2)
+
+	actor := &ConcurrentActor{
+		className:    "Test",
+		inbox:        newInbox(1, waitGroup),
+		inboxPolicy:  BlockInboxPolicy,
+		dependencies: Dependencies{WaitGroup: waitGroup},
+	}
+	actor.SendMessage(context.Message{Name: "one", Sender: actor})
+	// it shouldn't block
+	actor.SendMessage(context.Message{Name: "two", Sender: actor})
+
+	gotFirstMessage, _ := actor.inbox.receive()
+	gotSecondMessage, _ := actor.inbox.receive()
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.Equal(test, "one", gotFirstMessage.Name)
+	assert.Equal(test, "two", gotSecondMessage.Name)
+	assert.Equal(test, InboxStats{Capacity: 1, Accepted: 2}, actor.InboxStats())
+}
+
+func TestConcurrentActor_withDeadLetters(test *testing.T) {
+	type fields struct {
+		deadLetters  DeadLetterMode
+		withHandler  bool
+		wantLogError bool
+	}
+
+	for _, testData := range []struct {
+		name    string
+		fields  fields
+		wantLog []int
+	}{
+		{
+			name:    "success with the ignore mode",
+			fields:  fields{deadLetters: IgnoreDeadLetters},
+			wantLog: []int{4, 5},
+		},
+		{
+			name:    "success with the warn mode",
+			fields:  fields{deadLetters: WarnOnDeadLetters, wantLogError: true},
+			wantLog: []int{4, 5},
+		},
+		{
+			// the actor is stopped by its failure policy
+			name:    "success with the error mode",
+			fields:  fields{deadLetters: FailOnDeadLetters, wantLogError: true},
+			wantLog: nil,
+		},
+		{
+			name:    "success with the actor mode",
+			fields:  fields{deadLetters: ForwardDeadLetters, withHandler: true},
+			wantLog: []int{100, 101, 4, 5},
+		},
+		{
+			name:    "success with the actor mode and without the handler",
+			fields:  fields{deadLetters: ForwardDeadLetters, wantLogError: true},
+			wantLog: []int{4, 5},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var log commandLog
+			contextSecondCopy := new(MockContext)
+			if testData.wantLog != nil {
+				contextSecondCopy.On("SetValue", SenderValueName, types.Nil{}).Return()
+			}
+
+			options := loggableCommandOptions{"message_2": {withCalls()}}
+			states := newLoggableParameterizedStates(contextSecondCopy, &log, group(2), group(2), group(2), options)
+			if testData.fields.withHandler {
+				contextSecondCopy.On("SetValue", "name", types.NewPairFromText("message_0")).Return()
+				contextSecondCopy.On("SetValue", "args", &types.Pair{Head: 23}).Return()
+
+				config := parameterizedGroup(2, "name", "args")
+				config.idOffset = 100
+
+				states.StateGroup["state_1"].messages[UnhandledMessageName] =
+					newLoggableParameterizedCommands(contextSecondCopy, &log, config, withCalls())
+			}
+			actor := &Actor{states: states, currentState: context.State{Name: "state_1"}}
+
+			processingWaiter := new(MockWaitGroup)
+			processingWaiter.On("Add", 1).Times(4)
+			processingWaiter.On("Done").Times(4)
+			processingWaiter.On("Wait").Times(1)
+
+			errorHandler := new(MockErrorHandler)
+			if testData.fields.wantLogError {
+				errorHandler.On("LogError", mock.MatchedBy(func(error) bool { return true })).Times(1)
+			}
+
+			synchronousProcessingWaiter := syncutils.MultiWaitGroup{processingWaiter, new(sync.WaitGroup)}
+			concurrentActor := &ConcurrentActor{
+				innerActor:    actor,
+				inbox:         newInbox(4, synchronousProcessingWaiter),
+				failurePolicy: StopActorPolicy,
+				deadLetters:   testData.fields.deadLetters,
+				dependencies: Dependencies{
+					WaitGroup:    synchronousProcessingWaiter,
+					ErrorHandler: errorHandler,
+				},
+			}
+
+			contextFirstCopy := new(MockContext)
+			contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
+			contextFirstCopy.On("SetStateHolder", actor).Return()
+			if testData.wantLog != nil {
+				contextFirstCopy.On("Copy").Return(contextSecondCopy)
+			}
+
+			contextOriginal := new(MockContext)
+			contextOriginal.On("Copy").Return(contextFirstCopy)
+
+			go concurrentActor.Start(contextOriginal, nil)
+
+			// the dead letter
+			concurrentActor.SendMessage(context.Message{Name: "message_0", Arguments: []interface{}{23}})
+			// the broadcast message, it isn't a dead letter
+			concurrentActor.SendMessage(context.Message{Name: "message_0", Broadcast: true})
+			// the system message, it isn't a dead letter
+			concurrentActor.SendMessage(context.Message{Name: "__unknown__"})
+			// the handled message
+			concurrentActor.SendMessage(context.Message{Name: "message_2"})
+			synchronousProcessingWaiter.Wait()
+
+			mock.AssertExpectationsForObjects(
+				test,
+				contextOriginal,
+				contextFirstCopy,
+				contextSecondCopy,
+				processingWaiter,
+				errorHandler,
+			)
+			assert.Equal(test, testData.wantLog, log.commands)
+		})
+	}
+}
+
+func TestConcurrentActor_String(test *testing.T) {
+	actor := &ConcurrentActor{id: 23, className: "Test"}
+	got := actor.String()
+
+	assert.Equal(test, "<actor Test#23>", got)
+}
+
+func TestConcurrentActor_MarshalText(test *testing.T) {
+	actor := &ConcurrentActor{id: 23, className: "Test"}
+	gotBytes, gotErr := json.Marshal(actor)
+
+	assert.Equal(test, []byte(`"\u003cactor Test#23\u003e"`), gotBytes)
+	assert.NoError(test, gotErr)
+}
+
 func TestConcurrentActorFactory(test *testing.T) {
 	actorFactory := ActorFactory{
 		name:         "Test",
@@ -289,19 +1139,96 @@ func TestConcurrentActorFactory(test *testing.T) {
 
 	mock.AssertExpectationsForObjects(test, dependencies.WaitGroup, dependencies.ErrorHandler)
 
-	assert.Equal(test, 23, cap(got.inbox))
+	assert.Equal(test, 23, got.inbox.capacity)
+	assert.Equal(test, dependencies.WaitGroup, got.inbox.waitGroup)
 	got.inbox = nil
 
-	want := ConcurrentActor{
+	assert.NotZero(test, got.id)
+	got.id = 0
+
+	want := &ConcurrentActor{
+		className: "Test",
 		innerActor: &Actor{
 			states:       ParameterizedStateGroup{StateGroup: StateGroup{"state_0": {}, "state_1": {}}},
 			currentState: context.State{Name: "state_0"},
 		},
+		initialState: context.State{Name: "state_0"},
 		dependencies: dependencies,
 	}
 	assert.Equal(test, want, got)
 }
 
+func TestConcurrentActorFactory_WithValues(test *testing.T) {
+	actorFactory := ActorFactory{
+		name:         "Test",
+		states:       ParameterizedStateGroup{StateGroup: StateGroup{"state_0": {}}},
+		initialState: context.State{Name: "state_0"},
+	}
+	values := context.ValueGroup{"one": 23, "two": 42}
+	factory := NewConcurrentActorFactory(actorFactory, 0, Dependencies{}).WithValues(values)
+	got := factory.CreateActor()
+
+	assert.Equal(test, values, factory.values)
+	assert.Equal(test, values, got.values)
+}
+
+func TestConcurrentActorFactory_WithFailurePolicy(test *testing.T) {
+	actorFactory := ActorFactory{
+		name:         "Test",
+		states:       ParameterizedStateGroup{StateGroup: StateGroup{"state_0": {}}},
+		initialState: context.State{Name: "state_0"},
+	}
+	factory :=
+		NewConcurrentActorFactory(actorFactory, 0, Dependencies{}).WithFailurePolicy(StopActorPolicy)
+	got := factory.CreateActor()
+
+	assert.Equal(test, StopActorPolicy, factory.failurePolicy)
+	assert.Equal(test, StopActorPolicy, got.failurePolicy)
+}
+
+func TestConcurrentActorFactory_WithInboxPolicy(test *testing.T) {
+	actorFactory := ActorFactory{
+		name:         "Test",
+		states:       ParameterizedStateGroup{StateGroup: StateGroup{"state_0": {}}},
+		initialState: context.State{Name: "state_0"},
+	}
+	factory :=
+		NewConcurrentActorFactory(actorFactory, 0, Dependencies{}).WithInboxPolicy(BlockInboxPolicy)
+	got := factory.CreateActor()
+
+	assert.Equal(test, BlockInboxPolicy, factory.inboxPolicy)
+	assert.Equal(test, BlockInboxPolicy, got.inboxPolicy)
+}
+
+func TestConcurrentActorFactory_WithDeadLetterMode(test *testing.T) {
+	actorFactory := ActorFactory{
+		name:         "Test",
+		states:       ParameterizedStateGroup{StateGroup: StateGroup{"state_0": {}}},
+		initialState: context.State{Name: "state_0"},
+	}
+	factory := NewConcurrentActorFactory(actorFactory, 0, Dependencies{}).
+		WithDeadLetterMode(ForwardDeadLetters)
+	got := factory.CreateActor()
+
+	assert.Equal(test, ForwardDeadLetters, factory.deadLetters)
+	assert.Equal(test, ForwardDeadLetters, got.deadLetters)
+}
+
+func TestConcurrentActorFactory_withSeveralActors(test *testing.T) {
+	actorFactory := ActorFactory{
+		name:         "Test",
+		states:       ParameterizedStateGroup{StateGroup: StateGroup{"state_0": {}}},
+		initialState: context.State{Name: "state_0"},
+	}
+	factory := NewConcurrentActorFactory(actorFactory, 0, Dependencies{})
+	gotOne := factory.CreateActor()
+	gotTwo := factory.CreateActor()
+
+	assert.NotEqual(test, gotOne.ID(), gotTwo.ID())
+	assert.Equal(test, fmt.Sprintf("<actor Test#%d>", gotOne.ID()), gotOne.String())
+	assert.Equal(test, fmt.Sprintf("<actor Test#%d>", gotTwo.ID()), gotTwo.String())
+}
+
 func TestNewConcurrentActorGroup(test *testing.T) {
 	contextFirstCopy := new(MockContext)
 	contextFirstCopy.
@@ -310,6 +1237,12 @@ func TestNewConcurrentActorGroup(test *testing.T) {
 	contextFirstCopy.
 		On("SetActorRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).
 		Return()
+	contextFirstCopy.
+		On("SetTopicRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).
+		Return()
+	contextFirstCopy.
+		On("SetNameRegister", mock.AnythingOfType("*runtime.ConcurrentActorGroup")).
+		Return()
 
 	contextOriginal := new(MockContext)
 	contextOriginal.On("Copy").Return(contextFirstCopy)
@@ -318,6 +1251,7 @@ func TestNewConcurrentActorGroup(test *testing.T) {
 
 	mock.AssertExpectationsForObjects(test, contextOriginal, contextFirstCopy)
 	assert.Equal(test, got.context, contextFirstCopy)
+	assert.Equal(test, GoroutineScheduler{}, got.scheduler)
 	assert.Nil(test, got.actors)
 }
 
@@ -371,37 +1305,50 @@ func TestConcurrentActorGroup(test *testing.T) {
 				waiter.On("Done").Times(messageCount)
 			}
 
-			contextSecondCopy := new(MockContext)
-			contextFirstCopy := new(MockContext)
-			if messageCount != 0 {
-				contextFirstCopy.On("Copy").Return(contextSecondCopy)
-			}
-
-			contextOriginal := new(MockContext)
-			if len(testData.fields) != 0 {
-				contextOriginal.On("Copy").Return(contextFirstCopy)
-			}
-
 			var log commandLog
 			synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
 			errorHandler := new(MockErrorHandler)
-			concurrentActors := &ConcurrentActorGroup{context: contextOriginal}
+			contextOriginal := new(MockContext)
+			concurrentActors := &ConcurrentActorGroup{
+				context:   contextOriginal,
+				scheduler: GoroutineScheduler{},
+			}
+			mocks := []interface{}{contextOriginal, waiter, errorHandler}
 			for _, args := range testData.fields {
+				// each actor has its own contexts, because the mocks shared between
+				// the actors are raced on formatting of their calls
+				contextSecondCopy := new(MockContext)
+				contextSecondCopy.On("SetValue", SenderValueName, types.Nil{}).Return()
+
 				states := args.makeStates(contextSecondCopy, &log)
 				defer checkStates(test, states.StateGroup)
 
-				actor := &Actor{states, args.currentState}
-				contextFirstCopy.On("SetStateHolder", actor).Return()
-
-				concurrentActor := ConcurrentActor{
+				actor := &Actor{states: states, currentState: args.currentState}
+				concurrentActor := &ConcurrentActor{
 					innerActor: actor,
-					inbox:      make(inbox),
+					inbox:      newInbox(0, synchronousWaiter),
 					dependencies: Dependencies{
 						WaitGroup:    synchronousWaiter,
 						ErrorHandler: errorHandler,
 					},
 				}
+
+				started := make(chan struct{})
+				contextFirstCopy := new(MockContext)
+				contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
+				contextFirstCopy.
+					On("SetStateHolder", actor).
+					Return().
+					Run(func(mock.Arguments) { close(started) })
+				contextFirstCopy.On("Copy").Return(contextSecondCopy)
+				contextOriginal.On("Copy").Return(contextFirstCopy).Once()
+
+				// the actors are registered one by one
+				// to bind them to their contexts
 				concurrentActors.RegisterActor(concurrentActor, nil)
+				<-started
+
+				mocks = append(mocks, contextFirstCopy, contextSecondCopy)
 			}
 
 			for _, message := range testData.messages {
@@ -409,14 +1356,7 @@ func TestConcurrentActorGroup(test *testing.T) {
 			}
 			synchronousWaiter.Wait()
 
-			mock.AssertExpectationsForObjects(
-				test,
-				contextOriginal,
-				contextFirstCopy,
-				contextSecondCopy,
-				waiter,
-				errorHandler,
-			)
+			mock.AssertExpectationsForObjects(test, mocks...)
 			assert.ElementsMatch(test, testData.wantLog, log.commands)
 		})
 	}
@@ -452,6 +1392,7 @@ func TestConcurrentActorGroup_withArguments(test *testing.T) {
 			args: args{
 				contextSecondCopy: func() context.Context {
 					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
 					context.On("SetValue", "one", 5).Return()
 					context.On("SetValue", "two", 12).Return()
 
@@ -483,6 +1424,7 @@ func TestConcurrentActorGroup_withArguments(test *testing.T) {
 			args: args{
 				contextSecondCopy: func() context.Context {
 					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
 					context.On("SetValue", "one", 5).Return()
 					context.On("SetValue", "two", 12).Return()
 					context.On("SetValue", "two", 23).Return()
@@ -518,6 +1460,7 @@ func TestConcurrentActorGroup_withArguments(test *testing.T) {
 			args: args{
 				contextSecondCopy: func() context.Context {
 					context := new(MockContext)
+					context.On("SetValue", SenderValueName, types.Nil{}).Return()
 					context.On("SetValue", "one", 5).Return()
 					context.On("SetValue", "two", 12).Return()
 					context.On("SetValue", "two", 23).Return()
@@ -550,20 +1493,25 @@ func TestConcurrentActorGroup_withArguments(test *testing.T) {
 
 			var log commandLog
 			states := testData.fields.makeStates(testData.args.contextSecondCopy, &log)
-			actor := &Actor{states, testData.fields.currentState}
+			actor := &Actor{states: states, currentState: testData.fields.currentState}
 			contextFirstCopy.On("SetStateHolder", actor).Return()
 
 			synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
 			errorHandler := new(MockErrorHandler)
-			concurrentActor := ConcurrentActor{
+			concurrentActor := &ConcurrentActor{
 				innerActor: actor,
-				inbox:      make(inbox),
+				inbox:      newInbox(0, synchronousWaiter),
 				dependencies: Dependencies{
 					WaitGroup:    synchronousWaiter,
 					ErrorHandler: errorHandler,
 				},
 			}
-			concurrentActors := &ConcurrentActorGroup{context: contextOriginal}
+			contextFirstCopy.On("SetValue", SelfValueName, concurrentActor).Return()
+
+			concurrentActors := &ConcurrentActorGroup{
+				context:   contextOriginal,
+				scheduler: GoroutineScheduler{},
+			}
 			concurrentActors.RegisterActor(concurrentActor, testData.args.arguments)
 			concurrentActors.SendMessage(testData.args.message)
 			synchronousWaiter.Wait()
@@ -581,3 +1529,97 @@ func TestConcurrentActorGroup_withArguments(test *testing.T) {
 		})
 	}
 }
+
+func TestConcurrentActorGroup_withBroadcastDeadLetters(test *testing.T) {
+	for _, testData := range []struct {
+		name         string
+		deadLetters  DeadLetterMode
+		withHandler  bool
+		wantLogError bool
+	}{
+		{
+			name:         "success with the handled message",
+			deadLetters:  WarnOnDeadLetters,
+			withHandler:  true,
+			wantLogError: false,
+		},
+		{
+			name:         "success with the ignore mode",
+			deadLetters:  IgnoreDeadLetters,
+			withHandler:  false,
+			wantLogError: false,
+		},
+		{
+			name:         "success with the unhandled message",
+			deadLetters:  WarnOnDeadLetters,
+			withHandler:  false,
+			wantLogError: true,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			handlingCommand := new(MockCommand)
+			if testData.withHandler {
+				handlingCommand.
+					On("Run", mock.AnythingOfType("*context.DefaultContext")).
+					Return(types.Nil{}, nil).
+					Once()
+			}
+
+			errorHandler := new(MockErrorHandler)
+			if testData.wantLogError {
+				errorHandler.
+					On("LogError", mock.MatchedBy(func(err error) bool {
+						return err.Error() == "the broadcast message test isn't handled by any actor"
+					})).
+					Return().
+					Once()
+			}
+
+			var waiter sync.WaitGroup
+			group := NewConcurrentActorGroup(context.NewDefaultContext())
+			// only the first actor may handle the message
+			for index := 0; index < 2; index++ {
+				messages := MessageGroup{}
+				if testData.withHandler && index == 0 {
+					messages["test"] = NewParameterizedCommandGroup(nil, CommandGroup{handlingCommand})
+				}
+
+				states := StateGroup{"state_0": NewParameterizedMessageGroup(nil, messages)}
+				actorFactory, err := NewActorFactory(
+					"Test",
+					NewParameterizedStateGroup(nil, states),
+					context.State{Name: "state_0"},
+				)
+				require.NoError(test, err)
+
+				factory := NewConcurrentActorFactory(actorFactory, 1, Dependencies{
+					WaitGroup:    &waiter,
+					ErrorHandler: errorHandler,
+				})
+				group.RegisterActor(factory.WithDeadLetterMode(testData.deadLetters).CreateActor(), nil)
+			}
+
+			group.SendMessage(context.Message{Name: "test"})
+			waiter.Wait()
+
+			mock.AssertExpectationsForObjects(test, handlingCommand, errorHandler)
+		})
+	}
+}
+
+func TestConcurrentActorGroup_UnregisterActor(test *testing.T) {
+	actorOne := &ConcurrentActor{id: 1}
+	actorTwo := &ConcurrentActor{id: 2}
+	actorThree := &ConcurrentActor{id: 3}
+	group := &ConcurrentActorGroup{actors: []context.Actor{actorOne, actorTwo, actorThree}}
+	group.SubscribeActor("topic", actorOne)
+	group.SubscribeActor("topic", actorTwo)
+	group.RegisterActorName("one", actorOne)
+	group.RegisterActorName("two", actorTwo)
+	group.UnregisterActor(actorTwo)
+	group.UnregisterActor(&ConcurrentActor{id: 4})
+
+	assert.Equal(test, []context.Actor{actorOne, actorThree}, group.actors)
+	assert.Equal(test, map[string][]context.Actor{"topic": {actorOne}}, group.topics)
+	assert.Equal(test, map[string]context.Actor{"one": actorOne}, group.NameRegistry.actors)
+}
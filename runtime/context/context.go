@@ -1,9 +1,61 @@
 package context
 
+import (
+	"sync/atomic"
+)
+
 // Message ...
+//
+// The Broadcast flag is set for messages sent to all actors or published
+// to a topic. The Receipt is set for messages sent to all actors to detect ones
+// that aren't handled by any of them. The Reply channel is set for messages
+// that wait for a reply.
+// Messages with higher priorities are processed first; the default priority
+// is zero.
+//
+// Control messages (e.g. a restart or a stop of an actor) can be created only
+// by the NewControlMessage function, so programs can't send them.
 type Message struct {
 	Name      string
 	Arguments []interface{}
+	Sender    Actor
+	Broadcast bool
+	Receipt   *BroadcastReceipt
+	Reply     chan<- interface{}
+	Priority  int
+
+	control bool
+}
+
+// NewControlMessage ...
+func NewControlMessage(name string) Message {
+	return Message{Name: name, control: true}
+}
+
+// IsControl ...
+func (message Message) IsControl() bool {
+	return message.control
+}
+
+// BroadcastReceipt ...
+//
+// It's shared by the copies of the broadcast message sent to different actors.
+type BroadcastReceipt struct {
+	receiverCount  int64
+	rejectionCount int64
+}
+
+// NewBroadcastReceipt ...
+func NewBroadcastReceipt(receiverCount int) *BroadcastReceipt {
+	return &BroadcastReceipt{receiverCount: int64(receiverCount)}
+}
+
+// Reject ...
+//
+// It registers the receiver that doesn't handle the message and returns true,
+// if all receivers don't handle it.
+func (receipt *BroadcastReceipt) Reject() bool {
+	return atomic.AddInt64(&receipt.rejectionCount, 1) == receipt.receiverCount
 }
 
 //go:generate mockery --name=MessageSender --inpackage --case=underscore --testonly
@@ -22,8 +74,10 @@ type State struct {
 //go:generate mockery --name=StateHolder --inpackage --case=underscore --testonly
 
 // StateHolder ...
+//
+// The context is used for running of the state hooks.
 type StateHolder interface {
-	SetState(state State) error
+	SetState(context Context, state State) error
 }
 
 // Actor ...
@@ -38,6 +92,24 @@ type Actor interface {
 // ActorRegister ...
 type ActorRegister interface {
 	RegisterActor(actor Actor, arguments []interface{})
+	UnregisterActor(actor Actor)
+}
+
+//go:generate mockery --name=TopicRegister --inpackage --case=underscore --testonly
+
+// TopicRegister ...
+type TopicRegister interface {
+	SubscribeActor(topic string, actor Actor)
+	UnsubscribeActor(topic string, actor Actor)
+	PublishMessage(topic string, message Message)
+}
+
+//go:generate mockery --name=NameRegister --inpackage --case=underscore --testonly
+
+// NameRegister ...
+type NameRegister interface {
+	RegisterActorName(name string, actor Actor) error
+	LookupActor(name string) (Actor, bool)
 }
 
 //go:generate mockery --name=Context --inpackage --case=underscore --testonly
@@ -47,11 +119,15 @@ type Context interface {
 	MessageSender
 	StateHolder
 	ActorRegister
+	TopicRegister
+	NameRegister
 	ValueStore
 
 	SetMessageSender(sender MessageSender)
 	SetStateHolder(holder StateHolder)
 	SetActorRegister(register ActorRegister)
+	SetTopicRegister(register TopicRegister)
+	SetNameRegister(register NameRegister)
 	SetValueStore(store CopyableValueStore)
 	Copy() Context
 }
@@ -61,6 +137,8 @@ type DefaultContext struct {
 	MessageSender
 	StateHolder
 	ActorRegister
+	TopicRegister
+	NameRegister
 	CopyableValueStore
 }
 
@@ -85,6 +163,16 @@ func (context *DefaultContext) SetActorRegister(register ActorRegister) {
 	context.ActorRegister = register
 }
 
+// SetTopicRegister ...
+func (context *DefaultContext) SetTopicRegister(register TopicRegister) {
+	context.TopicRegister = register
+}
+
+// SetNameRegister ...
+func (context *DefaultContext) SetNameRegister(register NameRegister) {
+	context.NameRegister = register
+}
+
 // SetValueStore ...
 func (context *DefaultContext) SetValueStore(store CopyableValueStore) {
 	context.CopyableValueStore = store
@@ -96,6 +184,8 @@ func (context DefaultContext) Copy() Context {
 		MessageSender:      context.MessageSender,
 		StateHolder:        context.StateHolder,
 		ActorRegister:      context.ActorRegister,
+		TopicRegister:      context.TopicRegister,
+		NameRegister:       context.NameRegister,
 		CopyableValueStore: context.CopyableValueStore.Copy(),
 	}
 }
@@ -8,6 +8,21 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
+func TestNewControlMessage(test *testing.T) {
+	got := NewControlMessage("test")
+
+	assert.Equal(test, "test", got.Name)
+	assert.True(test, got.IsControl())
+	assert.False(test, Message{Name: "test"}.IsControl())
+}
+
+func TestBroadcastReceipt(test *testing.T) {
+	receipt := NewBroadcastReceipt(2)
+
+	assert.False(test, receipt.Reject())
+	assert.True(test, receipt.Reject())
+}
+
 func TestNewDefaultContext(test *testing.T) {
 	got := NewDefaultContext()
 
@@ -43,6 +58,24 @@ func TestDefaultContext_SetActorRegister(test *testing.T) {
 	assert.Equal(test, DefaultContext{ActorRegister: register}, defaultContext)
 }
 
+func TestDefaultContext_SetTopicRegister(test *testing.T) {
+	register := new(MockTopicRegister)
+	defaultContext := DefaultContext{}
+	defaultContext.SetTopicRegister(register)
+
+	mock.AssertExpectationsForObjects(test, register)
+	assert.Equal(test, DefaultContext{TopicRegister: register}, defaultContext)
+}
+
+func TestDefaultContext_SetNameRegister(test *testing.T) {
+	register := new(MockNameRegister)
+	defaultContext := DefaultContext{}
+	defaultContext.SetNameRegister(register)
+
+	mock.AssertExpectationsForObjects(test, register)
+	assert.Equal(test, DefaultContext{NameRegister: register}, defaultContext)
+}
+
 func TestDefaultContext_SetValueStore(test *testing.T) {
 	store := new(MockCopyableValueStore)
 	defaultContext := DefaultContext{}
@@ -59,15 +92,19 @@ func TestDefaultContext_Copy(test *testing.T) {
 	sender := new(MockMessageSender)
 	holder := new(MockStateHolder)
 	register := new(MockActorRegister)
+	topicRegister := new(MockTopicRegister)
+	nameRegister := new(MockNameRegister)
 	defaultContext := &DefaultContext{
 		MessageSender:      sender,
 		StateHolder:        holder,
 		ActorRegister:      register,
+		TopicRegister:      topicRegister,
+		NameRegister:       nameRegister,
 		CopyableValueStore: store,
 	}
 	defaultContextCopy := defaultContext.Copy()
 
-	mock.AssertExpectationsForObjects(test, sender, holder, store)
+	mock.AssertExpectationsForObjects(test, sender, holder, register, topicRegister, nameRegister, store)
 	assert.Equal(test, defaultContext, defaultContextCopy)
 	if assert.IsType(test, &DefaultContext{}, defaultContextCopy) {
 		assert.NotEqual(
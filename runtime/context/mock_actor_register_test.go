@@ -13,3 +13,8 @@ type MockActorRegister struct {
 func (_m *MockActorRegister) RegisterActor(actor Actor, arguments []interface{}) {
 	_m.Called(actor, arguments)
 }
+
+// UnregisterActor provides a mock function with given fields: actor
+func (_m *MockActorRegister) UnregisterActor(actor Actor) {
+	_m.Called(actor)
+}
@@ -28,11 +28,53 @@ func (_m *MockContext) Copy() Context {
 	return r0
 }
 
+// LookupActor provides a mock function with given fields: name
+func (_m *MockContext) LookupActor(name string) (Actor, bool) {
+	ret := _m.Called(name)
+
+	var r0 Actor
+	if rf, ok := ret.Get(0).(func(string) Actor); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(Actor)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// PublishMessage provides a mock function with given fields: topic, message
+func (_m *MockContext) PublishMessage(topic string, message Message) {
+	_m.Called(topic, message)
+}
+
 // RegisterActor provides a mock function with given fields: actor, arguments
 func (_m *MockContext) RegisterActor(actor Actor, arguments []interface{}) {
 	_m.Called(actor, arguments)
 }
 
+// RegisterActorName provides a mock function with given fields: name, actor
+func (_m *MockContext) RegisterActorName(name string, actor Actor) error {
+	ret := _m.Called(name, actor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, Actor) error); ok {
+		r0 = rf(name, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendMessage provides a mock function with given fields: message
 func (_m *MockContext) SendMessage(message Message) {
 	_m.Called(message)
@@ -48,13 +90,18 @@ func (_m *MockContext) SetMessageSender(sender MessageSender) {
 	_m.Called(sender)
 }
 
-// SetState provides a mock function with given fields: state
-func (_m *MockContext) SetState(state State) error {
-	ret := _m.Called(state)
+// SetNameRegister provides a mock function with given fields: register
+func (_m *MockContext) SetNameRegister(register NameRegister) {
+	_m.Called(register)
+}
+
+// SetState provides a mock function with given fields: context, state
+func (_m *MockContext) SetState(context Context, state State) error {
+	ret := _m.Called(context, state)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(State) error); ok {
-		r0 = rf(state)
+	if rf, ok := ret.Get(0).(func(Context, State) error); ok {
+		r0 = rf(context, state)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -67,6 +114,11 @@ func (_m *MockContext) SetStateHolder(holder StateHolder) {
 	_m.Called(holder)
 }
 
+// SetTopicRegister provides a mock function with given fields: register
+func (_m *MockContext) SetTopicRegister(register TopicRegister) {
+	_m.Called(register)
+}
+
 // SetValue provides a mock function with given fields: name, value
 func (_m *MockContext) SetValue(name string, value interface{}) {
 	_m.Called(name, value)
@@ -77,6 +129,21 @@ func (_m *MockContext) SetValueStore(store CopyableValueStore) {
 	_m.Called(store)
 }
 
+// SubscribeActor provides a mock function with given fields: topic, actor
+func (_m *MockContext) SubscribeActor(topic string, actor Actor) {
+	_m.Called(topic, actor)
+}
+
+// UnregisterActor provides a mock function with given fields: actor
+func (_m *MockContext) UnregisterActor(actor Actor) {
+	_m.Called(actor)
+}
+
+// UnsubscribeActor provides a mock function with given fields: topic, actor
+func (_m *MockContext) UnsubscribeActor(topic string, actor Actor) {
+	_m.Called(topic, actor)
+}
+
 // Value provides a mock function with given fields: name
 func (_m *MockContext) Value(name string) (interface{}, bool) {
 	ret := _m.Called(name)
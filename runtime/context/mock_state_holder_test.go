@@ -9,13 +9,13 @@ type MockStateHolder struct {
 	mock.Mock
 }
 
-// SetState provides a mock function with given fields: state
-func (_m *MockStateHolder) SetState(state State) error {
-	ret := _m.Called(state)
+// SetState provides a mock function with given fields: context, state
+func (_m *MockStateHolder) SetState(context Context, state State) error {
+	ret := _m.Called(context, state)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(State) error); ok {
-		r0 = rf(state)
+	if rf, ok := ret.Get(0).(func(Context, State) error); ok {
+		r0 = rf(context, state)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,79 @@
+package runtime
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// UnhandledMessageName ...
+const UnhandledMessageName = "__unhandled__"
+
+// DeadLetterMode ...
+//
+// It determines how an actor reacts to a message that isn't handled
+// in its current state. System messages (i.e. ones with names like __name__)
+// are never considered as dead letters. The message sent to all actors
+// is considered as a dead letter, only if it isn't handled by all of them;
+// then it's logged with any mode except the ignore one.
+//
+// With the forward mode, the message is passed to the __unhandled__(name, args)
+// handler of the current state; if it's missing, the dead letter is logged.
+type DeadLetterMode int
+
+// ...
+const (
+	IgnoreDeadLetters DeadLetterMode = iota
+	WarnOnDeadLetters
+	FailOnDeadLetters
+	ForwardDeadLetters
+)
+
+// nolint: gochecknoglobals
+var (
+	deadLetterModeNames = []string{"ignore", "warn", "error", "actor"}
+)
+
+// DeadLetterModeNames ...
+func DeadLetterModeNames() []string {
+	return append([]string(nil), deadLetterModeNames...)
+}
+
+// ParseDeadLetterMode ...
+func ParseDeadLetterMode(name string) (DeadLetterMode, error) {
+	for mode, modeName := range deadLetterModeNames {
+		if modeName == name {
+			return DeadLetterMode(mode), nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown dead letter mode %s", name)
+}
+
+// String ...
+func (mode DeadLetterMode) String() string {
+	if mode < 0 || int(mode) >= len(deadLetterModeNames) {
+		return "unknown"
+	}
+
+	return deadLetterModeNames[mode]
+}
+
+// IsSystemMessageName ...
+func IsSystemMessageName(name string) bool {
+	return len(name) > 4 && strings.HasPrefix(name, "__") && strings.HasSuffix(name, "__")
+}
+
+func newBroadcastDeadLetterError(message context.Message) error {
+	return errors.Errorf("the broadcast message %s isn't handled by any actor", message.Name)
+}
+
+func newDeadLetterError(actor *ConcurrentActor, message context.Message) error {
+	return errors.Errorf(
+		"the message %s isn't handled by the actor %s in the state %s",
+		message.Name,
+		actor,
+		actor.innerActor.currentState.Name,
+	)
+}
@@ -0,0 +1,121 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterModeNames(test *testing.T) {
+	got := DeadLetterModeNames()
+	got[0] = "modified"
+
+	assert.Equal(test, []string{"ignore", "warn", "error", "actor"}, DeadLetterModeNames())
+}
+
+func TestParseDeadLetterMode(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		args    string
+		want    DeadLetterMode
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with the ignore mode",
+			args:    "ignore",
+			want:    IgnoreDeadLetters,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the warn mode",
+			args:    "warn",
+			want:    WarnOnDeadLetters,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the error mode",
+			args:    "error",
+			want:    FailOnDeadLetters,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the actor mode",
+			args:    "actor",
+			want:    ForwardDeadLetters,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    "unknown",
+			want:    0,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := ParseDeadLetterMode(testData.args)
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestDeadLetterMode_String(test *testing.T) {
+	for _, testData := range []struct {
+		name string
+		mode DeadLetterMode
+		want string
+	}{
+		{
+			name: "known mode",
+			mode: FailOnDeadLetters,
+			want: "error",
+		},
+		{
+			name: "unknown mode",
+			mode: DeadLetterMode(23),
+			want: "unknown",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.mode.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestIsSystemMessageName(test *testing.T) {
+	for _, testData := range []struct {
+		name        string
+		messageName string
+		want        assert.BoolAssertionFunc
+	}{
+		{
+			name:        "system message",
+			messageName: "__initialize__",
+			want:        assert.True,
+		},
+		{
+			name:        "user message",
+			messageName: "initialize",
+			want:        assert.False,
+		},
+		{
+			name:        "user message with a prefix",
+			messageName: "__initialize",
+			want:        assert.False,
+		},
+		{
+			name:        "user message from underscores",
+			messageName: "____",
+			want:        assert.False,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := IsSystemMessageName(testData.messageName)
+
+			testData.want(test, got)
+		})
+	}
+}
@@ -14,7 +14,11 @@ var ErrReturn = errors.New("return")
 //go:generate mockery --name=ErrorHandler --inpackage --case=underscore --testonly
 
 // ErrorHandler ...
+//
+// The LogError() method should only report the error, when the HandleError() method
+// may also terminate the program.
 type ErrorHandler interface {
+	LogError(err error)
 	HandleError(err error)
 }
 
@@ -23,18 +27,45 @@ type Exiter func(code int)
 
 // DefaultErrorHandler ...
 type DefaultErrorHandler struct {
-	writer io.Writer
-	exiter Exiter
+	writer  io.Writer
+	exiter  Exiter
+	sources *SourceGroup
 }
 
 // NewDefaultErrorHandler ...
 func NewDefaultErrorHandler(writer io.Writer, exiter Exiter) DefaultErrorHandler {
-	return DefaultErrorHandler{writer, exiter}
+	return DefaultErrorHandler{writer: writer, exiter: exiter}
+}
+
+// WithSources ...
+//
+// The sources are used to show an excerpt of the code at the error position.
+func (handler DefaultErrorHandler) WithSources(sources *SourceGroup) DefaultErrorHandler {
+	handler.sources = sources
+	return handler
+}
+
+// LogError ...
+func (handler DefaultErrorHandler) LogError(err error) {
+	position, ok := FindPosition(err)
+	if !ok {
+		fmt.Fprintf(handler.writer, "error: %s\n", err) // nolint: errcheck, gosec
+		return
+	}
+
+	message := fmt.Sprintf("error: %s: %s\n", position, err)
+	if handler.sources != nil {
+		if excerpt, ok := handler.sources.Excerpt(position); ok {
+			message += excerpt + "\n"
+		}
+	}
+
+	fmt.Fprint(handler.writer, message) // nolint: errcheck, gosec
 }
 
 // HandleError ...
 func (handler DefaultErrorHandler) HandleError(err error) {
-	fmt.Fprintf(handler.writer, "error: %s\n", err) // nolint: errcheck, gosec
+	handler.LogError(err)
 	handler.exiter(1)
 }
 
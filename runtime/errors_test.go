@@ -4,6 +4,7 @@ import (
 	"testing"
 	"testing/iotest"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
@@ -22,6 +23,56 @@ func TestDefaultErrorHandler(test *testing.T) {
 	mock.AssertExpectationsForObjects(test, writer, exiter)
 }
 
+func TestDefaultErrorHandler_LogError(test *testing.T) {
+	const message = "error: timeout\n"
+	writer := new(MockWriter)
+	writer.On("Write", []byte(message)).Return(len(message), nil)
+
+	exiter := new(MockExiterInterface)
+
+	NewDefaultErrorHandler(writer, exiter.Exit).LogError(iotest.ErrTimeout)
+
+	mock.AssertExpectationsForObjects(test, writer, exiter)
+}
+
+func TestDefaultErrorHandler_withPosition(test *testing.T) {
+	for _, testData := range []struct {
+		name        string
+		sources     *SourceGroup
+		wantMessage string
+	}{
+		{
+			name:        "without sources",
+			sources:     nil,
+			wantMessage: "error: test.tt:1:9: test: timeout\n",
+		},
+		{
+			name: "with sources",
+			sources: func() *SourceGroup {
+				sources := NewSourceGroup()
+				sources.AddSource("test.tt", "let x = y / 0")
+
+				return sources
+			}(),
+			wantMessage: "error: test.tt:1:9: test: timeout\nlet x = y / 0\n        ^\n",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			writer := new(MockWriter)
+			writer.On("Write", []byte(testData.wantMessage)).Return(len(testData.wantMessage), nil)
+
+			exiter := new(MockExiterInterface)
+			exiter.On("Exit", 1).Return()
+
+			position := Position{Filename: "test.tt", Line: 1, Column: 9}
+			err := errors.Wrap(NewPositionedError(iotest.ErrTimeout, position), "test")
+			NewDefaultErrorHandler(writer, exiter.Exit).WithSources(testData.sources).HandleError(err)
+
+			mock.AssertExpectationsForObjects(test, writer, exiter)
+		})
+	}
+}
+
 func TestNewUnknownStateError(test *testing.T) {
 	got := newUnknownStateError(context.State{Name: "test"})
 	assert.Equal(test, "unknown state test", got.Error())
@@ -0,0 +1,126 @@
+package expressions
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+const (
+	expectedResultCount = 2
+)
+
+const (
+	dataResultIndex = iota
+	errorResultIndex
+)
+
+var (
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+)
+
+// CallValue ...
+//
+// It calls either a function defined in a program or a Go function. The Go
+// function receives the context as the first argument, if it declares it.
+func CallValue(
+	ctx context.Context,
+	function interface{},
+	arguments []interface{},
+) (result interface{}, err error) {
+	if typedFunction, ok := function.(runtime.Function); ok {
+		return typedFunction.Call(ctx, arguments)
+	}
+
+	functionType, err := checkFunctionType(function, len(arguments))
+	if err != nil {
+		return nil, err
+	}
+
+	for index, argument := range arguments {
+		if err := checkArgumentType(functionType, index, argument); err != nil {
+			return nil, err
+		}
+	}
+
+	return callGoFunction(ctx, function, arguments)
+}
+
+func checkFunctionType(function interface{}, argumentCount int) (reflect.Type, error) {
+	functionType := reflect.TypeOf(function)
+	if functionType == nil || functionType.Kind() != reflect.Func {
+		return nil, errors.Errorf("value isn't function, it's %T", function)
+	}
+
+	parameterCount := functionType.NumIn() - parameterOffset(functionType)
+	if parameterCount != argumentCount {
+		return nil, errors.Errorf(
+			"incorrect count of function arguments (%d instead %d)",
+			argumentCount,
+			parameterCount,
+		)
+	}
+	if functionType.NumOut() != expectedResultCount {
+		return nil, errors.Errorf(
+			"incorrect count of function results (%d instead %d)",
+			functionType.NumOut(),
+			expectedResultCount,
+		)
+	}
+	if !functionType.Out(errorResultIndex).Implements(errorType) {
+		return nil, errors.Errorf(
+			"incorrect type of the result #%d of the function (%s instead %s)",
+			errorResultIndex,
+			functionType.Out(errorResultIndex),
+			errorType,
+		)
+	}
+
+	return functionType, nil
+}
+
+func checkArgumentType(functionType reflect.Type, index int, argument interface{}) error {
+	parameterType := functionType.In(index + parameterOffset(functionType))
+	if !reflect.TypeOf(argument).AssignableTo(parameterType) {
+		return errors.Errorf(
+			"incorrect type of the argument #%d (%T instead %s)",
+			index,
+			argument,
+			parameterType,
+		)
+	}
+
+	return nil
+}
+
+func callGoFunction(
+	ctx context.Context,
+	function interface{},
+	arguments []interface{},
+) (result interface{}, err error) {
+	var argumentValues []reflect.Value
+	if parameterOffset(reflect.TypeOf(function)) != 0 {
+		argumentValues = append(argumentValues, reflect.ValueOf(&ctx).Elem())
+	}
+	for _, argument := range arguments {
+		argumentValues = append(argumentValues, reflect.ValueOf(argument))
+	}
+
+	results := reflect.ValueOf(function).Call(argumentValues)
+	if err := results[errorResultIndex].Interface(); err != nil {
+		return nil, err.(error)
+	}
+
+	return results[dataResultIndex].Interface(), nil
+}
+
+func parameterOffset(functionType reflect.Type) int {
+	if functionType.NumIn() != 0 && functionType.In(0) == contextType {
+		return 1
+	}
+
+	return 0
+}
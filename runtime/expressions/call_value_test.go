@@ -0,0 +1,127 @@
+package expressions
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestCallValue(test *testing.T) {
+	type args struct {
+		ctx       context.Context
+		function  interface{}
+		arguments []interface{}
+	}
+
+	for _, data := range []struct {
+		name       string
+		args       args
+		wantResult interface{}
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the Go function",
+			args: args{
+				ctx:       new(MockContext),
+				function:  func(a float64, b float64) (float64, error) { return a + b, nil },
+				arguments: []interface{}{2.3, 4.2},
+			},
+			wantResult: 6.5,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the Go function and the context argument",
+			args: args{
+				ctx: func() context.Context {
+					context := new(MockContext)
+					context.On("Value", "b").Return(4.2, true)
+
+					return context
+				}(),
+				function: func(context context.Context, a float64) (float64, error) {
+					b, _ := context.Value("b")
+					return a + b.(float64), nil
+				},
+				arguments: []interface{}{2.3},
+			},
+			wantResult: 6.5,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the user function",
+			args: args{
+				ctx: func() context.Context {
+					contextCopy := new(MockContext)
+					contextCopy.On("SetValue", "a", 2.3).Return()
+					contextCopy.On("SetValue", "b", 4.2).Return()
+
+					context := new(MockContext)
+					context.On("Copy").Return(contextCopy)
+
+					return context
+				}(),
+				function: func() runtime.Function {
+					command := new(MockCommand)
+					command.On("Run", mock.AnythingOfType("*expressions.MockContext")).Return(6.5, nil)
+
+					return runtime.NewFunction([]string{"a", "b"}, runtime.CommandGroup{command})
+				}(),
+				arguments: []interface{}{2.3, 4.2},
+			},
+			wantResult: 6.5,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with an incorrect function type",
+			args: args{
+				ctx:       new(MockContext),
+				function:  23.0,
+				arguments: []interface{}{2.3, 4.2},
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with incorrect argument count",
+			args: args{
+				ctx:       new(MockContext),
+				function:  func(a float64, b float64) (float64, error) { return a + b, nil },
+				arguments: []interface{}{2.3},
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an incorrect argument type",
+			args: args{
+				ctx:       new(MockContext),
+				function:  func(a float64, b float64) (float64, error) { return a + b, nil },
+				arguments: []interface{}{2.3, "4.2"},
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with function calling",
+			args: args{
+				ctx:       new(MockContext),
+				function:  func(a float64, b float64) (float64, error) { return 0, iotest.ErrTimeout },
+				arguments: []interface{}{2.3, 4.2},
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotResult, gotErr := CallValue(data.args.ctx, data.args.function, data.args.arguments)
+
+			mock.AssertExpectationsForObjects(test, data.args.ctx)
+			assert.Equal(test, data.wantResult, gotResult)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
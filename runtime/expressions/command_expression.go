@@ -0,0 +1,21 @@
+package expressions
+
+import (
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// CommandExpression ...
+type CommandExpression struct {
+	command runtime.Command
+}
+
+// NewCommandExpression ...
+func NewCommandExpression(command runtime.Command) CommandExpression {
+	return CommandExpression{command}
+}
+
+// Evaluate ...
+func (expression CommandExpression) Evaluate(context context.Context) (result interface{}, err error) {
+	return expression.command.Run(context)
+}
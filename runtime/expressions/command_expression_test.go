@@ -0,0 +1,61 @@
+package expressions
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestCommandExpression(test *testing.T) {
+	type fields struct {
+		command runtime.Command
+	}
+
+	for _, testData := range []struct {
+		name       string
+		fields     fields
+		wantResult interface{}
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				command: func() runtime.Command {
+					command := new(MockCommand)
+					command.On("Run", mock.AnythingOfType("*expressions.MockContext")).Return(2.3, nil)
+
+					return command
+				}(),
+			},
+			wantResult: 2.3,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				command: func() runtime.Command {
+					command := new(MockCommand)
+					command.
+						On("Run", mock.AnythingOfType("*expressions.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return command
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			context := new(MockContext)
+			gotResult, gotErr := NewCommandExpression(testData.fields.command).Evaluate(context)
+
+			mock.AssertExpectationsForObjects(test, testData.fields.command, context)
+			assert.Equal(test, testData.wantResult, gotResult)
+			testData.wantErr(test, gotErr)
+		})
+	}
+}
@@ -1,25 +1,11 @@
 package expressions
 
 import (
-	"reflect"
-
 	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 )
 
-const (
-	expectedResultCount = 2
-)
-
-const (
-	dataResultIndex = iota
-	errorResultIndex
-)
-
-var (
-	errorType = reflect.TypeOf((*error)(nil)).Elem()
-)
-
 // FunctionCall ...
 type FunctionCall struct {
 	name      string
@@ -38,68 +24,64 @@ func (expression FunctionCall) Evaluate(context context.Context) (result interfa
 		return nil, errors.Errorf("unknown function %s", expression.name)
 	}
 
-	functionType := reflect.TypeOf(function)
-	if functionType.Kind() != reflect.Func {
-		return nil, errors.Errorf("%s isn't function, it's %T", expression.name, function)
-	}
-	if functionType.NumIn() != len(expression.arguments) {
-		return nil, errors.Errorf(
-			"incorrect count of %s function arguments (%d instead %d)",
-			expression.name,
-			len(expression.arguments),
-			functionType.NumIn(),
-		)
-	}
-	if functionType.NumOut() != expectedResultCount {
-		return nil, errors.Errorf(
-			"incorrect count of %s function results (%d instead %d)",
-			expression.name,
-			functionType.NumOut(),
-			expectedResultCount,
-		)
+	if typedFunction, ok := function.(runtime.Function); ok {
+		return expression.callFunction(context, typedFunction)
 	}
-	if !functionType.Out(errorResultIndex).Implements(errorType) {
-		return nil, errors.Errorf(
-			"incorrect type of the result #%d of the function %s (%s instead %s)",
-			errorResultIndex,
-			expression.name,
-			functionType.Out(errorResultIndex),
-			errorType,
-		)
+
+	functionType, err := checkFunctionType(function, len(expression.arguments))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to call the function %s", expression.name)
 	}
 
-	var arguments []reflect.Value
+	var arguments []interface{}
 	for index, argument := range expression.arguments {
-		result, err2 := argument.Evaluate(context)
-		if err2 != nil {
+		result, err := argument.Evaluate(context)
+		if err != nil {
 			return nil, errors.Wrapf(
-				err2,
+				err,
 				"unable to evaluate the argument #%d for the function %s",
 				index,
 				expression.name,
 			)
 		}
-		if !reflect.TypeOf(result).AssignableTo(functionType.In(index)) {
-			return nil, errors.Errorf(
-				"incorrect type of the argument #%d for the function %s (%T instead %s)",
+		if err := checkArgumentType(functionType, index, result); err != nil {
+			return nil, errors.Wrapf(err, "unable to call the function %s", expression.name)
+		}
+
+		arguments = append(arguments, result)
+	}
+
+	result, err = callGoFunction(context, function, arguments)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to call the function %s", expression.name)
+	}
+
+	return result, nil
+}
+
+func (expression FunctionCall) callFunction(
+	context context.Context,
+	function runtime.Function,
+) (result interface{}, err error) {
+	var arguments []interface{}
+	for index, argument := range expression.arguments {
+		result, err := argument.Evaluate(context)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"unable to evaluate the argument #%d for the function %s",
 				index,
 				expression.name,
-				result,
-				functionType.In(index),
 			)
 		}
 
-		arguments = append(arguments, reflect.ValueOf(result))
+		arguments = append(arguments, result)
 	}
 
-	results := reflect.ValueOf(function).Call(arguments)
-	if results[errorResultIndex].Interface() != nil {
-		return nil, errors.Wrapf(
-			results[errorResultIndex].Interface().(error),
-			"unable to call the function %s",
-			expression.name,
-		)
+	result, err = function.Call(context, arguments)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to call the function %s", expression.name)
 	}
 
-	return results[dataResultIndex].Interface(), nil
+	return result, nil
 }
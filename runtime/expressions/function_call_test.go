@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 )
 
@@ -112,6 +113,36 @@ func TestFunctionCall_Evaluate(test *testing.T) {
 			wantResult: 6.5,
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "success with the context argument",
+			fields: fields{
+				name: "add",
+				arguments: []Expression{
+					func() Expression {
+						expression := NewSignedExpression("one")
+						expression.On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).Return(2.3, nil)
+
+						return expression
+					}(),
+				},
+			},
+			args: args{
+				context: func() context.Context {
+					add := func(context context.Context, a float64) (float64, error) {
+						b, _ := context.Value("b")
+						return a + b.(float64), nil
+					}
+
+					context := new(MockContext)
+					context.On("Value", "add").Return(add, true)
+					context.On("Value", "b").Return(4.2, true)
+
+					return context
+				}(),
+			},
+			wantResult: 6.5,
+			wantErr:    assert.NoError,
+		},
 		{
 			name: "error with an unknown function",
 			fields: fields{
@@ -291,6 +322,115 @@ func TestFunctionCall_Evaluate(test *testing.T) {
 			wantResult: nil,
 			wantErr:    assert.Error,
 		},
+		{
+			name: "success with the user function",
+			fields: fields{
+				name: "add",
+				arguments: []Expression{
+					func() Expression {
+						expression := NewSignedExpression("one")
+						expression.On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).Return(2.3, nil)
+
+						return expression
+					}(),
+					func() Expression {
+						expression := NewSignedExpression("two")
+						expression.On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).Return(4.2, nil)
+
+						return expression
+					}(),
+				},
+			},
+			args: args{
+				context: func() context.Context {
+					contextCopy := new(MockContext)
+					contextCopy.On("SetValue", "a", 2.3).Return()
+					contextCopy.On("SetValue", "b", 4.2).Return()
+
+					command := new(MockCommand)
+					command.On("Run", contextCopy).Return(6.5, nil)
+
+					add := runtime.NewFunction([]string{"a", "b"}, runtime.CommandGroup{command})
+
+					context := new(MockContext)
+					context.On("Value", "add").Return(add, true)
+					context.On("Copy").Return(contextCopy)
+
+					return context
+				}(),
+			},
+			wantResult: 6.5,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error with argument evaluation for the user function",
+			fields: fields{
+				name: "add",
+				arguments: []Expression{
+					func() Expression {
+						expression := NewSignedExpression("one")
+						expression.
+							On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).
+							Return(nil, iotest.ErrTimeout)
+
+						return expression
+					}(),
+					NewSignedExpression("two"),
+				},
+			},
+			args: args{
+				context: func() context.Context {
+					add := runtime.NewFunction([]string{"a", "b"}, runtime.CommandGroup{new(MockCommand)})
+
+					context := new(MockContext)
+					context.On("Value", "add").Return(add, true)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with user function calling",
+			fields: fields{
+				name: "add",
+				arguments: []Expression{
+					func() Expression {
+						expression := NewSignedExpression("one")
+						expression.On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).Return(2.3, nil)
+
+						return expression
+					}(),
+					func() Expression {
+						expression := NewSignedExpression("two")
+						expression.On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).Return(4.2, nil)
+
+						return expression
+					}(),
+				},
+			},
+			args: args{
+				context: func() context.Context {
+					contextCopy := new(MockContext)
+					contextCopy.On("SetValue", "a", 2.3).Return()
+					contextCopy.On("SetValue", "b", 4.2).Return()
+
+					command := new(MockCommand)
+					command.On("Run", contextCopy).Return(nil, iotest.ErrTimeout)
+
+					add := runtime.NewFunction([]string{"a", "b"}, runtime.CommandGroup{command})
+
+					context := new(MockContext)
+					context.On("Value", "add").Return(add, true)
+					context.On("Copy").Return(contextCopy)
+
+					return context
+				}(),
+			},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			expression := FunctionCall{
@@ -0,0 +1,26 @@
+package expressions
+
+import (
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// Lambda ...
+type Lambda struct {
+	parameters []string
+	commands   runtime.CommandGroup
+}
+
+// NewLambda ...
+func NewLambda(parameters []string, commands runtime.CommandGroup) Lambda {
+	return Lambda{parameters, commands}
+}
+
+// Evaluate ...
+//
+// It captures a copy of the current context as a closure of the resulting function.
+func (expression Lambda) Evaluate(context context.Context) (result interface{}, err error) {
+	function := runtime.NewFunction(expression.parameters, expression.commands).
+		WithClosure(context.Copy())
+	return function, nil
+}
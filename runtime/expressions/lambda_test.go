@@ -0,0 +1,37 @@
+package expressions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestNewLambda(test *testing.T) {
+	commands := runtime.CommandGroup{new(MockCommand), new(MockCommand)}
+	got := NewLambda([]string{"one", "two"}, commands)
+
+	for _, command := range commands {
+		mock.AssertExpectationsForObjects(test, command)
+	}
+	assert.Equal(test, []string{"one", "two"}, got.parameters)
+	assert.Equal(test, commands, got.commands)
+}
+
+func TestLambda_Evaluate(test *testing.T) {
+	commands := runtime.CommandGroup{new(MockCommand), new(MockCommand)}
+	contextCopy := new(MockContext)
+	context := new(MockContext)
+	context.On("Copy").Return(contextCopy)
+
+	gotResult, gotErr := NewLambda([]string{"one", "two"}, commands).Evaluate(context)
+
+	for _, command := range commands {
+		mock.AssertExpectationsForObjects(test, command)
+	}
+	mock.AssertExpectationsForObjects(test, context, contextCopy)
+	wantResult := runtime.NewFunction([]string{"one", "two"}, commands).WithClosure(contextCopy)
+	assert.Equal(test, wantResult, gotResult)
+	assert.NoError(test, gotErr)
+}
@@ -0,0 +1,34 @@
+package expressions
+
+import (
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// PositionedExpression ...
+//
+// It binds the expression to its position in the source code for error reporting.
+type PositionedExpression struct {
+	expression Expression
+	position   runtime.Position
+}
+
+// NewPositionedExpression ...
+func NewPositionedExpression(
+	expression Expression,
+	position runtime.Position,
+) PositionedExpression {
+	return PositionedExpression{expression, position}
+}
+
+// Evaluate ...
+func (expression PositionedExpression) Evaluate(
+	context context.Context,
+) (result interface{}, err error) {
+	result, err = expression.expression.Evaluate(context)
+	if err != nil {
+		return nil, runtime.NewPositionedError(err, expression.position)
+	}
+
+	return result, nil
+}
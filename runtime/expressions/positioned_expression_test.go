@@ -0,0 +1,69 @@
+package expressions
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+func TestPositionedExpression(test *testing.T) {
+	type fields struct {
+		expression Expression
+	}
+
+	for _, testData := range []struct {
+		name         string
+		fields       fields
+		wantResult   interface{}
+		wantPosition runtime.Position
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				expression: func() Expression {
+					expression := new(MockExpression)
+					expression.On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).Return(2.3, nil)
+
+					return expression
+				}(),
+			},
+			wantResult:   2.3,
+			wantPosition: runtime.Position{},
+			wantErr:      assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				expression: func() Expression {
+					expression := new(MockExpression)
+					expression.
+						On("Evaluate", mock.AnythingOfType("*expressions.MockContext")).
+						Return(nil, iotest.ErrTimeout)
+
+					return expression
+				}(),
+			},
+			wantResult:   nil,
+			wantPosition: runtime.Position{Filename: "test.tt", Line: 12, Column: 23},
+			wantErr:      assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			position := runtime.Position{Filename: "test.tt", Line: 12, Column: 23}
+			context := new(MockContext)
+			gotResult, gotErr :=
+				NewPositionedExpression(testData.fields.expression, position).Evaluate(context)
+
+			gotPosition, _ := runtime.FindPosition(gotErr)
+
+			mock.AssertExpectationsForObjects(test, testData.fields.expression, context)
+			assert.Equal(test, testData.wantResult, gotResult)
+			assert.Equal(test, testData.wantPosition, gotPosition)
+			testData.wantErr(test, gotErr)
+		})
+	}
+}
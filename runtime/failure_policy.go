@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+)
+
+// FailurePolicy ...
+//
+// It determines how an actor reacts to an error on processing of a message.
+//
+// With the supervise policy, the actor is stopped and its parent (i.e. the actor
+// that started it) is notified by the __child_failed__(ref, error) message.
+// The parent can restart the child, leave it stopped or escalate the error.
+// Actors without the parent crash the process.
+type FailurePolicy int
+
+// ...
+const (
+	CrashProcessPolicy FailurePolicy = iota
+	StopActorPolicy
+	RestartActorPolicy
+	IgnoreAndLogPolicy
+	SupervisePolicy
+)
+
+// nolint: gochecknoglobals
+var (
+	failurePolicyNames = []string{
+		"crash-process",
+		"stop-actor",
+		"restart-actor",
+		"ignore-and-log",
+		"supervise",
+	}
+)
+
+// FailurePolicyNames ...
+func FailurePolicyNames() []string {
+	return append([]string(nil), failurePolicyNames...)
+}
+
+// ParseFailurePolicy ...
+func ParseFailurePolicy(name string) (FailurePolicy, error) {
+	for policy, policyName := range failurePolicyNames {
+		if policyName == name {
+			return FailurePolicy(policy), nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown failure policy %s", name)
+}
+
+// String ...
+func (policy FailurePolicy) String() string {
+	if policy < 0 || int(policy) >= len(failurePolicyNames) {
+		return "unknown"
+	}
+
+	return failurePolicyNames[policy]
+}
+
+// FailurePolicyGroup ...
+//
+// The policies by classes override the default one.
+type FailurePolicyGroup struct {
+	Default FailurePolicy
+	ByClass map[string]FailurePolicy
+}
+
+// Select ...
+func (group FailurePolicyGroup) Select(className string) FailurePolicy {
+	if policy, ok := group.ByClass[className]; ok {
+		return policy
+	}
+
+	return group.Default
+}
@@ -0,0 +1,103 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailurePolicyNames(test *testing.T) {
+	got := FailurePolicyNames()
+	got[0] = "modified"
+
+	want := []string{"crash-process", "stop-actor", "restart-actor", "ignore-and-log", "supervise"}
+	assert.Equal(test, want, FailurePolicyNames())
+}
+
+func TestParseFailurePolicy(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		args    string
+		want    FailurePolicy
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with the crash-process policy",
+			args:    "crash-process",
+			want:    CrashProcessPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the stop-actor policy",
+			args:    "stop-actor",
+			want:    StopActorPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the restart-actor policy",
+			args:    "restart-actor",
+			want:    RestartActorPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the ignore-and-log policy",
+			args:    "ignore-and-log",
+			want:    IgnoreAndLogPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the supervise policy",
+			args:    "supervise",
+			want:    SupervisePolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    "unknown",
+			want:    0,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := ParseFailurePolicy(testData.args)
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestFailurePolicy_String(test *testing.T) {
+	for _, testData := range []struct {
+		name   string
+		policy FailurePolicy
+		want   string
+	}{
+		{
+			name:   "known policy",
+			policy: RestartActorPolicy,
+			want:   "restart-actor",
+		},
+		{
+			name:   "unknown policy",
+			policy: FailurePolicy(23),
+			want:   "unknown",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.policy.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestFailurePolicyGroup_Select(test *testing.T) {
+	group := FailurePolicyGroup{
+		Default: StopActorPolicy,
+		ByClass: map[string]FailurePolicy{"Test": RestartActorPolicy},
+	}
+
+	assert.Equal(test, RestartActorPolicy, group.Select("Test"))
+	assert.Equal(test, StopActorPolicy, group.Select("Unknown"))
+}
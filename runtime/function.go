@@ -0,0 +1,76 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+// Function ...
+type Function struct {
+	commands ParameterizedCommandGroup
+	closure  context.Context
+	values   context.ValueGroup
+}
+
+// NewFunction ...
+func NewFunction(parameters []string, commands CommandGroup) Function {
+	return Function{commands: NewParameterizedCommandGroup(parameters, commands)}
+}
+
+// WithClosure ...
+//
+// The closure context is used as the source of values at the function call.
+func (function Function) WithClosure(closure context.Context) Function {
+	function.closure = closure
+	return function
+}
+
+// WithValues ...
+//
+// The values are set to the context at the function call. It's used to bind the function
+// to definitions of its module.
+func (function Function) WithValues(values context.ValueGroup) Function {
+	function.values = values
+	return function
+}
+
+// String ...
+func (function Function) String() string {
+	return "<function>"
+}
+
+// MarshalText ...
+func (function Function) MarshalText() (text []byte, err error) {
+	return []byte(function.String()), nil
+}
+
+// Call ...
+func (function Function) Call(
+	ctx context.Context,
+	arguments []interface{},
+) (result interface{}, err error) {
+	var callContext context.Context
+	if function.closure != nil {
+		callContext = function.closure.Copy()
+		callContext.SetMessageSender(ctx)
+		callContext.SetStateHolder(ctx)
+		callContext.SetActorRegister(ctx)
+		callContext.SetTopicRegister(ctx)
+		callContext.SetNameRegister(ctx)
+	} else {
+		callContext = ctx.Copy()
+	}
+	context.SetValues(callContext, function.values)
+
+	result, err = function.commands.ParameterizedRun(callContext, arguments)
+	if err != nil {
+		if errors.Cause(err) == ErrReturn {
+			return types.Nil{}, nil
+		}
+
+		return nil, errors.Wrap(err, "unable to call the function")
+	}
+
+	return result, nil
+}
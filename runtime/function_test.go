@@ -0,0 +1,188 @@
+package runtime
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestNewFunction(test *testing.T) {
+	commands := CommandGroup{new(MockCommand), new(MockCommand)}
+	got := NewFunction([]string{"one", "two"}, commands)
+
+	for _, command := range commands {
+		mock.AssertExpectationsForObjects(test, command)
+	}
+	assert.Equal(test, NewParameterizedCommandGroup([]string{"one", "two"}, commands), got.commands)
+	assert.Nil(test, got.closure)
+}
+
+func TestFunction_WithClosure(test *testing.T) {
+	closure := new(MockContext)
+	got := NewFunction(nil, nil).WithClosure(closure)
+
+	mock.AssertExpectationsForObjects(test, closure)
+	assert.Equal(test, closure, got.closure)
+}
+
+func TestFunction_WithValues(test *testing.T) {
+	values := context.ValueGroup{"one": 23, "two": 42}
+	got := NewFunction(nil, nil).WithValues(values)
+
+	assert.Equal(test, values, got.values)
+}
+
+func TestFunction_String(test *testing.T) {
+	got := NewFunction(nil, nil).String()
+
+	assert.Equal(test, "<function>", got)
+}
+
+func TestFunction_MarshalText(test *testing.T) {
+	gotBytes, gotErr := json.Marshal(NewFunction(nil, nil))
+
+	assert.Equal(test, []byte(`"\u003cfunction\u003e"`), gotBytes)
+	assert.NoError(test, gotErr)
+}
+
+func TestFunction_Call(test *testing.T) {
+	type fields struct {
+		parameters   []string
+		makeCommands func(context context.Context, log *commandLog) CommandGroup
+		withClosure  bool
+		values       context.ValueGroup
+	}
+	type args struct {
+		arguments []interface{}
+	}
+
+	for _, testData := range []struct {
+		name       string
+		fields     fields
+		args       args
+		wantLog    []int
+		wantResult interface{}
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without the closure",
+			fields: fields{
+				parameters: []string{"one", "two"},
+				makeCommands: func(context context.Context, log *commandLog) CommandGroup {
+					return newLoggableCommands(context, log, group(5), withCalls())
+				},
+				withClosure: false,
+			},
+			args:       args{arguments: []interface{}{23, 42}},
+			wantLog:    []int{0, 1, 2, 3, 4},
+			wantResult: 4,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the closure",
+			fields: fields{
+				parameters: []string{"one", "two"},
+				makeCommands: func(context context.Context, log *commandLog) CommandGroup {
+					return newLoggableCommands(context, log, group(5), withCalls())
+				},
+				withClosure: true,
+			},
+			args:       args{arguments: []interface{}{23, 42}},
+			wantLog:    []int{0, 1, 2, 3, 4},
+			wantResult: 4,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the values",
+			fields: fields{
+				parameters: []string{"one", "two"},
+				makeCommands: func(context context.Context, log *commandLog) CommandGroup {
+					return newLoggableCommands(context, log, group(5), withCalls())
+				},
+				withClosure: false,
+				values:      context.ValueGroup{"three": 12},
+			},
+			args:       args{arguments: []interface{}{23, 42}},
+			wantLog:    []int{0, 1, 2, 3, 4},
+			wantResult: 4,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success with the return",
+			fields: fields{
+				parameters: []string{"one", "two"},
+				makeCommands: func(context context.Context, log *commandLog) CommandGroup {
+					return newLoggableCommands(context, log, group(5), withCustomErrOn(ErrReturn, 2))
+				},
+				withClosure: false,
+			},
+			args:       args{arguments: []interface{}{23, 42}},
+			wantLog:    []int{0, 1, 2},
+			wantResult: types.Nil{},
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				parameters: []string{"one", "two"},
+				makeCommands: func(context context.Context, log *commandLog) CommandGroup {
+					return newLoggableCommands(context, log, group(5), withErrOn(2))
+				},
+				withClosure: false,
+			},
+			args:       args{arguments: []interface{}{23, 42}},
+			wantLog:    []int{0, 1, 2},
+			wantResult: nil,
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			contextCopy := new(MockContext)
+			contextCopy.On("SetValue", "one", 23).Return()
+			contextCopy.On("SetValue", "two", 42).Return()
+			for name, value := range testData.fields.values {
+				contextCopy.On("SetValue", name, value).Return()
+			}
+
+			context := new(MockContext)
+			var closure *MockContext
+			if testData.fields.withClosure {
+				contextCopy.On("SetMessageSender", context).Return()
+				contextCopy.On("SetStateHolder", context).Return()
+				contextCopy.On("SetActorRegister", context).Return()
+				contextCopy.On("SetTopicRegister", context).Return()
+				contextCopy.On("SetNameRegister", context).Return()
+
+				closure = new(MockContext)
+				closure.On("Copy").Return(contextCopy)
+			} else {
+				context.On("Copy").Return(contextCopy)
+			}
+
+			var log commandLog
+			commands := testData.fields.makeCommands(contextCopy, &log)
+			function := NewFunction(testData.fields.parameters, commands)
+			if closure != nil {
+				function = function.WithClosure(closure)
+			}
+			if testData.fields.values != nil {
+				function = function.WithValues(testData.fields.values)
+			}
+
+			gotResult, gotErr := function.Call(context, testData.args.arguments)
+
+			mock.AssertExpectationsForObjects(test, context, contextCopy)
+			if closure != nil {
+				mock.AssertExpectationsForObjects(test, closure)
+			}
+			checkCommands(test, commands)
+			assert.Equal(test, testData.wantLog, log.commands)
+			assert.Equal(test, testData.wantResult, gotResult)
+			testData.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package runtime
+
+import (
+	stderrors "errors"
+	"sync"
+
+	syncutils "github.com/thewizardplusplus/go-sync-utils"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// nolint: gochecknoglobals
+var (
+	errInboxClosed = stderrors.New("the inbox is closed")
+	errInboxFull   = stderrors.New("the inbox is full")
+)
+
+// it's a multi-level queue of messages; the messages are received from the level
+// with the highest priority first and in the FIFO order within a level;
+// each pending message is considered as a pending job of the wait group,
+// and the job is finished by the inbox only if the message is dropped
+type inbox struct {
+	capacity  int
+	waitGroup syncutils.WaitGroup
+
+	locker   sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	levels   []*inboxLevel
+	size     int
+	closed   bool
+	stats    InboxStats
+
+	// the listener is called on each accepted message; the inbox with
+	// the listener never blocks its senders, because they may run
+	// on the same thread as the receiver
+	listener func()
+}
+
+// the levels are ordered by their priorities in descending order
+type inboxLevel struct {
+	priority int
+	items    []inboxItem
+}
+
+// protected messages are never dropped by the drop-oldest policy
+type inboxItem struct {
+	message   context.Message
+	protected bool
+}
+
+func newInbox(capacity int, waitGroup syncutils.WaitGroup) *inbox {
+	inbox := &inbox{capacity: capacity, waitGroup: waitGroup}
+	inbox.notEmpty = sync.NewCond(&inbox.locker)
+	inbox.notFull = sync.NewCond(&inbox.locker)
+
+	return inbox
+}
+
+// it returns the errInboxFull error if the sent message is dropped
+func (inbox *inbox) send(message context.Message, policy InboxPolicy, protected bool) error {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	if inbox.closed {
+		return errInboxClosed
+	}
+
+	// waiter increment should call synchronously
+	// otherwise the program may end before all messages are processed
+	inbox.waitGroup.Add(1)
+
+	if err := inbox.makeRoom(message, policy); err != nil {
+		inbox.waitGroup.Done()
+		return err
+	}
+
+	inbox.push(inboxItem{message: message, protected: protected})
+	inbox.stats.Accepted++
+
+	return nil
+}
+
+// it blocks until a message is available; it returns false if the inbox is closed
+func (inbox *inbox) receive() (context.Message, bool) {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	for inbox.size == 0 && !inbox.closed {
+		inbox.notEmpty.Wait()
+	}
+	if inbox.closed {
+		return context.Message{}, false
+	}
+
+	return inbox.pop(), true
+}
+
+// it doesn't block; it returns false if the inbox is empty or closed
+func (inbox *inbox) tryReceive() (context.Message, bool) {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	if inbox.size == 0 || inbox.closed {
+		return context.Message{}, false
+	}
+
+	return inbox.pop(), true
+}
+
+// it doesn't block; it returns the first message accepted by the filter
+// in order of receiving; it returns false if there is no such message
+// or the inbox is closed
+func (inbox *inbox) tryReceiveMatching(
+	filter func(message context.Message) bool,
+) (context.Message, bool) {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	if inbox.closed {
+		return context.Message{}, false
+	}
+
+	for levelIndex, level := range inbox.levels {
+		for itemIndex, item := range level.items {
+			if !filter(item.message) {
+				continue
+			}
+
+			level.items = append(level.items[:itemIndex], level.items[itemIndex+1:]...)
+			if len(level.items) == 0 {
+				inbox.levels = append(inbox.levels[:levelIndex], inbox.levels[levelIndex+1:]...)
+			}
+
+			inbox.size--
+			inbox.notFull.Signal()
+
+			return item.message, true
+		}
+	}
+
+	return context.Message{}, false
+}
+
+func (inbox *inbox) setListener(listener func()) {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	inbox.listener = listener
+}
+
+func (inbox *inbox) pop() context.Message {
+	level := inbox.levels[0]
+	item := level.items[0]
+	level.items = level.items[1:]
+	if len(level.items) == 0 {
+		inbox.levels = inbox.levels[1:]
+	}
+
+	inbox.size--
+	inbox.notFull.Signal()
+
+	return item.message
+}
+
+// it drops all pending messages; blocked senders are released
+// with the errInboxClosed error
+func (inbox *inbox) close() {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	for ; inbox.size > 0; inbox.size-- {
+		inbox.waitGroup.Done()
+	}
+
+	inbox.levels = nil
+	inbox.closed = true
+
+	inbox.notEmpty.Broadcast()
+	inbox.notFull.Broadcast()
+}
+
+func (inbox *inbox) inboxStats() InboxStats {
+	inbox.locker.Lock()
+	defer inbox.locker.Unlock()
+
+	stats := inbox.stats
+	stats.Size = inbox.size
+	stats.Capacity = inbox.capacity
+
+	return stats
+}
+
+func (inbox *inbox) makeRoom(message context.Message, policy InboxPolicy) error {
+	if policy == UnboundedInboxPolicy || !inbox.isFull() {
+		return nil
+	}
+
+	switch policy {
+	case BlockInboxPolicy:
+		if inbox.listener != nil {
+			return nil
+		}
+
+		for inbox.isFull() && !inbox.closed {
+			inbox.notFull.Wait()
+		}
+		if inbox.closed {
+			return errInboxClosed
+		}
+
+		inbox.stats.Blocked++
+	case DropOldestInboxPolicy:
+		for inbox.isFull() {
+			if !inbox.dropOldest(message.Priority) {
+				inbox.stats.Dropped++
+				return errInboxFull
+			}
+		}
+	case FailInboxPolicy:
+		inbox.stats.Rejected++
+		return errInboxFull
+	default:
+		inbox.stats.Dropped++
+		return errInboxFull
+	}
+
+	return nil
+}
+
+// the inbox can always hold at least one message
+func (inbox *inbox) isFull() bool {
+	return inbox.size != 0 && inbox.size >= inbox.capacity
+}
+
+func (inbox *inbox) push(item inboxItem) {
+	priority := item.message.Priority
+
+	index := 0
+	for index < len(inbox.levels) && inbox.levels[index].priority > priority {
+		index++
+	}
+	if index == len(inbox.levels) || inbox.levels[index].priority != priority {
+		inbox.levels = append(inbox.levels, nil)
+		copy(inbox.levels[index+1:], inbox.levels[index:])
+		inbox.levels[index] = &inboxLevel{priority: priority}
+	}
+
+	level := inbox.levels[index]
+	level.items = append(level.items, item)
+
+	inbox.size++
+	inbox.notEmpty.Signal()
+
+	if inbox.listener != nil {
+		inbox.listener()
+	}
+}
+
+// it drops the oldest unprotected message from the level with the lowest priority,
+// but messages with priorities higher than the specified one are kept;
+// it returns false if there is no such message
+func (inbox *inbox) dropOldest(priority int) bool {
+	for levelIndex := len(inbox.levels) - 1; levelIndex >= 0; levelIndex-- {
+		level := inbox.levels[levelIndex]
+		if level.priority > priority {
+			break
+		}
+
+		for itemIndex, item := range level.items {
+			if item.protected {
+				continue
+			}
+
+			level.items = append(level.items[:itemIndex], level.items[itemIndex+1:]...)
+			if len(level.items) == 0 {
+				inbox.levels = append(inbox.levels[:levelIndex], inbox.levels[levelIndex+1:]...)
+			}
+
+			inbox.size--
+			inbox.stats.Dropped++
+			inbox.waitGroup.Done()
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,115 @@
+package runtime
+
+import (
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+type failableMessageSender interface {
+	TrySendMessage(message context.Message) error
+}
+
+// InboxPolicy ...
+//
+// It determines how an actor reacts to a message sent to its full inbox.
+//
+// With the unbounded policy, the message is put to the inbox anyway, so the inbox
+// size doesn't limit it. With the block policy, the sender waits for the room.
+// With the drop policies, the new or the oldest pending message is dropped;
+// the drop-oldest policy drops messages from the lowest priority level, and it
+// never drops messages with priorities higher than the new one. With the fail
+// policy, the new message is dropped and the sending is considered as failed.
+//
+// System messages (i.e. ones with names like __name__) and messages that the actor
+// sends to itself are always kept as with the unbounded policy, so they are never
+// dropped and never block the actor.
+type InboxPolicy int
+
+// ...
+const (
+	UnboundedInboxPolicy InboxPolicy = iota
+	BlockInboxPolicy
+	DropNewestInboxPolicy
+	DropOldestInboxPolicy
+	FailInboxPolicy
+)
+
+// nolint: gochecknoglobals
+var (
+	inboxPolicyNames = []string{"unbounded", "block", "drop-newest", "drop-oldest", "fail"}
+)
+
+// InboxPolicyNames ...
+func InboxPolicyNames() []string {
+	return append([]string(nil), inboxPolicyNames...)
+}
+
+// ParseInboxPolicy ...
+func ParseInboxPolicy(name string) (InboxPolicy, error) {
+	for policy, policyName := range inboxPolicyNames {
+		if policyName == name {
+			return InboxPolicy(policy), nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown inbox policy %s", name)
+}
+
+// String ...
+func (policy InboxPolicy) String() string {
+	if policy < 0 || int(policy) >= len(inboxPolicyNames) {
+		return "unknown"
+	}
+
+	return inboxPolicyNames[policy]
+}
+
+// InboxPolicyGroup ...
+//
+// The policies by classes override the default one.
+type InboxPolicyGroup struct {
+	Default InboxPolicy
+	ByClass map[string]InboxPolicy
+}
+
+// Select ...
+func (group InboxPolicyGroup) Select(className string) InboxPolicy {
+	if policy, ok := group.ByClass[className]; ok {
+		return policy
+	}
+
+	return group.Default
+}
+
+// InboxStats ...
+//
+// The Accepted counter includes all messages put to the inbox. The Blocked counter
+// includes messages that were accepted after waiting for room. The Dropped counter
+// includes messages dropped by the drop policies, and the Rejected counter includes
+// ones dropped by the fail policy.
+type InboxStats struct {
+	Size     int
+	Capacity int
+	Accepted int
+	Blocked  int
+	Dropped  int
+	Rejected int
+}
+
+// TrySendMessage ...
+//
+// It sends the message by the TrySendMessage method of the receiver, if the receiver
+// supports it. Otherwise, the message is sent by the SendMessage method and the sending
+// never fails.
+func TrySendMessage(receiver context.MessageSender, message context.Message) error {
+	if failableReceiver, ok := receiver.(failableMessageSender); ok {
+		return failableReceiver.TrySendMessage(message)
+	}
+
+	receiver.SendMessage(message)
+	return nil
+}
+
+func newInboxOverflowError(actor *ConcurrentActor, message context.Message) error {
+	return errors.Errorf("the inbox of the actor %s is full for the message %s", actor, message.Name)
+}
@@ -0,0 +1,142 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestInboxPolicyNames(test *testing.T) {
+	got := InboxPolicyNames()
+	got[0] = "modified"
+
+	want := []string{"unbounded", "block", "drop-newest", "drop-oldest", "fail"}
+	assert.Equal(test, want, InboxPolicyNames())
+}
+
+func TestParseInboxPolicy(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		args    string
+		want    InboxPolicy
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with the unbounded policy",
+			args:    "unbounded",
+			want:    UnboundedInboxPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the block policy",
+			args:    "block",
+			want:    BlockInboxPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the drop-newest policy",
+			args:    "drop-newest",
+			want:    DropNewestInboxPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the drop-oldest policy",
+			args:    "drop-oldest",
+			want:    DropOldestInboxPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the fail policy",
+			args:    "fail",
+			want:    FailInboxPolicy,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    "unknown",
+			want:    0,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := ParseInboxPolicy(testData.args)
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestInboxPolicy_String(test *testing.T) {
+	for _, testData := range []struct {
+		name   string
+		policy InboxPolicy
+		want   string
+	}{
+		{
+			name:   "known policy",
+			policy: DropOldestInboxPolicy,
+			want:   "drop-oldest",
+		},
+		{
+			name:   "unknown policy",
+			policy: InboxPolicy(23),
+			want:   "unknown",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.policy.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestInboxPolicyGroup_Select(test *testing.T) {
+	group := InboxPolicyGroup{
+		Default: BlockInboxPolicy,
+		ByClass: map[string]InboxPolicy{"Test": FailInboxPolicy},
+	}
+
+	assert.Equal(test, FailInboxPolicy, group.Select("Test"))
+	assert.Equal(test, BlockInboxPolicy, group.Select("Unknown"))
+}
+
+func TestTrySendMessage(test *testing.T) {
+	message := context.Message{Name: "test"}
+	receiver := new(MockContext)
+	receiver.On("SendMessage", message).Return().Once()
+
+	err := TrySendMessage(receiver, message)
+
+	mock.AssertExpectationsForObjects(test, receiver)
+	assert.NoError(test, err)
+}
+
+func TestTrySendMessage_withFailableReceiver(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(2)
+	waitGroup.On("Done").Once()
+
+	errorHandler := new(MockErrorHandler)
+
+	receiver := &ConcurrentActor{
+		className:    "Test",
+		inbox:        newInbox(1, waitGroup),
+		inboxPolicy:  FailInboxPolicy,
+		dependencies: Dependencies{WaitGroup: waitGroup, ErrorHandler: errorHandler},
+	}
+	firstErr := TrySendMessage(receiver, context.Message{Name: "one"})
+	secondErr := TrySendMessage(receiver, context.Message{Name: "two"})
+
+	mock.AssertExpectationsForObjects(test, waitGroup, errorHandler)
+	assert.NoError(test, firstErr)
+	assert.Error(test, secondErr)
+	assert.Equal(
+		test,
+		InboxStats{Size: 1, Capacity: 1, Accepted: 1, Rejected: 1},
+		receiver.InboxStats(),
+	)
+}
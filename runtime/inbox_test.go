@@ -0,0 +1,258 @@
+package runtime
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestInbox_withPriorities(test *testing.T) {
+	messages := []context.Message{
+		{Name: "one", Priority: 0},
+		{Name: "two", Priority: 1},
+		{Name: "three", Priority: 0},
+		{Name: "four", Priority: 2},
+		{Name: "five", Priority: -1},
+		{Name: "six", Priority: 1},
+	}
+
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(len(messages))
+
+	inbox := newInbox(len(messages), waitGroup)
+	for _, message := range messages {
+		err := inbox.send(message, UnboundedInboxPolicy, false)
+		assert.NoError(test, err)
+	}
+
+	var gotNames []string
+	for index := 0; index < len(messages); index++ {
+		message, ok := inbox.receive()
+		assert.True(test, ok)
+
+		gotNames = append(gotNames, message.Name)
+	}
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.Equal(test, []string{"four", "two", "six", "one", "three", "five"}, gotNames)
+	assert.Empty(test, inbox.levels)
+}
+
+func TestInbox_send_withDropOldestPolicy(test *testing.T) {
+	for _, testData := range []struct {
+		name         string
+		messages     []context.Message
+		protected    []bool
+		message      context.Message
+		wantNames    []string
+		wantErr      error
+		wantDropped  int
+		wantAccepted int
+	}{
+		{
+			name: "success with the lowest priority level",
+			messages: []context.Message{
+				{Name: "one", Priority: 1},
+				{Name: "two", Priority: 0},
+				{Name: "three", Priority: 0},
+			},
+			protected:    []bool{false, false, false},
+			message:      context.Message{Name: "four", Priority: 1},
+			wantNames:    []string{"one", "four", "three"},
+			wantErr:      nil,
+			wantDropped:  1,
+			wantAccepted: 4,
+		},
+		{
+			name: "success with protected messages",
+			messages: []context.Message{
+				{Name: "one", Priority: 0},
+				{Name: "two", Priority: 0},
+				{Name: "three", Priority: 0},
+			},
+			protected:    []bool{true, false, false},
+			message:      context.Message{Name: "four", Priority: 0},
+			wantNames:    []string{"one", "three", "four"},
+			wantErr:      nil,
+			wantDropped:  1,
+			wantAccepted: 4,
+		},
+		{
+			name: "error with higher priorities",
+			messages: []context.Message{
+				{Name: "one", Priority: 1},
+				{Name: "two", Priority: 1},
+				{Name: "three", Priority: 1},
+			},
+			protected:    []bool{false, false, false},
+			message:      context.Message{Name: "four", Priority: 0},
+			wantNames:    []string{"one", "two", "three"},
+			wantErr:      errInboxFull,
+			wantDropped:  1,
+			wantAccepted: 3,
+		},
+		{
+			name: "error with protected messages only",
+			messages: []context.Message{
+				{Name: "one", Priority: 0},
+				{Name: "two", Priority: 0},
+				{Name: "three", Priority: 0},
+			},
+			protected:    []bool{true, true, true},
+			message:      context.Message{Name: "four", Priority: 0},
+			wantNames:    []string{"one", "two", "three"},
+			wantErr:      errInboxFull,
+			wantDropped:  1,
+			wantAccepted: 3,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			waitGroup := new(MockWaitGroup)
+			waitGroup.On("Add", 1).Times(len(testData.messages) + 1)
+			waitGroup.On("Done").Times(testData.wantDropped)
+
+			inbox := newInbox(len(testData.messages), waitGroup)
+			for index, message := range testData.messages {
+				inbox.send(message, UnboundedInboxPolicy, testData.protected[index]) // nolint: errcheck, gosec
+			}
+			gotErr := inbox.send(testData.message, DropOldestInboxPolicy, false)
+
+			var gotNames []string
+			for inbox.size != 0 {
+				message, _ := inbox.receive()
+				gotNames = append(gotNames, message.Name)
+			}
+
+			mock.AssertExpectationsForObjects(test, waitGroup)
+			assert.Equal(test, testData.wantNames, gotNames)
+			assert.Equal(test, testData.wantErr, gotErr)
+			assert.Equal(test, testData.wantDropped, inbox.stats.Dropped)
+			assert.Equal(test, testData.wantAccepted, inbox.stats.Accepted)
+		})
+	}
+}
+
+func TestInbox_close(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(3)
+	waitGroup.On("Done").Times(3)
+
+	inbox := newInbox(2, waitGroup)
+	inbox.send(context.Message{Name: "one"}, BlockInboxPolicy, false) // nolint: errcheck, gosec
+	inbox.send(context.Message{Name: "two"}, BlockInboxPolicy, false) // nolint: errcheck, gosec
+
+	var sendingWaiter sync.WaitGroup
+	sendingWaiter.Add(1)
+
+	var gotSendingErr error
+	go func() {
+		defer sendingWaiter.Done()
+		gotSendingErr = inbox.send(context.Message{Name: "three"}, BlockInboxPolicy, false)
+	}()
+
+	// wait for blocking of the sending
+	for {
+		inbox.locker.Lock()
+		// the blocked sending has incremented the waiter
+		blocked := len(waitGroup.Calls) == 3
+		inbox.locker.Unlock()
+
+		if blocked {
+			break
+		}
+	}
+
+	inbox.close()
+	sendingWaiter.Wait()
+
+	_, gotReceivingOk := inbox.receive()
+	gotClosedSendingErr := inbox.send(context.Message{Name: "four"}, UnboundedInboxPolicy, false)
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.Equal(test, errInboxClosed, gotSendingErr)
+	assert.False(test, gotReceivingOk)
+	assert.Equal(test, errInboxClosed, gotClosedSendingErr)
+	assert.Empty(test, inbox.levels)
+}
+
+func TestInbox_tryReceive(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(2)
+	waitGroup.On("Done").Times(1)
+
+	inbox := newInbox(1, waitGroup)
+	_, gotEmptyOk := inbox.tryReceive()
+
+	inbox.send(context.Message{Name: "one"}, UnboundedInboxPolicy, false) // nolint: errcheck, gosec
+	gotMessage, gotOk := inbox.tryReceive()
+
+	inbox.send(context.Message{Name: "two"}, UnboundedInboxPolicy, false) // nolint: errcheck, gosec
+	inbox.close()
+	_, gotClosedOk := inbox.tryReceive()
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.False(test, gotEmptyOk)
+	assert.Equal(test, context.Message{Name: "one"}, gotMessage)
+	assert.True(test, gotOk)
+	assert.False(test, gotClosedOk)
+}
+
+func TestInbox_send_withListener(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(3)
+
+	var gotCalls int
+	inbox := newInbox(2, waitGroup)
+	inbox.setListener(func() { gotCalls++ })
+
+	// the block policy doesn't block the sender with the listener
+	for _, name := range []string{"one", "two", "three"} {
+		err := inbox.send(context.Message{Name: name}, BlockInboxPolicy, false)
+		assert.NoError(test, err)
+	}
+
+	var gotNames []string
+	for {
+		message, ok := inbox.tryReceive()
+		if !ok {
+			break
+		}
+
+		gotNames = append(gotNames, message.Name)
+	}
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.Equal(test, 3, gotCalls)
+	assert.Equal(test, []string{"one", "two", "three"}, gotNames)
+}
+
+func TestInbox_tryReceiveMatching(test *testing.T) {
+	waitGroup := new(MockWaitGroup)
+	waitGroup.On("Add", 1).Times(3)
+
+	inbox := newInbox(3, waitGroup)
+	for _, message := range []context.Message{
+		{Name: "one", Priority: 1},
+		{Name: "two", Priority: 0},
+		{Name: "three", Priority: 0},
+	} {
+		inbox.send(message, UnboundedInboxPolicy, false) // nolint: errcheck, gosec
+	}
+
+	isNotOne := func(message context.Message) bool { return message.Name != "one" }
+	gotMessageOne, gotOkOne := inbox.tryReceiveMatching(isNotOne)
+	gotMessageTwo, gotOkTwo := inbox.tryReceiveMatching(isNotOne)
+	_, gotOkThree := inbox.tryReceiveMatching(isNotOne)
+
+	mock.AssertExpectationsForObjects(test, waitGroup)
+	assert.Equal(test, context.Message{Name: "two"}, gotMessageOne)
+	assert.True(test, gotOkOne)
+	assert.Equal(test, context.Message{Name: "three"}, gotMessageTwo)
+	assert.True(test, gotOkTwo)
+	assert.False(test, gotOkThree)
+	assert.Equal(test, 1, inbox.size)
+	assert.Len(test, inbox.levels, 1)
+}
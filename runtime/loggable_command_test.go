@@ -229,6 +229,8 @@ func checkMessages(test *testing.T, messages MessageGroup) {
 
 func checkStates(test *testing.T, states StateGroup) {
 	for _, parameterizedMessages := range states {
+		checkCommands(test, parameterizedMessages.enterHook)
+		checkCommands(test, parameterizedMessages.exitHook)
 		checkMessages(test, parameterizedMessages.messages)
 	}
 }
@@ -5,9 +5,21 @@ import (
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 )
 
+// WildcardMessageName ...
+//
+// The wildcard handler receives the name and the arguments of a message
+// that isn't handled by other handlers.
+const WildcardMessageName = "_"
+
 // MessageGroup ...
 type MessageGroup map[string]ParameterizedCommandGroup
 
+// Contains ...
+func (messages MessageGroup) Contains(name string) bool {
+	_, ok := messages[name]
+	return ok
+}
+
 // ProcessMessage ...
 func (messages MessageGroup) ProcessMessage(
 	context context.Context,
@@ -22,9 +34,14 @@ func (messages MessageGroup) ProcessMessage(
 }
 
 // ParameterizedMessageGroup ...
+//
+// It may contain the enter and exit hooks of the state, that are run
+// on entering and leaving of the latter.
 type ParameterizedMessageGroup struct {
 	parameters []string
 	messages   MessageGroup
+	enterHook  CommandGroup
+	exitHook   CommandGroup
 }
 
 // NewParameterizedMessageGroup ...
@@ -32,7 +49,23 @@ func NewParameterizedMessageGroup(
 	parameters []string,
 	messages MessageGroup,
 ) ParameterizedMessageGroup {
-	return ParameterizedMessageGroup{parameters, messages}
+	return ParameterizedMessageGroup{parameters: parameters, messages: messages}
+}
+
+// WithHooks ...
+func (parameterizedMessages ParameterizedMessageGroup) WithHooks(
+	enterHook CommandGroup,
+	exitHook CommandGroup,
+) ParameterizedMessageGroup {
+	parameterizedMessages.enterHook = enterHook
+	parameterizedMessages.exitHook = exitHook
+
+	return parameterizedMessages
+}
+
+// Contains ...
+func (parameterizedMessages ParameterizedMessageGroup) Contains(name string) bool {
+	return parameterizedMessages.messages.Contains(name)
 }
 
 // ParameterizedProcessMessage ...
@@ -50,3 +83,53 @@ func (parameterizedMessages ParameterizedMessageGroup) ParameterizedProcessMessa
 
 	return nil
 }
+
+// ParameterizedEnter ...
+func (parameterizedMessages ParameterizedMessageGroup) ParameterizedEnter(
+	ctx context.Context,
+	values context.ValueGroup,
+	arguments []interface{},
+) error {
+	err := parameterizedMessages.runHook(ctx, values, arguments, parameterizedMessages.enterHook)
+	if err != nil {
+		return errors.Wrap(err, "unable to run the enter hook")
+	}
+
+	return nil
+}
+
+// ParameterizedExit ...
+func (parameterizedMessages ParameterizedMessageGroup) ParameterizedExit(
+	ctx context.Context,
+	values context.ValueGroup,
+	arguments []interface{},
+) error {
+	err := parameterizedMessages.runHook(ctx, values, arguments, parameterizedMessages.exitHook)
+	if err != nil {
+		return errors.Wrap(err, "unable to run the exit hook")
+	}
+
+	return nil
+}
+
+// the hook is run in a copy of the context to avoid leaking of its values
+func (parameterizedMessages ParameterizedMessageGroup) runHook(
+	ctx context.Context,
+	values context.ValueGroup,
+	arguments []interface{},
+	hook CommandGroup,
+) error {
+	if len(hook) == 0 {
+		return nil
+	}
+
+	hookContext := ctx.Copy()
+	context.SetValues(hookContext, values)
+	context.SetValues(hookContext, context.ZipValues(parameterizedMessages.parameters, arguments))
+
+	if _, err := hook.Run(hookContext); err != nil && errors.Cause(err) != ErrReturn {
+		return err
+	}
+
+	return nil
+}
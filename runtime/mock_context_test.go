@@ -30,11 +30,53 @@ func (_m *MockContext) Copy() context.Context {
 	return r0
 }
 
+// LookupActor provides a mock function with given fields: name
+func (_m *MockContext) LookupActor(name string) (context.Actor, bool) {
+	ret := _m.Called(name)
+
+	var r0 context.Actor
+	if rf, ok := ret.Get(0).(func(string) context.Actor); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(context.Actor)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// PublishMessage provides a mock function with given fields: topic, message
+func (_m *MockContext) PublishMessage(topic string, message context.Message) {
+	_m.Called(topic, message)
+}
+
 // RegisterActor provides a mock function with given fields: actor, arguments
 func (_m *MockContext) RegisterActor(actor context.Actor, arguments []interface{}) {
 	_m.Called(actor, arguments)
 }
 
+// RegisterActorName provides a mock function with given fields: name, actor
+func (_m *MockContext) RegisterActorName(name string, actor context.Actor) error {
+	ret := _m.Called(name, actor)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, context.Actor) error); ok {
+		r0 = rf(name, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendMessage provides a mock function with given fields: message
 func (_m *MockContext) SendMessage(message context.Message) {
 	_m.Called(message)
@@ -50,13 +92,18 @@ func (_m *MockContext) SetMessageSender(sender context.MessageSender) {
 	_m.Called(sender)
 }
 
-// SetState provides a mock function with given fields: state
-func (_m *MockContext) SetState(state context.State) error {
-	ret := _m.Called(state)
+// SetNameRegister provides a mock function with given fields: register
+func (_m *MockContext) SetNameRegister(register context.NameRegister) {
+	_m.Called(register)
+}
+
+// SetState provides a mock function with given fields: _a0, state
+func (_m *MockContext) SetState(_a0 context.Context, state context.State) error {
+	ret := _m.Called(_a0, state)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.State) error); ok {
-		r0 = rf(state)
+	if rf, ok := ret.Get(0).(func(context.Context, context.State) error); ok {
+		r0 = rf(_a0, state)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -69,6 +116,11 @@ func (_m *MockContext) SetStateHolder(holder context.StateHolder) {
 	_m.Called(holder)
 }
 
+// SetTopicRegister provides a mock function with given fields: register
+func (_m *MockContext) SetTopicRegister(register context.TopicRegister) {
+	_m.Called(register)
+}
+
 // SetValue provides a mock function with given fields: name, value
 func (_m *MockContext) SetValue(name string, value interface{}) {
 	_m.Called(name, value)
@@ -79,6 +131,21 @@ func (_m *MockContext) SetValueStore(store context.CopyableValueStore) {
 	_m.Called(store)
 }
 
+// SubscribeActor provides a mock function with given fields: topic, actor
+func (_m *MockContext) SubscribeActor(topic string, actor context.Actor) {
+	_m.Called(topic, actor)
+}
+
+// UnregisterActor provides a mock function with given fields: actor
+func (_m *MockContext) UnregisterActor(actor context.Actor) {
+	_m.Called(actor)
+}
+
+// UnsubscribeActor provides a mock function with given fields: topic, actor
+func (_m *MockContext) UnsubscribeActor(topic string, actor context.Actor) {
+	_m.Called(topic, actor)
+}
+
 // Value provides a mock function with given fields: name
 func (_m *MockContext) Value(name string) (interface{}, bool) {
 	ret := _m.Called(name)
@@ -27,6 +27,16 @@ type Context interface {
 	context.Context
 }
 
+//go:generate mockery --name=ContextActor --inpackage --case=underscore --testonly
+
+// ContextActor ...
+//
+// It's used only for mock generating.
+//
+type ContextActor interface {
+	context.Actor
+}
+
 //go:generate mockery --name=WaitGroup --inpackage --case=underscore --testonly
 
 // WaitGroup ...
@@ -0,0 +1,60 @@
+package runtime
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// NameRegistry ...
+//
+// It maps unique names to actors. An actor may have several names.
+//
+// The zero value is ready to use.
+type NameRegistry struct {
+	locker sync.RWMutex
+	actors map[string]context.Actor
+}
+
+// RegisterActorName ...
+//
+// It returns an error if the name is already taken by any actor.
+func (registry *NameRegistry) RegisterActorName(name string, actor context.Actor) error {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	if _, ok := registry.actors[name]; ok {
+		return errors.Errorf("the actor name %s is already taken", name)
+	}
+
+	if registry.actors == nil {
+		registry.actors = make(map[string]context.Actor)
+	}
+
+	registry.actors[name] = actor
+	return nil
+}
+
+// LookupActor ...
+func (registry *NameRegistry) LookupActor(name string) (context.Actor, bool) {
+	registry.locker.RLock()
+	defer registry.locker.RUnlock()
+
+	actor, ok := registry.actors[name]
+	return actor, ok
+}
+
+// UnregisterActorNames ...
+//
+// It releases all names of the actor, e.g. on its stopping.
+func (registry *NameRegistry) UnregisterActorNames(actor context.Actor) {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	for name, namedActor := range registry.actors {
+		if namedActor == actor {
+			delete(registry.actors, name)
+		}
+	}
+}
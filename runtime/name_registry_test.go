@@ -0,0 +1,50 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestNameRegistry_RegisterActorName(test *testing.T) {
+	actorOne := new(MockContextActor)
+	actorTwo := new(MockContextActor)
+
+	var registry NameRegistry
+	errOne := registry.RegisterActorName("one", actorOne)
+	errTwo := registry.RegisterActorName("two", actorOne)
+	errThree := registry.RegisterActorName("one", actorTwo)
+
+	mock.AssertExpectationsForObjects(test, actorOne, actorTwo)
+	assert.NoError(test, errOne)
+	assert.NoError(test, errTwo)
+	assert.Error(test, errThree)
+	assert.Equal(test, map[string]context.Actor{"one": actorOne, "two": actorOne}, registry.actors)
+}
+
+func TestNameRegistry_LookupActor(test *testing.T) {
+	actor := new(MockContextActor)
+	registry := NameRegistry{actors: map[string]context.Actor{"one": actor}}
+	gotActor, gotOk := registry.LookupActor("one")
+	gotUnknownActor, gotUnknownOk := registry.LookupActor("unknown")
+
+	mock.AssertExpectationsForObjects(test, actor)
+	assert.Equal(test, actor, gotActor)
+	assert.True(test, gotOk)
+	assert.Nil(test, gotUnknownActor)
+	assert.False(test, gotUnknownOk)
+}
+
+func TestNameRegistry_UnregisterActorNames(test *testing.T) {
+	actorOne := new(MockContextActor)
+	actorTwo := new(MockContextActor)
+	registry := NameRegistry{
+		actors: map[string]context.Actor{"one": actorOne, "two": actorTwo, "three": actorOne},
+	}
+	registry.UnregisterActorNames(actorOne)
+
+	mock.AssertExpectationsForObjects(test, actorOne, actorTwo)
+	assert.Equal(test, map[string]context.Actor{"two": actorTwo}, registry.actors)
+}
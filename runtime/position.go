@@ -0,0 +1,128 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Position ...
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+// IsValid ...
+func (position Position) IsValid() bool {
+	return position.Line > 0
+}
+
+// String ...
+func (position Position) String() string {
+	text := fmt.Sprintf("%d:%d", position.Line, position.Column)
+	if len(position.Filename) != 0 {
+		text = position.Filename + ":" + text
+	}
+
+	return text
+}
+
+// PositionedError ...
+type PositionedError struct {
+	err      error
+	position Position
+}
+
+// NewPositionedError ...
+func NewPositionedError(err error, position Position) PositionedError {
+	return PositionedError{err, position}
+}
+
+// Error ...
+func (err PositionedError) Error() string {
+	return err.err.Error()
+}
+
+// Cause ...
+func (err PositionedError) Cause() error {
+	return err.err
+}
+
+// Position ...
+func (err PositionedError) Position() Position {
+	return err.position
+}
+
+// FindPosition ...
+//
+// It returns the innermost (i.e. the most precise) position from the error chain.
+func FindPosition(err error) (position Position, ok bool) {
+	for err != nil {
+		if positionedError, isPositioned := err.(PositionedError); isPositioned {
+			position, ok = positionedError.Position(), true
+		}
+
+		cause, hasCause := err.(interface{ Cause() error })
+		if !hasCause {
+			break
+		}
+
+		err = cause.Cause()
+	}
+
+	return position, ok
+}
+
+// SourceGroup ...
+//
+// It stores source codes by their filenames for showing excerpts in error messages.
+type SourceGroup struct {
+	locker  sync.RWMutex
+	sources map[string][]string
+}
+
+// NewSourceGroup ...
+func NewSourceGroup() *SourceGroup {
+	return &SourceGroup{sources: make(map[string][]string)}
+}
+
+// AddSource ...
+func (group *SourceGroup) AddSource(filename string, code string) {
+	group.locker.Lock()
+	defer group.locker.Unlock()
+
+	group.sources[filename] = strings.Split(code, "\n")
+}
+
+// Excerpt ...
+//
+// It returns the source line of the position and a line with a caret pointing to the position
+// column.
+func (group *SourceGroup) Excerpt(position Position) (excerpt string, ok bool) {
+	group.locker.RLock()
+	defer group.locker.RUnlock()
+
+	lines, ok := group.sources[position.Filename]
+	if !ok || position.Line < 1 || position.Line > len(lines) {
+		return "", false
+	}
+
+	line := strings.TrimSuffix(lines[position.Line-1], "\r")
+
+	var caretPrefix strings.Builder
+	for index, symbol := range []rune(line) {
+		if index >= position.Column-1 {
+			break
+		}
+
+		// keep tabulations for correct alignment of the caret
+		if symbol == '\t' {
+			caretPrefix.WriteRune('\t')
+		} else {
+			caretPrefix.WriteRune(' ')
+		}
+	}
+
+	return line + "\n" + caretPrefix.String() + "^", true
+}
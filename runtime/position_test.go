@@ -0,0 +1,166 @@
+package runtime
+
+import (
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_IsValid(test *testing.T) {
+	for _, testData := range []struct {
+		name     string
+		position Position
+		want     bool
+	}{
+		{
+			name:     "valid",
+			position: Position{Filename: "test.tt", Line: 12, Column: 23},
+			want:     true,
+		},
+		{
+			name:     "invalid",
+			position: Position{},
+			want:     false,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.position.IsValid()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestPosition_String(test *testing.T) {
+	for _, testData := range []struct {
+		name     string
+		position Position
+		want     string
+	}{
+		{
+			name:     "with the filename",
+			position: Position{Filename: "test.tt", Line: 12, Column: 23},
+			want:     "test.tt:12:23",
+		},
+		{
+			name:     "without the filename",
+			position: Position{Line: 12, Column: 23},
+			want:     "12:23",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.position.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestPositionedError(test *testing.T) {
+	position := Position{Filename: "test.tt", Line: 12, Column: 23}
+	err := NewPositionedError(iotest.ErrTimeout, position)
+
+	assert.Equal(test, iotest.ErrTimeout.Error(), err.Error())
+	assert.Equal(test, iotest.ErrTimeout, err.Cause())
+	assert.Equal(test, position, err.Position())
+	assert.Equal(test, iotest.ErrTimeout, errors.Cause(errors.Wrap(err, "test")))
+}
+
+func TestFindPosition(test *testing.T) {
+	for _, testData := range []struct {
+		name         string
+		err          error
+		wantPosition Position
+		wantOk       bool
+	}{
+		{
+			name:         "without positions",
+			err:          errors.Wrap(iotest.ErrTimeout, "test"),
+			wantPosition: Position{},
+			wantOk:       false,
+		},
+		{
+			name: "with the single position",
+			err: errors.Wrap(
+				NewPositionedError(
+					errors.Wrap(iotest.ErrTimeout, "test"),
+					Position{Filename: "test.tt", Line: 12, Column: 23},
+				),
+				"test",
+			),
+			wantPosition: Position{Filename: "test.tt", Line: 12, Column: 23},
+			wantOk:       true,
+		},
+		{
+			name: "with few positions",
+			err: NewPositionedError(
+				errors.Wrap(
+					NewPositionedError(iotest.ErrTimeout, Position{Filename: "test.tt", Line: 12, Column: 42}),
+					"test",
+				),
+				Position{Filename: "test.tt", Line: 12, Column: 23},
+			),
+			wantPosition: Position{Filename: "test.tt", Line: 12, Column: 42},
+			wantOk:       true,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			gotPosition, gotOk := FindPosition(testData.err)
+
+			assert.Equal(test, testData.wantPosition, gotPosition)
+			assert.Equal(test, testData.wantOk, gotOk)
+		})
+	}
+}
+
+func TestSourceGroup_Excerpt(test *testing.T) {
+	sources := NewSourceGroup()
+	sources.AddSource("test.tt", "actor Main()\n\tstate test()\r\n\t\tmessage test() 1 / x;\n;")
+
+	for _, testData := range []struct {
+		name        string
+		position    Position
+		wantExcerpt string
+		wantOk      bool
+	}{
+		{
+			name:        "success",
+			position:    Position{Filename: "test.tt", Line: 1, Column: 7},
+			wantExcerpt: "actor Main()\n      ^",
+			wantOk:      true,
+		},
+		{
+			name:        "success with tabulations",
+			position:    Position{Filename: "test.tt", Line: 3, Column: 18},
+			wantExcerpt: "\t\tmessage test() 1 / x;\n\t\t               ^",
+			wantOk:      true,
+		},
+		{
+			name:        "success with a line break",
+			position:    Position{Filename: "test.tt", Line: 2, Column: 2},
+			wantExcerpt: "\tstate test()\n\t^",
+			wantOk:      true,
+		},
+		{
+			name:        "error with an unknown file",
+			position:    Position{Filename: "unknown.tt", Line: 1, Column: 1},
+			wantExcerpt: "",
+			wantOk:      false,
+		},
+		{
+			name:        "error with an incorrect line",
+			position:    Position{Filename: "test.tt", Line: 23, Column: 1},
+			wantExcerpt: "",
+			wantOk:      false,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			gotExcerpt, gotOk := sources.Excerpt(testData.position)
+
+			assert.Equal(test, testData.wantExcerpt, gotExcerpt)
+			assert.Equal(test, testData.wantOk, gotOk)
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package runtime
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+// RecordKind ...
+type RecordKind string
+
+// ...
+const (
+	StartRecordKind   RecordKind = "start"
+	MessageRecordKind RecordKind = "message"
+	RandomRecordKind  RecordKind = "random"
+	TimeRecordKind    RecordKind = "time"
+)
+
+// Record ...
+//
+// It's a single line of a trace. The start record describes a registration
+// of the actor by its parent (zero for the initial actors), the message record
+// describes a dequeuing of the message by the actor, the random and time records
+// describe values produced by the corresponding functions for the actor.
+// The Request flag marks messages sent by the ask function.
+type Record struct {
+	Kind      RecordKind      `json:"kind"`
+	Actor     int             `json:"actor"`
+	Parent    int             `json:"parent,omitempty"`
+	Class     string          `json:"class,omitempty"`
+	State     string          `json:"state,omitempty"`
+	Message   string          `json:"message,omitempty"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Request   bool            `json:"request,omitempty"`
+	Value     float64         `json:"value,omitempty"`
+}
+
+// Recorder ...
+//
+// It's implemented by the trace recorder for recording of a trace
+// and by the replay scheduler for replaying of it.
+type Recorder interface {
+	RecordStart(actor *ConcurrentActor) error
+	RecordMessage(actor *ConcurrentActor, message context.Message) error
+	RecordValue(actor *ConcurrentActor, kind RecordKind, producer func() float64) (float64, error)
+}
+
+// TraceRecorder ...
+//
+// It writes records to the writer in the JSON Lines format. Each record is written
+// by a single call of the writer, so the trace is complete even if the program
+// is terminated abruptly.
+type TraceRecorder struct {
+	locker sync.Mutex
+	writer io.Writer
+}
+
+// NewTraceRecorder ...
+func NewTraceRecorder(writer io.Writer) *TraceRecorder {
+	return &TraceRecorder{writer: writer}
+}
+
+// RecordStart ...
+func (recorder *TraceRecorder) RecordStart(actor *ConcurrentActor) error {
+	record := Record{Kind: StartRecordKind, Actor: actor.id, Class: actor.className}
+	if parent, ok := actor.parent.(*ConcurrentActor); ok {
+		record.Parent = parent.id
+	}
+
+	return recorder.write(record)
+}
+
+// RecordMessage ...
+//
+// If the arguments of the message can't be marshalled, the message is recorded
+// without them, but the error is returned.
+func (recorder *TraceRecorder) RecordMessage(
+	actor *ConcurrentActor,
+	message context.Message,
+) error {
+	arguments, argumentsErr := marshalArguments(message.Arguments)
+	if err := recorder.write(Record{
+		Kind:      MessageRecordKind,
+		Actor:     actor.id,
+		State:     actor.innerActor.currentState.Name,
+		Message:   message.Name,
+		Arguments: arguments,
+		Request:   message.Reply != nil,
+	}); err != nil {
+		return err
+	}
+	if argumentsErr != nil {
+		return errors.Wrapf(
+			argumentsErr,
+			"unable to marshal arguments of the message %s",
+			message.Name,
+		)
+	}
+
+	return nil
+}
+
+// RecordValue ...
+func (recorder *TraceRecorder) RecordValue(
+	actor *ConcurrentActor,
+	kind RecordKind,
+	producer func() float64,
+) (float64, error) {
+	value := producer()
+	return value, recorder.write(Record{Kind: kind, Actor: actor.id, Value: value})
+}
+
+func (recorder *TraceRecorder) write(record Record) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(record); err != nil {
+		return errors.Wrap(err, "unable to marshal the record")
+	}
+
+	recorder.locker.Lock()
+	defer recorder.locker.Unlock()
+
+	if _, err := recorder.writer.Write(buffer.Bytes()); err != nil {
+		return errors.Wrap(err, "unable to write the record")
+	}
+
+	return nil
+}
+
+// LoadTrace ...
+func LoadTrace(reader io.Reader) ([]Record, error) {
+	var records []Record
+	if err := scanLines(reader, func(line []byte, lineNumber int) error {
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return errors.Wrapf(err, "unable to unmarshal the record on line %d", lineNumber)
+		}
+
+		records = append(records, record)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// it skips empty lines
+func scanLines(reader io.Reader, handler func(line []byte, lineNumber int) error) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 1<<30)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		if err := handler(scanner.Bytes(), lineNumber); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "unable to read the trace")
+	}
+
+	return nil
+}
+
+func marshalArguments(arguments []interface{}) (json.RawMessage, error) {
+	return marshalDeepArguments(arguments, types.GetDeepValue)
+}
+
+func marshalDeepArguments(
+	arguments []interface{},
+	getDeepValue func(value interface{}) (interface{}, error),
+) (json.RawMessage, error) {
+	if len(arguments) == 0 {
+		return nil, nil
+	}
+
+	var deepArguments []interface{}
+	for index, argument := range arguments {
+		deepArgument, err := getDeepValue(argument)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to get the deep value of the argument #%d", index)
+		}
+
+		deepArguments = append(deepArguments, deepArgument)
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(deepArguments); err != nil {
+		return nil, errors.Wrap(err, "unable to marshal the arguments to JSON")
+	}
+
+	return bytes.TrimSuffix(buffer.Bytes(), []byte{'\n'}), nil
+}
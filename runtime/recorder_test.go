@@ -0,0 +1,96 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestTraceRecorder(test *testing.T) {
+	parent := &ConcurrentActor{id: 12, className: "Parent"}
+	actor := &ConcurrentActor{
+		id:         23,
+		className:  "Child",
+		innerActor: &Actor{currentState: context.State{Name: "state"}},
+		parent:     parent,
+	}
+
+	var buffer bytes.Buffer
+	recorder := NewTraceRecorder(&buffer)
+	startErr := recorder.RecordStart(actor)
+	messageErr := recorder.RecordMessage(actor, context.Message{
+		Name:      "test",
+		Arguments: []interface{}{4.2, types.NewPairFromText("<hi>"), parent},
+	})
+	requestErr := recorder.RecordMessage(actor, context.Message{
+		Name:  "request",
+		Reply: make(chan interface{}, 1),
+	})
+	value, valueErr := recorder.RecordValue(actor, RandomRecordKind, func() float64 { return 0.5 })
+
+	wantTrace := `{"kind":"start","actor":23,"parent":12,"class":"Child"}
+{"kind":"message","actor":23,"state":"state","message":"test","arguments":[4.2,[60,104,105,62],"<actor Parent#12>"]}
+{"kind":"message","actor":23,"state":"state","message":"request","request":true}
+{"kind":"random","actor":23,"value":0.5}
+`
+	assert.NoError(test, startErr)
+	assert.NoError(test, messageErr)
+	assert.NoError(test, requestErr)
+	assert.Equal(test, 0.5, value)
+	assert.NoError(test, valueErr)
+	assert.Equal(test, wantTrace, buffer.String())
+}
+
+func TestLoadTrace(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		trace   string
+		want    []Record
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			trace: `{"kind":"start","actor":23,"parent":12,"class":"Child"}
+
+{"kind":"message","actor":23,"state":"state","message":"test","arguments":[4.2]}
+`,
+			want: []Record{
+				{Kind: StartRecordKind, Actor: 23, Parent: 12, Class: "Child"},
+				{
+					Kind:      MessageRecordKind,
+					Actor:     23,
+					State:     "state",
+					Message:   "test",
+					Arguments: json.RawMessage("[4.2]"),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			trace:   `{"kind":"start","actor":23}` + "\nincorrect\n",
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := LoadTrace(strings.NewReader(testData.trace))
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestLoadTrace_withReadingError(test *testing.T) {
+	got, err := LoadTrace(iotest.TimeoutReader(strings.NewReader("{}")))
+
+	assert.Nil(test, got)
+	assert.Error(test, err)
+}
@@ -0,0 +1,235 @@
+package runtime
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// ReplayScheduler ...
+//
+// It replays the trace written by the trace recorder, so it should be used both
+// as the scheduler and as the recorder. It runs all actors in a single goroutine
+// and makes them dequeue messages in the recorded order; the recorded message is
+// awaited in the inbox of the recorded actor, and other messages stay there until
+// their turns. It also assigns the recorded IDs to the actors and returns
+// the recorded values from the random and time functions.
+//
+// After the trace is over, the actors are run by the round-robin scheduler
+// with the zero seed.
+//
+// If the program diverges from the trace (i.e. the recorded message can't be
+// received anymore), the error is handled by the error handler.
+type ReplayScheduler struct {
+	*RoundRobinScheduler
+
+	messages     []Record
+	errorHandler ErrorHandler
+
+	// it's used only by the goroutine of the scheduler
+	stoppedActors map[int]struct{}
+
+	locker   sync.Mutex
+	children map[int][]int
+	values   map[replayedValueKey][]float64
+}
+
+type replayedValueKey struct {
+	actor int
+	kind  RecordKind
+}
+
+// NewReplayScheduler ...
+//
+// The trace with requests of the ask function can't be replayed, because
+// the function fails in actors sharing the goroutine.
+func NewReplayScheduler(trace []Record, errorHandler ErrorHandler) (*ReplayScheduler, error) {
+	scheduler := &ReplayScheduler{
+		RoundRobinScheduler: NewRoundRobinScheduler(0),
+		errorHandler:        errorHandler,
+		stoppedActors:       make(map[int]struct{}),
+		children:            make(map[int][]int),
+		values:              make(map[replayedValueKey][]float64),
+	}
+
+	var maximalActorID int
+	for _, record := range trace {
+		switch record.Kind {
+		case StartRecordKind:
+			scheduler.children[record.Parent] = append(scheduler.children[record.Parent], record.Actor)
+			if record.Actor > maximalActorID {
+				maximalActorID = record.Actor
+			}
+		case MessageRecordKind:
+			if record.Request {
+				return nil, errors.Errorf(
+					"unable to replay the request %s to the actor #%d",
+					record.Message,
+					record.Actor,
+				)
+			}
+
+			scheduler.messages = append(scheduler.messages, record)
+		case RandomRecordKind, TimeRecordKind:
+			key := replayedValueKey{actor: record.Actor, kind: record.Kind}
+			scheduler.values[key] = append(scheduler.values[key], record.Value)
+		}
+	}
+
+	// the actors missed in the trace shouldn't get the recorded IDs
+	for {
+		lastID := atomic.LoadInt64(&lastActorID)
+		if lastID >= int64(maximalActorID) ||
+			atomic.CompareAndSwapInt64(&lastActorID, lastID, int64(maximalActorID)) {
+			break
+		}
+	}
+
+	return scheduler, nil
+}
+
+// Start ...
+func (scheduler *ReplayScheduler) Start() {
+	scheduler.starter.Do(func() { go scheduler.run() })
+}
+
+// RecordStart ...
+//
+// It assigns the recorded ID to the actor. The IDs are matched by the parents
+// of the actors and the order of their starts.
+func (scheduler *ReplayScheduler) RecordStart(actor *ConcurrentActor) error {
+	var parentID int
+	if parent, ok := actor.parent.(*ConcurrentActor); ok {
+		parentID = parent.id
+	}
+
+	scheduler.locker.Lock()
+	defer scheduler.locker.Unlock()
+
+	children := scheduler.children[parentID]
+	if len(children) == 0 {
+		return errors.Errorf("the start of the actor %s isn't recorded", actor)
+	}
+
+	actor.id = children[0]
+	scheduler.children[parentID] = children[1:]
+
+	return nil
+}
+
+// RecordMessage ...
+//
+// It does nothing, because the order of messages is controlled by the scheduler.
+func (scheduler *ReplayScheduler) RecordMessage(
+	actor *ConcurrentActor,
+	message context.Message,
+) error {
+	return nil
+}
+
+// RecordValue ...
+//
+// It returns the next recorded value of the kind for the actor. If there are no
+// such values, it returns the produced value and an error.
+func (scheduler *ReplayScheduler) RecordValue(
+	actor *ConcurrentActor,
+	kind RecordKind,
+	producer func() float64,
+) (float64, error) {
+	scheduler.locker.Lock()
+	defer scheduler.locker.Unlock()
+
+	key := replayedValueKey{actor: actor.id, kind: kind}
+	values := scheduler.values[key]
+	if len(values) == 0 {
+		return producer(), errors.Errorf("the %s value isn't recorded for the actor %s", kind, actor)
+	}
+
+	scheduler.values[key] = values[1:]
+	return values[0], nil
+}
+
+func (scheduler *ReplayScheduler) run() {
+	for _, record := range scheduler.messages {
+		if err := scheduler.awaitMessage(record); err != nil {
+			scheduler.errorHandler.HandleError(errors.Wrap(err, "the program diverged from the trace"))
+			return
+		}
+	}
+
+	scheduler.RoundRobinScheduler.run()
+}
+
+// it returns an error if the recorded message can't be received anymore
+func (scheduler *ReplayScheduler) awaitMessage(record Record) error {
+	for !scheduler.replayMessage(record) {
+		if _, ok := scheduler.stoppedActors[record.Actor]; ok {
+			return errors.Errorf(
+				"the actor #%d is stopped before receiving of the message %s",
+				record.Actor,
+				record.Message,
+			)
+		}
+
+		// all actors are idle, because they're run only by this goroutine,
+		// so only timers can send new messages; the timers send their messages
+		// before finishing, so the message should be checked once more
+		if !scheduler.hasPendingTimers() {
+			if scheduler.replayMessage(record) {
+				break
+			}
+
+			return errors.Errorf(
+				"the message %s to the actor #%d isn't sent",
+				record.Message,
+				record.Actor,
+			)
+		}
+
+		<-scheduler.wakeups
+	}
+
+	return nil
+}
+
+func (scheduler *ReplayScheduler) hasPendingTimers() bool {
+	for _, actor := range scheduler.activeActors {
+		if actor.actor.timers.hasTimers() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// it returns false if the recorded message isn't received yet
+func (scheduler *ReplayScheduler) replayMessage(record Record) bool {
+	scheduler.startNewActors()
+
+	for _, actor := range scheduler.activeActors {
+		if actor.actor.id != record.Actor {
+			continue
+		}
+
+		message, ok := actor.actor.inbox.tryReceiveMatching(func(message context.Message) bool {
+			// arguments that can't be marshalled aren't recorded
+			arguments, _ := marshalArguments(message.Arguments) // nolint: errcheck, gosec
+			return message.Name == record.Message && bytes.Equal(arguments, record.Arguments)
+		})
+		if !ok {
+			return false
+		}
+
+		if !actor.actor.handleMessage(actor.context, actor.arguments, message) {
+			scheduler.removeActor(actor)
+			scheduler.stoppedActors[record.Actor] = struct{}{}
+		}
+
+		return true
+	}
+
+	return false
+}
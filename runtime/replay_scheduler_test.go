@@ -0,0 +1,193 @@
+package runtime
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestReplayScheduler(test *testing.T) {
+	trace := []Record{
+		{Kind: StartRecordKind, Actor: 10, Class: "Test"},
+		{Kind: StartRecordKind, Actor: 20, Class: "Test"},
+		{Kind: StartRecordKind, Actor: 30, Class: "Test"},
+		{Kind: MessageRecordKind, Actor: 30, Message: "test", Arguments: json.RawMessage("[2]")},
+		{Kind: MessageRecordKind, Actor: 10, Message: "test", Arguments: json.RawMessage("[1]")},
+		{Kind: MessageRecordKind, Actor: 30, Message: "test", Arguments: json.RawMessage("[1]")},
+		{Kind: MessageRecordKind, Actor: 20, Message: "test", Arguments: json.RawMessage("[2]")},
+		{Kind: MessageRecordKind, Actor: 10, Message: "test", Arguments: json.RawMessage("[2]")},
+		{Kind: MessageRecordKind, Actor: 20, Message: "test", Arguments: json.RawMessage("[1]")},
+	}
+
+	var log commandLog
+	var waiter sync.WaitGroup
+	scheduler, err := NewReplayScheduler(trace, new(MockErrorHandler))
+	require.NoError(test, err)
+
+	group := NewConcurrentActorGroup(context.NewDefaultContext()).WithScheduler(scheduler)
+	var actors []*ConcurrentActor
+	for id := 1; id <= 3; id++ {
+		command := newLoggableCommand(&log, id)
+		command.mock.On("Run", mock.Anything).Return(types.Nil{}, nil)
+
+		commands := NewParameterizedCommandGroup([]string{"number"}, CommandGroup{command})
+		messages := MessageGroup{"test": commands}
+		states := StateGroup{"state": NewParameterizedMessageGroup(nil, messages)}
+		actor := &ConcurrentActor{
+			innerActor: &Actor{
+				states:       NewParameterizedStateGroup(nil, states),
+				currentState: context.State{Name: "state"},
+			},
+			inbox:        newInbox(0, &waiter),
+			dependencies: Dependencies{WaitGroup: &waiter, Recorder: scheduler},
+		}
+		group.RegisterActor(actor, nil)
+
+		actors = append(actors, actor)
+	}
+
+	group.SendMessage(context.Message{Name: "test", Arguments: []interface{}{1.0}})
+	group.SendMessage(context.Message{Name: "test", Arguments: []interface{}{2.0}})
+	scheduler.Start()
+	waiter.Wait()
+
+	for index, actor := range actors {
+		assert.Equal(test, (index+1)*10, actor.id)
+	}
+	assert.Equal(test, []int{3, 1, 3, 2, 1, 2}, log.commands)
+}
+
+func TestReplayScheduler_withDivergence(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		trace   []Record
+		wantErr string
+	}{
+		{
+			name: "with an unsent message",
+			trace: []Record{
+				{Kind: StartRecordKind, Actor: 10, Class: "Test"},
+				{Kind: MessageRecordKind, Actor: 10, Message: "test"},
+				{Kind: MessageRecordKind, Actor: 10, Message: "unknown"},
+			},
+			wantErr: "the program diverged from the trace: " +
+				"the message unknown to the actor #10 isn't sent",
+		},
+		{
+			name: "with a stopped actor",
+			trace: []Record{
+				{Kind: StartRecordKind, Actor: 10, Class: "Test"},
+				{Kind: MessageRecordKind, Actor: 10, Message: StopMessageName},
+				{Kind: MessageRecordKind, Actor: 10, Message: "test"},
+			},
+			wantErr: "the program diverged from the trace: " +
+				"the actor #10 is stopped before receiving of the message test",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			errs := make(chan error, 1)
+			errorHandler := new(MockErrorHandler)
+			errorHandler.
+				On("HandleError", mock.AnythingOfType("*errors.withStack")).
+				Return().
+				Run(func(arguments mock.Arguments) { errs <- arguments.Error(0) })
+
+			scheduler, err := NewReplayScheduler(testData.trace, errorHandler)
+			require.NoError(test, err)
+
+			var waiter sync.WaitGroup
+			group := NewConcurrentActorGroup(context.NewDefaultContext()).WithScheduler(scheduler)
+			messages := MessageGroup{"test": NewParameterizedCommandGroup(nil, nil)}
+			states := StateGroup{"state": NewParameterizedMessageGroup(nil, messages)}
+			actor := &ConcurrentActor{
+				innerActor: &Actor{
+					states:       NewParameterizedStateGroup(nil, states),
+					currentState: context.State{Name: "state"},
+				},
+				inbox:        newInbox(0, &waiter),
+				dependencies: Dependencies{WaitGroup: &waiter, Recorder: scheduler},
+			}
+			group.RegisterActor(actor, nil)
+
+			actor.Stop()
+			actor.SendMessage(context.Message{Name: "test"})
+			scheduler.Start()
+
+			select {
+			case err := <-errs:
+				assert.EqualError(test, err, testData.wantErr)
+			case <-time.After(time.Second):
+				test.Fatal("the divergence isn't detected")
+			}
+		})
+	}
+}
+
+func TestReplayScheduler_withRequest(test *testing.T) {
+	scheduler, err := NewReplayScheduler([]Record{
+		{Kind: StartRecordKind, Actor: 10, Class: "Test"},
+		{Kind: MessageRecordKind, Actor: 10, Message: "test", Request: true},
+	}, new(MockErrorHandler))
+
+	assert.Nil(test, scheduler)
+	assert.Error(test, err)
+}
+
+func TestReplayScheduler_RecordStart(test *testing.T) {
+	scheduler, err := NewReplayScheduler([]Record{
+		{Kind: StartRecordKind, Actor: 10, Class: "Test"},
+		{Kind: StartRecordKind, Actor: 20, Parent: 10, Class: "Test"},
+	}, new(MockErrorHandler))
+	require.NoError(test, err)
+
+	parent := &ConcurrentActor{id: 1}
+	parentErr := scheduler.RecordStart(parent)
+
+	child := &ConcurrentActor{id: 2, parent: parent}
+	childErr := scheduler.RecordStart(child)
+
+	unrecordedChild := &ConcurrentActor{id: 3, parent: parent}
+	unrecordedChildErr := scheduler.RecordStart(unrecordedChild)
+
+	assert.Equal(test, 10, parent.id)
+	assert.NoError(test, parentErr)
+	assert.Equal(test, 20, child.id)
+	assert.NoError(test, childErr)
+	assert.Equal(test, 3, unrecordedChild.id)
+	assert.Error(test, unrecordedChildErr)
+	assert.True(test, atomic.LoadInt64(&lastActorID) >= 20)
+}
+
+func TestReplayScheduler_RecordValue(test *testing.T) {
+	scheduler, err := NewReplayScheduler([]Record{
+		{Kind: RandomRecordKind, Actor: 10, Value: 0.1},
+		{Kind: TimeRecordKind, Actor: 10, Value: 23},
+		{Kind: RandomRecordKind, Actor: 20, Value: 0.2},
+		{Kind: RandomRecordKind, Actor: 10, Value: 0.3},
+	}, new(MockErrorHandler))
+	require.NoError(test, err)
+
+	actor := &ConcurrentActor{id: 10}
+	producer := func() float64 { return 0.5 }
+	var gotValues []float64
+	for _, kind := range []RecordKind{RandomRecordKind, RandomRecordKind, TimeRecordKind} {
+		value, err := scheduler.RecordValue(actor, kind, producer)
+		assert.NoError(test, err)
+
+		gotValues = append(gotValues, value)
+	}
+
+	gotUnrecordedValue, gotUnrecordedErr := scheduler.RecordValue(actor, TimeRecordKind, producer)
+
+	assert.Equal(test, []float64{0.1, 0.3, 23}, gotValues)
+	assert.Equal(test, 0.5, gotUnrecordedValue)
+	assert.Error(test, gotUnrecordedErr)
+}
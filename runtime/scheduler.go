@@ -0,0 +1,236 @@
+package runtime
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// SchedulerKind ...
+//
+// With the goroutine scheduler, each actor runs in its own goroutine. With
+// the round-robin scheduler, all actors run in a single goroutine in turn,
+// so the order of processing of messages is deterministic.
+type SchedulerKind int
+
+// ...
+const (
+	GoroutineSchedulerKind SchedulerKind = iota
+	RoundRobinSchedulerKind
+)
+
+// nolint: gochecknoglobals
+var (
+	schedulerKindNames = []string{"goroutine", "round-robin"}
+)
+
+// SchedulerKindNames ...
+func SchedulerKindNames() []string {
+	return append([]string(nil), schedulerKindNames...)
+}
+
+// ParseSchedulerKind ...
+func ParseSchedulerKind(name string) (SchedulerKind, error) {
+	for kind, kindName := range schedulerKindNames {
+		if kindName == name {
+			return SchedulerKind(kind), nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown scheduler kind %s", name)
+}
+
+// String ...
+func (kind SchedulerKind) String() string {
+	if kind < 0 || int(kind) >= len(schedulerKindNames) {
+		return "unknown"
+	}
+
+	return schedulerKindNames[kind]
+}
+
+// Scheduler ...
+//
+// The Start method should be called once after scheduling of the initial actors.
+type Scheduler interface {
+	ScheduleActor(actor context.Actor, context context.Context, arguments []interface{})
+	Start()
+}
+
+// NewScheduler ...
+//
+// The seed is used only by the round-robin scheduler.
+func NewScheduler(kind SchedulerKind, seed int64) Scheduler {
+	if kind == RoundRobinSchedulerKind {
+		return NewRoundRobinScheduler(seed)
+	}
+
+	return GoroutineScheduler{}
+}
+
+// GoroutineScheduler ...
+//
+// It starts each actor in its own goroutine immediately on its scheduling.
+type GoroutineScheduler struct{}
+
+// ScheduleActor ...
+func (scheduler GoroutineScheduler) ScheduleActor(
+	actor context.Actor,
+	context context.Context,
+	arguments []interface{},
+) {
+	go actor.Start(context, arguments)
+}
+
+// Start ...
+func (scheduler GoroutineScheduler) Start() {}
+
+// RoundRobinScheduler ...
+//
+// It runs all actors in a single goroutine. Each actor in turn processes
+// a single message from its inbox. The seed determines the order of the turns:
+// each new actor is inserted to a random place of the round. It also determines
+// the random numbers of the actors, because they're drawn from the same source.
+//
+// The actors scheduled before the scheduler start are started in order of their
+// scheduling.
+//
+// Since actors share the goroutine, senders can't be blocked, so the block inbox
+// policy shouldn't be used with the scheduler (it doesn't limit the inboxes then).
+// Similarly, actors can't wait for replies to their requests, so the ask function
+// fails in them.
+//
+// Actors other than the concurrent ones run in their own goroutines.
+type RoundRobinScheduler struct {
+	wakeups chan struct{}
+	starter sync.Once
+
+	locker    sync.Mutex
+	newActors []*scheduledActor
+
+	// they are used only by the goroutine of the scheduler
+	random       *rand.Rand
+	activeActors []*scheduledActor
+}
+
+type scheduledActor struct {
+	actor     *ConcurrentActor
+	context   context.Context
+	arguments []interface{}
+}
+
+// NewRoundRobinScheduler ...
+func NewRoundRobinScheduler(seed int64) *RoundRobinScheduler {
+	return &RoundRobinScheduler{
+		wakeups: make(chan struct{}, 1),
+		// nolint: gosec
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
+
+// ScheduleActor ...
+func (scheduler *RoundRobinScheduler) ScheduleActor(
+	actor context.Actor,
+	context context.Context,
+	arguments []interface{},
+) {
+	concurrentActor, ok := actor.(*ConcurrentActor)
+	if !ok {
+		go actor.Start(context, arguments)
+		return
+	}
+
+	scheduler.locker.Lock()
+	defer scheduler.locker.Unlock()
+
+	scheduler.newActors = append(scheduler.newActors, &scheduledActor{
+		actor:     concurrentActor,
+		context:   context,
+		arguments: arguments,
+	})
+	concurrentActor.inbox.setListener(scheduler.wakeUp)
+	concurrentActor.random = scheduler.random
+	concurrentActor.sharesGoroutine = true
+}
+
+// Start ...
+func (scheduler *RoundRobinScheduler) Start() {
+	scheduler.starter.Do(func() { go scheduler.run() })
+}
+
+func (scheduler *RoundRobinScheduler) wakeUp() {
+	select {
+	case scheduler.wakeups <- struct{}{}:
+	default:
+	}
+}
+
+func (scheduler *RoundRobinScheduler) run() {
+	for {
+		if !scheduler.runRound() {
+			<-scheduler.wakeups
+		}
+	}
+}
+
+// it returns false if no actor has done anything
+func (scheduler *RoundRobinScheduler) runRound() bool {
+	progressed := scheduler.startNewActors()
+
+	// the actors started during the round take their turns in the next one
+	for _, actor := range scheduler.activeActors {
+		message, ok := actor.actor.inbox.tryReceive()
+		if !ok {
+			continue
+		}
+
+		if !actor.actor.handleMessage(actor.context, actor.arguments, message) {
+			scheduler.removeActor(actor)
+		}
+
+		// the actors started by the message should be started before the next turn
+		scheduler.startNewActors()
+		progressed = true
+	}
+
+	return progressed
+}
+
+// it returns false if there are no new actors
+func (scheduler *RoundRobinScheduler) startNewActors() bool {
+	scheduler.locker.Lock()
+	newActors := scheduler.newActors
+	scheduler.newActors = nil
+	scheduler.locker.Unlock()
+
+	for _, actor := range newActors {
+		// the actor is started outside the lock, because its initial state
+		// may start other actors
+		actor.context = actor.actor.prepare(actor.context, actor.arguments)
+
+		// the active actors are copied, because the current round may iterate over them
+		index := scheduler.random.Intn(len(scheduler.activeActors) + 1)
+		activeActors := make([]*scheduledActor, 0, len(scheduler.activeActors)+1)
+		activeActors = append(activeActors, scheduler.activeActors[:index]...)
+		activeActors = append(activeActors, actor)
+		activeActors = append(activeActors, scheduler.activeActors[index:]...)
+		scheduler.activeActors = activeActors
+	}
+
+	return len(newActors) != 0
+}
+
+func (scheduler *RoundRobinScheduler) removeActor(actor *scheduledActor) {
+	for index, activeActor := range scheduler.activeActors {
+		if activeActor == actor {
+			// the active actors are copied, because the current round may iterate over them
+			scheduler.activeActors = append(
+				scheduler.activeActors[:index:index],
+				scheduler.activeActors[index+1:]...,
+			)
+			break
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package runtime
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestSchedulerKindNames(test *testing.T) {
+	got := SchedulerKindNames()
+	got[0] = "modified"
+
+	assert.Equal(test, []string{"goroutine", "round-robin"}, SchedulerKindNames())
+}
+
+func TestParseSchedulerKind(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		args    string
+		want    SchedulerKind
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with the goroutine scheduler",
+			args:    "goroutine",
+			want:    GoroutineSchedulerKind,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the round-robin scheduler",
+			args:    "round-robin",
+			want:    RoundRobinSchedulerKind,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    "unknown",
+			want:    0,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := ParseSchedulerKind(testData.args)
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestSchedulerKind_String(test *testing.T) {
+	for _, testData := range []struct {
+		name string
+		kind SchedulerKind
+		want string
+	}{
+		{
+			name: "known kind",
+			kind: RoundRobinSchedulerKind,
+			want: "round-robin",
+		},
+		{
+			name: "unknown kind",
+			kind: SchedulerKind(23),
+			want: "unknown",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.kind.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestNewScheduler(test *testing.T) {
+	assert.Equal(test, GoroutineScheduler{}, NewScheduler(GoroutineSchedulerKind, 23))
+	assert.IsType(test, &RoundRobinScheduler{}, NewScheduler(RoundRobinSchedulerKind, 23))
+}
+
+func TestGoroutineScheduler(test *testing.T) {
+	var waiter sync.WaitGroup
+	waiter.Add(1)
+
+	context := new(MockContext)
+	arguments := []interface{}{2.3, 4.2}
+	actor := new(MockContextActor)
+	actor.On("Start", context, arguments).Return().Run(func(mock.Arguments) { waiter.Done() })
+
+	GoroutineScheduler{}.ScheduleActor(actor, context, arguments)
+	waiter.Wait()
+
+	mock.AssertExpectationsForObjects(test, context, actor)
+}
+
+func TestRoundRobinScheduler(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		seed    int64
+		wantLog []int
+	}{
+		{
+			name:    "success with the first seed",
+			seed:    0,
+			wantLog: []int{2, 3, 1, 2, 3, 1},
+		},
+		{
+			name:    "success with the second seed",
+			seed:    23,
+			wantLog: []int{2, 1, 3, 2, 1, 3},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var log commandLog
+			var waiter sync.WaitGroup
+			scheduler := NewRoundRobinScheduler(testData.seed)
+			group := NewConcurrentActorGroup(context.NewDefaultContext()).WithScheduler(scheduler)
+			for id := 1; id <= 3; id++ {
+				command := newLoggableCommand(&log, id)
+				command.mock.On("Run", mock.Anything).Return(types.Nil{}, nil)
+
+				messages := MessageGroup{"test": NewParameterizedCommandGroup(nil, CommandGroup{command})}
+				states := StateGroup{"state": NewParameterizedMessageGroup(nil, messages)}
+				group.RegisterActor(&ConcurrentActor{
+					innerActor: &Actor{
+						states:       NewParameterizedStateGroup(nil, states),
+						currentState: context.State{Name: "state"},
+					},
+					inbox:        newInbox(0, &waiter),
+					dependencies: Dependencies{WaitGroup: &waiter},
+				}, nil)
+			}
+
+			group.SendMessage(context.Message{Name: "test"})
+			group.SendMessage(context.Message{Name: "test"})
+			scheduler.Start()
+			waiter.Wait()
+
+			assert.Equal(test, testData.wantLog, log.commands)
+		})
+	}
+}
+
+func TestRoundRobinScheduler_withRandom(test *testing.T) {
+	makeNumbers := func(seed int64) []float64 {
+		scheduler := NewRoundRobinScheduler(seed)
+		actor := &ConcurrentActor{inbox: newInbox(0, new(sync.WaitGroup))}
+		scheduler.ScheduleActor(actor, context.NewDefaultContext(), nil)
+
+		var numbers []float64
+		for index := 0; index < 3; index++ {
+			numbers = append(numbers, actor.Random())
+		}
+
+		return numbers
+	}
+
+	assert.Equal(test, makeNumbers(23), makeNumbers(23))
+	assert.NotEqual(test, makeNumbers(23), makeNumbers(42))
+}
@@ -3,9 +3,15 @@ package runtime
 import (
 	"github.com/pkg/errors"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
+// DefaultStateName ...
+const DefaultStateName = "_"
+
 // StateGroup ...
+//
+// The handlers of the default state apply in every state unless they're overridden.
 type StateGroup map[string]ParameterizedMessageGroup
 
 // Contains ...
@@ -14,9 +20,19 @@ func (states StateGroup) Contains(state context.State) bool {
 	return ok
 }
 
+// HandlesMessage ...
+func (states StateGroup) HandlesMessage(state context.State, name string) bool {
+	_, _, ok := states.selectHandler(state, context.Message{Name: name})
+	return ok
+}
+
 // ProcessMessage ...
+//
+// The handler of the message is searched in the following order: the handler in the state,
+// the handler in the default state, the wildcard handler in the state and the wildcard
+// handler in the default state. The wildcard handlers don't handle system messages.
 func (states StateGroup) ProcessMessage(
-	context context.Context,
+	ctx context.Context,
 	state context.State,
 	message context.Message,
 ) error {
@@ -24,7 +40,12 @@ func (states StateGroup) ProcessMessage(
 		return newUnknownStateError(state)
 	}
 
-	err := states[state.Name].ParameterizedProcessMessage(context, state.Arguments, message)
+	messages, message, ok := states.selectHandler(state, message)
+	if !ok {
+		return nil
+	}
+
+	err := messages.ParameterizedProcessMessage(ctx, state.Arguments, message)
 	if err != nil {
 		return errors.Wrapf(err, "unable to process the state %s", state.Name)
 	}
@@ -32,6 +53,78 @@ func (states StateGroup) ProcessMessage(
 	return nil
 }
 
+// EnterState ...
+//
+// It runs the enter hook of the state, if the latter has it.
+func (states StateGroup) EnterState(ctx context.Context, state context.State) error {
+	return states.enterState(ctx, nil, state)
+}
+
+// ExitState ...
+//
+// It runs the exit hook of the state, if the latter has it.
+func (states StateGroup) ExitState(ctx context.Context, state context.State) error {
+	if !states.Contains(state) {
+		return newUnknownStateError(state)
+	}
+
+	if err := states[state.Name].ParameterizedExit(ctx, nil, state.Arguments); err != nil {
+		return errors.Wrapf(err, "unable to exit the state %s", state.Name)
+	}
+
+	return nil
+}
+
+func (states StateGroup) enterState(
+	ctx context.Context,
+	values context.ValueGroup,
+	state context.State,
+) error {
+	if !states.Contains(state) {
+		return newUnknownStateError(state)
+	}
+
+	if err := states[state.Name].ParameterizedEnter(ctx, values, state.Arguments); err != nil {
+		return errors.Wrapf(err, "unable to enter the state %s", state.Name)
+	}
+
+	return nil
+}
+
+func (states StateGroup) selectHandler(state context.State, message context.Message) (
+	messages ParameterizedMessageGroup,
+	handledMessage context.Message,
+	ok bool,
+) {
+	candidates := []string{state.Name, DefaultStateName}
+	for _, candidate := range candidates {
+		if messages, ok := states[candidate]; ok && messages.Contains(message.Name) {
+			return messages, message, true
+		}
+	}
+
+	if IsSystemMessageName(message.Name) {
+		return ParameterizedMessageGroup{}, context.Message{}, false
+	}
+
+	for _, candidate := range candidates {
+		if messages, ok := states[candidate]; ok && messages.Contains(WildcardMessageName) {
+			wildcardMessage := context.Message{
+				Name: WildcardMessageName,
+				Arguments: []interface{}{
+					types.NewPairFromText(message.Name),
+					types.NewPairFromSlice(message.Arguments),
+				},
+				Sender:    message.Sender,
+				Broadcast: message.Broadcast,
+			}
+			return messages, wildcardMessage, true
+		}
+	}
+
+	return ParameterizedMessageGroup{}, context.Message{}, false
+}
+
 // ParameterizedStateGroup ...
 type ParameterizedStateGroup struct {
 	StateGroup
@@ -64,3 +157,15 @@ func (parameterizedStates ParameterizedStateGroup) ParameterizedProcessMessage(
 
 	return nil
 }
+
+// ParameterizedEnterState ...
+//
+// It runs the enter hook of the state with the parameters of the group bound.
+func (parameterizedStates ParameterizedStateGroup) ParameterizedEnterState(
+	ctx context.Context,
+	arguments []interface{},
+	state context.State,
+) error {
+	values := context.ZipValues(parameterizedStates.parameters, arguments)
+	return parameterizedStates.StateGroup.enterState(ctx, values, state)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 func TestStateGroup(test *testing.T) {
@@ -92,6 +93,153 @@ func TestStateGroup(test *testing.T) {
 			wantLog: []int{15, 16, 17, 18, 19},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with the default state",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), nil)
+				options := loggableCommandOptions{"message_4": {withCalls()}}
+				states[DefaultStateName] =
+					newLoggableParameterizedMessages(context, log, group(2, 3), group(5, 100), options)
+
+				return states
+			},
+			args: args{
+				context: new(MockContext),
+				state:   context.State{Name: "state_1"},
+				message: context.Message{Name: "message_4"},
+			},
+			wantLog: []int{120, 121, 122, 123, 124},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the default state and the overridden handler",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), loggableCommandOptions{
+					"message_3": {withCalls()},
+				})
+				states[DefaultStateName] =
+					newLoggableParameterizedMessages(context, log, group(2, 3), group(5, 100), nil)
+
+				return states
+			},
+			args: args{
+				context: new(MockContext),
+				state:   context.State{Name: "state_1"},
+				message: context.Message{Name: "message_3"},
+			},
+			wantLog: []int{15, 16, 17, 18, 19},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the wildcard handler",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), nil)
+				states["state_1"].messages[WildcardMessageName] = newWildcardCommands(context, log, withCalls())
+
+				return states
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", "name", types.NewPairFromText("message_unknown")).Return()
+					context.On("SetValue", "args", &types.Pair{Head: 23}).Return()
+
+					return context
+				}(),
+				state: context.State{Name: "state_1"},
+				message: context.Message{
+					Name:      "message_unknown",
+					Arguments: []interface{}{23},
+				},
+			},
+			wantLog: []int{200, 201},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the wildcard handler in the default state",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), nil)
+				states[DefaultStateName] =
+					newLoggableParameterizedMessages(context, log, group(0), group(5), nil)
+				states[DefaultStateName].messages[WildcardMessageName] =
+					newWildcardCommands(context, log, withCalls())
+
+				return states
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", "name", types.NewPairFromText("message_unknown")).Return()
+					context.On("SetValue", "args", (*types.Pair)(nil)).Return()
+
+					return context
+				}(),
+				state:   context.State{Name: "state_1"},
+				message: context.Message{Name: "message_unknown"},
+			},
+			wantLog: []int{200, 201},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the wildcard handler and the handler in the default state",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), nil)
+				states["state_1"].messages[WildcardMessageName] = newWildcardCommands(context, log)
+				options := loggableCommandOptions{"message_4": {withCalls()}}
+				states[DefaultStateName] =
+					newLoggableParameterizedMessages(context, log, group(2, 3), group(5, 100), options)
+
+				return states
+			},
+			args: args{
+				context: new(MockContext),
+				state:   context.State{Name: "state_1"},
+				message: context.Message{Name: "message_4"},
+			},
+			wantLog: []int{120, 121, 122, 123, 124},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the wildcard handlers in the current and default states",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), nil)
+				states["state_1"].messages[WildcardMessageName] = newWildcardCommands(context, log, withCalls())
+				states[DefaultStateName] =
+					newLoggableParameterizedMessages(context, log, group(0), group(5), nil)
+				states[DefaultStateName].messages[WildcardMessageName] = newWildcardCommands(context, log)
+
+				return states
+			},
+			args: args{
+				context: func() context.Context {
+					context := new(MockContext)
+					context.On("SetValue", "name", types.NewPairFromText("message_unknown")).Return()
+					context.On("SetValue", "args", (*types.Pair)(nil)).Return()
+
+					return context
+				}(),
+				state:   context.State{Name: "state_1"},
+				message: context.Message{Name: "message_unknown"},
+			},
+			wantLog: []int{200, 201},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the wildcard handler and a system message",
+			makeStates: func(context context.Context, log *commandLog) StateGroup {
+				states := newLoggableStates(context, log, 2, group(2), group(5), nil)
+				states["state_1"].messages[WildcardMessageName] = newWildcardCommands(context, log)
+
+				return states
+			},
+			args: args{
+				context: new(MockContext),
+				state:   context.State{Name: "state_1"},
+				message: context.Message{Name: "__unknown__"},
+			},
+			wantLog: nil,
+			wantErr: assert.NoError,
+		},
 		{
 			name:       "error with an empty group",
 			makeStates: func(context context.Context, log *commandLog) StateGroup { return nil },
@@ -145,6 +293,20 @@ func TestStateGroup(test *testing.T) {
 	}
 }
 
+func TestStateGroup_HandlesMessage(test *testing.T) {
+	states := StateGroup{
+		"state_0":        {messages: MessageGroup{"message_0": {}}},
+		"state_1":        {messages: MessageGroup{WildcardMessageName: {}}},
+		DefaultStateName: {messages: MessageGroup{"message_1": {}}},
+	}
+
+	assert.True(test, states.HandlesMessage(context.State{Name: "state_0"}, "message_0"))
+	assert.True(test, states.HandlesMessage(context.State{Name: "state_0"}, "message_1"))
+	assert.False(test, states.HandlesMessage(context.State{Name: "state_0"}, "message_2"))
+	assert.True(test, states.HandlesMessage(context.State{Name: "state_1"}, "message_2"))
+	assert.False(test, states.HandlesMessage(context.State{Name: "state_1"}, "__message__"))
+}
+
 func TestParameterizedStateGroup(test *testing.T) {
 	type fields struct {
 		parameters []string
@@ -317,3 +479,14 @@ func TestParameterizedStateGroup(test *testing.T) {
 		})
 	}
 }
+
+func newWildcardCommands(
+	context context.Context,
+	log *commandLog,
+	options ...loggableCommandOption,
+) ParameterizedCommandGroup {
+	config := parameterizedGroup(2, "name", "args")
+	config.idOffset = 200
+
+	return newLoggableParameterizedCommands(context, log, config, options...)
+}
@@ -0,0 +1,186 @@
+package runtime
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	syncutils "github.com/thewizardplusplus/go-sync-utils"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// nolint: gochecknoglobals
+var (
+	lastTimerID int64
+)
+
+// Timer ...
+//
+// It sends the message to the receiver after the delay. If the interval is
+// positive, it repeats the sending with this interval until cancelling.
+//
+// The timer is considered as a pending job of the wait group until it's
+// finished or cancelled, so the program doesn't end before that.
+type Timer struct {
+	id        int
+	receiver  context.MessageSender
+	message   context.Message
+	delay     time.Duration
+	interval  time.Duration
+	waitGroup syncutils.WaitGroup
+	finisher  func(timer *Timer)
+
+	locker   sync.Mutex
+	finished bool
+	stopper  chan struct{}
+}
+
+// NewTimer ...
+func NewTimer(
+	receiver context.MessageSender,
+	message context.Message,
+	delay time.Duration,
+	interval time.Duration,
+	waitGroup syncutils.WaitGroup,
+) *Timer {
+	id := int(atomic.AddInt64(&lastTimerID, 1))
+	return &Timer{
+		id:        id,
+		receiver:  receiver,
+		message:   message,
+		delay:     delay,
+		interval:  interval,
+		waitGroup: waitGroup,
+		stopper:   make(chan struct{}),
+	}
+}
+
+// ID ...
+func (timer *Timer) ID() int {
+	return timer.id
+}
+
+// String ...
+func (timer *Timer) String() string {
+	return fmt.Sprintf("<timer #%d>", timer.id)
+}
+
+// MarshalText ...
+func (timer *Timer) MarshalText() (text []byte, err error) {
+	return []byte(timer.String()), nil
+}
+
+// Start ...
+func (timer *Timer) Start() {
+	// waiter increment should call synchronously
+	// otherwise the program may end before the timer is fired
+	timer.waitGroup.Add(1)
+	go timer.run()
+}
+
+// Cancel ...
+//
+// It returns false if the timer is already finished or cancelled.
+func (timer *Timer) Cancel() bool {
+	timer.locker.Lock()
+	defer timer.locker.Unlock()
+
+	if timer.finished {
+		return false
+	}
+
+	timer.finished = true
+	close(timer.stopper)
+
+	return true
+}
+
+func (timer *Timer) run() {
+	defer timer.finish()
+
+	for wait := timer.delay; ; wait = timer.interval {
+		ticker := time.NewTimer(wait)
+		select {
+		case <-ticker.C:
+		case <-timer.stopper:
+			ticker.Stop()
+			return
+		}
+
+		if !timer.send() || timer.interval <= 0 {
+			return
+		}
+	}
+}
+
+func (timer *Timer) send() bool {
+	timer.locker.Lock()
+	// the timer may be cancelled concurrently with its firing
+	if timer.finished {
+		timer.locker.Unlock()
+		return false
+	}
+	if timer.interval <= 0 {
+		timer.finished = true
+	}
+	timer.locker.Unlock()
+
+	// don't hold the lock during sending, because it may block
+	// and then block the cancelling of the timer
+	timer.receiver.SendMessage(timer.message)
+	return true
+}
+
+func (timer *Timer) finish() {
+	timer.locker.Lock()
+	timer.finished = true
+	timer.locker.Unlock()
+
+	if timer.finisher != nil {
+		timer.finisher(timer)
+	}
+
+	timer.waitGroup.Done()
+}
+
+type timerGroup struct {
+	locker sync.Mutex
+	timers map[*Timer]struct{}
+}
+
+func (group *timerGroup) startTimer(timer *Timer) {
+	group.locker.Lock()
+	defer group.locker.Unlock()
+
+	if group.timers == nil {
+		group.timers = make(map[*Timer]struct{})
+	}
+
+	group.timers[timer] = struct{}{}
+	timer.finisher = group.removeTimer
+	timer.Start()
+}
+
+func (group *timerGroup) removeTimer(timer *Timer) {
+	group.locker.Lock()
+	defer group.locker.Unlock()
+
+	delete(group.timers, timer)
+}
+
+func (group *timerGroup) hasTimers() bool {
+	group.locker.Lock()
+	defer group.locker.Unlock()
+
+	return len(group.timers) != 0
+}
+
+func (group *timerGroup) cancelTimers() {
+	group.locker.Lock()
+	defer group.locker.Unlock()
+
+	for timer := range group.timers {
+		timer.Cancel()
+	}
+}
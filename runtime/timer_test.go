@@ -0,0 +1,158 @@
+package runtime
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	syncutils "github.com/thewizardplusplus/go-sync-utils"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestTimer(test *testing.T) {
+	message := context.Message{Name: "test", Arguments: []interface{}{23}}
+	receiver := new(MockContext)
+	receiver.On("SendMessage", message).Return().Once()
+
+	waiter := new(MockWaitGroup)
+	waiter.On("Add", 1).Once()
+	waiter.On("Done").Once()
+	waiter.On("Wait").Once()
+
+	synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
+	timer := NewTimer(receiver, message, time.Millisecond, 0, synchronousWaiter)
+	timer.Start()
+	synchronousWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(test, receiver, waiter)
+	assert.False(test, timer.Cancel())
+}
+
+func TestTimer_withInterval(test *testing.T) {
+	message := context.Message{Name: "test", Arguments: []interface{}{23}}
+	sendings := make(chan struct{}, 10)
+	receiver := new(MockContext)
+	receiver.
+		On("SendMessage", message).
+		Return().
+		Run(func(mock.Arguments) { sendings <- struct{}{} })
+
+	waiter := new(MockWaitGroup)
+	waiter.On("Add", 1).Once()
+	waiter.On("Done").Once()
+	waiter.On("Wait").Once()
+
+	synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
+	timer := NewTimer(receiver, message, time.Millisecond, time.Millisecond, synchronousWaiter)
+	timer.Start()
+	for i := 0; i < 3; i++ {
+		<-sendings
+	}
+
+	gotCancelResult := timer.Cancel()
+	synchronousWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(test, receiver, waiter)
+	assert.True(test, gotCancelResult)
+	assert.GreaterOrEqual(test, len(receiver.Calls), 3)
+}
+
+func TestTimer_Cancel(test *testing.T) {
+	receiver := new(MockContext)
+
+	waiter := new(MockWaitGroup)
+	waiter.On("Add", 1).Once()
+	waiter.On("Done").Once()
+	waiter.On("Wait").Once()
+
+	synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
+	timer := NewTimer(receiver, context.Message{Name: "test"}, time.Hour, 0, synchronousWaiter)
+	timer.Start()
+
+	gotFirstCancelResult := timer.Cancel()
+	gotSecondCancelResult := timer.Cancel()
+	synchronousWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(test, receiver, waiter)
+	assert.True(test, gotFirstCancelResult)
+	assert.False(test, gotSecondCancelResult)
+}
+
+func TestTimer_Cancel_withBlockedSending(test *testing.T) {
+	message := context.Message{Name: "test"}
+	sending := make(chan struct{})
+	unblocker := make(chan struct{})
+	receiver := new(MockContext)
+	receiver.
+		On("SendMessage", message).
+		Return().
+		Run(func(mock.Arguments) {
+			close(sending)
+			<-unblocker
+		}).
+		Once()
+
+	waiter := new(MockWaitGroup)
+	waiter.On("Add", 1).Once()
+	waiter.On("Done").Once()
+	waiter.On("Wait").Once()
+
+	synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
+	timer := NewTimer(receiver, message, time.Millisecond, time.Millisecond, synchronousWaiter)
+	timer.Start()
+	<-sending
+
+	cancelResults := make(chan bool)
+	go func() { cancelResults <- timer.Cancel() }()
+
+	var gotCancelResult bool
+	select {
+	case gotCancelResult = <-cancelResults:
+	case <-time.After(time.Second):
+		test.Fatal("the cancelling is blocked by the sending")
+	}
+
+	close(unblocker)
+	synchronousWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(test, receiver, waiter)
+	assert.True(test, gotCancelResult)
+}
+
+func TestTimer_String(test *testing.T) {
+	timer := &Timer{id: 23}
+	got := timer.String()
+
+	assert.Equal(test, "<timer #23>", got)
+}
+
+func TestTimer_MarshalText(test *testing.T) {
+	timer := &Timer{id: 23}
+	gotText, gotErr := timer.MarshalText()
+
+	assert.Equal(test, []byte("<timer #23>"), gotText)
+	assert.NoError(test, gotErr)
+}
+
+func TestConcurrentActor_StartTimer(test *testing.T) {
+	receiver := new(MockContext)
+
+	waiter := new(MockWaitGroup)
+	waiter.On("Add", 1).Times(2)
+	waiter.On("Done").Times(2)
+	waiter.On("Wait").Once()
+
+	synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
+	actor := &ConcurrentActor{dependencies: Dependencies{WaitGroup: synchronousWaiter}}
+	timerOne := actor.StartTimer(receiver, context.Message{Name: "one"}, time.Hour, 0)
+	timerTwo := actor.StartTimer(receiver, context.Message{Name: "two"}, time.Hour, time.Hour)
+	actor.timers.cancelTimers()
+	synchronousWaiter.Wait()
+
+	mock.AssertExpectationsForObjects(test, receiver, waiter)
+	assert.False(test, timerOne.Cancel())
+	assert.False(test, timerTwo.Cancel())
+	assert.Empty(test, actor.timers.timers)
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"sync"
+
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+// TopicRegistry ...
+//
+// It routes messages published to a topic only to the actors subscribed to it.
+// The subscribers of a topic receive its messages in order of their subscription.
+//
+// The zero value is ready to use.
+type TopicRegistry struct {
+	locker sync.RWMutex
+	topics map[string][]context.Actor
+}
+
+// SubscribeActor ...
+//
+// Repeated subscriptions of the actor to the same topic are ignored.
+func (registry *TopicRegistry) SubscribeActor(topic string, actor context.Actor) {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	if registry.topics == nil {
+		registry.topics = make(map[string][]context.Actor)
+	}
+
+	for _, subscriber := range registry.topics[topic] {
+		if subscriber == actor {
+			return
+		}
+	}
+
+	registry.topics[topic] = append(registry.topics[topic], actor)
+}
+
+// UnsubscribeActor ...
+func (registry *TopicRegistry) UnsubscribeActor(topic string, actor context.Actor) {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	registry.unsubscribeActor(topic, actor)
+}
+
+// UnsubscribeActorFromAll ...
+//
+// It unsubscribes the actor from all topics, e.g. on its stopping.
+func (registry *TopicRegistry) UnsubscribeActorFromAll(actor context.Actor) {
+	registry.locker.Lock()
+	defer registry.locker.Unlock()
+
+	for topic := range registry.topics {
+		registry.unsubscribeActor(topic, actor)
+	}
+}
+
+// PublishMessage ...
+//
+// The published message is marked as broadcast, so it isn't a dead letter
+// for subscribers that don't handle it.
+func (registry *TopicRegistry) PublishMessage(topic string, message context.Message) {
+	// don't hold the lock during sending, because it may block
+	registry.locker.RLock()
+	subscribers := append([]context.Actor(nil), registry.topics[topic]...)
+	registry.locker.RUnlock()
+
+	message.Broadcast = true
+
+	for _, subscriber := range subscribers {
+		subscriber.SendMessage(message)
+	}
+}
+
+func (registry *TopicRegistry) unsubscribeActor(topic string, actor context.Actor) {
+	subscribers := registry.topics[topic]
+	for index, subscriber := range subscribers {
+		if subscriber == actor {
+			subscribers = append(subscribers[:index], subscribers[index+1:]...)
+			break
+		}
+	}
+
+	if len(subscribers) == 0 {
+		delete(registry.topics, topic)
+		return
+	}
+
+	registry.topics[topic] = subscribers
+}
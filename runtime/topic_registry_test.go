@@ -0,0 +1,82 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestTopicRegistry_SubscribeActor(test *testing.T) {
+	actorOne := new(MockContextActor)
+	actorTwo := new(MockContextActor)
+
+	var registry TopicRegistry
+	registry.SubscribeActor("one", actorOne)
+	registry.SubscribeActor("one", actorTwo)
+	registry.SubscribeActor("one", actorOne)
+	registry.SubscribeActor("two", actorTwo)
+
+	mock.AssertExpectationsForObjects(test, actorOne, actorTwo)
+	assert.Equal(test, map[string][]context.Actor{
+		"one": {actorOne, actorTwo},
+		"two": {actorTwo},
+	}, registry.topics)
+}
+
+func TestTopicRegistry_UnsubscribeActor(test *testing.T) {
+	actorOne := new(MockContextActor)
+	actorTwo := new(MockContextActor)
+	registry := TopicRegistry{
+		topics: map[string][]context.Actor{
+			"one": {actorOne, actorTwo},
+			"two": {actorTwo},
+		},
+	}
+	registry.UnsubscribeActor("one", actorOne)
+	registry.UnsubscribeActor("two", actorTwo)
+	registry.UnsubscribeActor("two", actorOne)
+	registry.UnsubscribeActor("unknown", actorOne)
+
+	mock.AssertExpectationsForObjects(test, actorOne, actorTwo)
+	assert.Equal(test, map[string][]context.Actor{"one": {actorTwo}}, registry.topics)
+}
+
+func TestTopicRegistry_UnsubscribeActorFromAll(test *testing.T) {
+	actorOne := new(MockContextActor)
+	actorTwo := new(MockContextActor)
+	registry := TopicRegistry{
+		topics: map[string][]context.Actor{
+			"one": {actorOne, actorTwo},
+			"two": {actorTwo},
+		},
+	}
+	registry.UnsubscribeActorFromAll(actorTwo)
+
+	mock.AssertExpectationsForObjects(test, actorOne, actorTwo)
+	assert.Equal(test, map[string][]context.Actor{"one": {actorOne}}, registry.topics)
+}
+
+func TestTopicRegistry_PublishMessage(test *testing.T) {
+	publishedMessage := context.Message{Name: "test", Arguments: []interface{}{23}, Broadcast: true}
+
+	actorOne := new(MockContextActor)
+	actorOne.On("SendMessage", publishedMessage).Return()
+
+	actorTwo := new(MockContextActor)
+	actorTwo.On("SendMessage", publishedMessage).Return()
+
+	actorThree := new(MockContextActor)
+
+	registry := TopicRegistry{
+		topics: map[string][]context.Actor{
+			"one": {actorOne, actorTwo},
+			"two": {actorThree},
+		},
+	}
+	registry.PublishMessage("one", context.Message{Name: "test", Arguments: []interface{}{23}})
+	registry.PublishMessage("unknown", context.Message{Name: "test"})
+
+	mock.AssertExpectationsForObjects(test, actorOne, actorTwo, actorThree)
+}
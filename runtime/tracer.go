@@ -0,0 +1,359 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+// TraceEventKind ...
+type TraceEventKind string
+
+// ...
+const (
+	StartEventKind       TraceEventKind = "start"
+	SendEventKind        TraceEventKind = "send"
+	DequeueEventKind     TraceEventKind = "dequeue"
+	StateChangeEventKind TraceEventKind = "state_change"
+	ErrorEventKind       TraceEventKind = "error"
+)
+
+// TraceEvent ...
+//
+// The Actor and Class fields identify the actor that starts, receives a message,
+// changes its state or fails. The Sender and SenderClass fields identify the sender
+// of the message, if it's an actor. The State field contains the initial state
+// on the start, the current state on the dequeuing and the new state
+// on the state change.
+//
+// The Arguments field contains the arguments of the message marshalled to JSON,
+// where the lists of printable characters are represented by strings.
+type TraceEvent struct {
+	Time          time.Time       `json:"time"`
+	Kind          TraceEventKind  `json:"event"`
+	Actor         int             `json:"actor"`
+	Class         string          `json:"class"`
+	Parent        int             `json:"parent,omitempty"`
+	Sender        int             `json:"sender,omitempty"`
+	SenderClass   string          `json:"sender_class,omitempty"`
+	Message       string          `json:"message,omitempty"`
+	Arguments     json.RawMessage `json:"arguments,omitempty"`
+	State         string          `json:"state,omitempty"`
+	PreviousState string          `json:"previous_state,omitempty"`
+	Error         string          `json:"error,omitempty"`
+}
+
+// Tracer ...
+type Tracer interface {
+	TraceEvent(event TraceEvent) error
+}
+
+// TraceFormat ...
+//
+// With the JSON Lines format, each event is written as the JSON representation
+// of the TraceEvent structure. With the OpenTelemetry format, each event is written
+// as a separate OTLP/JSON request for export of a single log record.
+type TraceFormat int
+
+// ...
+const (
+	JSONLinesTraceFormat TraceFormat = iota
+	OpenTelemetryTraceFormat
+)
+
+// nolint: gochecknoglobals
+var (
+	traceFormatNames = []string{"jsonl", "otel"}
+)
+
+// TraceFormatNames ...
+func TraceFormatNames() []string {
+	return append([]string(nil), traceFormatNames...)
+}
+
+// ParseTraceFormat ...
+func ParseTraceFormat(name string) (TraceFormat, error) {
+	for format, formatName := range traceFormatNames {
+		if formatName == name {
+			return TraceFormat(format), nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown trace format %s", name)
+}
+
+// String ...
+func (format TraceFormat) String() string {
+	if format < 0 || int(format) >= len(traceFormatNames) {
+		return "unknown"
+	}
+
+	return traceFormatNames[format]
+}
+
+// TraceWriter ...
+//
+// It writes events to the writer, one event per line. Each event is written
+// by a single call of the writer.
+type TraceWriter struct {
+	format TraceFormat
+	locker sync.Mutex
+	writer io.Writer
+}
+
+// NewTraceWriter ...
+func NewTraceWriter(writer io.Writer, format TraceFormat) *TraceWriter {
+	return &TraceWriter{format: format, writer: writer}
+}
+
+// TraceEvent ...
+func (tracer *TraceWriter) TraceEvent(event TraceEvent) error {
+	var value interface{} = event
+	if tracer.format == OpenTelemetryTraceFormat {
+		value = makeOTLPRequest(event)
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return errors.Wrap(err, "unable to marshal the event")
+	}
+
+	tracer.locker.Lock()
+	defer tracer.locker.Unlock()
+
+	if _, err := tracer.writer.Write(buffer.Bytes()); err != nil {
+		return errors.Wrap(err, "unable to write the event")
+	}
+
+	return nil
+}
+
+// LoadTraceEvents ...
+//
+// It supports only the JSON Lines format. Events of unknown kinds and without
+// the actor are considered as errors.
+func LoadTraceEvents(reader io.Reader) ([]TraceEvent, error) {
+	var events []TraceEvent
+	if err := scanLines(reader, func(line []byte, lineNumber int) error {
+		var event TraceEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			return errors.Wrapf(err, "unable to unmarshal the event on line %d", lineNumber)
+		}
+
+		switch event.Kind {
+		case StartEventKind, SendEventKind, DequeueEventKind, StateChangeEventKind, ErrorEventKind:
+		default:
+			return errors.Errorf("unknown kind %q of the event on line %d", event.Kind, lineNumber)
+		}
+		// identifiers of actors start from one
+		if event.Actor == 0 {
+			return errors.Errorf("missed actor of the event on line %d", lineNumber)
+		}
+
+		events = append(events, event)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// the types below describe the subset of the OTLP/JSON format for logs
+type otlpRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string          `json:"timeUnixNano"`
+	SeverityNumber int             `json:"severityNumber"`
+	SeverityText   string          `json:"severityText"`
+	Body           otlpValue       `json:"body"`
+	Attributes     []otlpAttribute `json:"attributes"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+func marshalTracedArguments(arguments []interface{}) (json.RawMessage, error) {
+	return marshalDeepArguments(arguments, getTracedValue)
+}
+
+// strings can't be distinguished from lists of numbers, so only lists of integers,
+// that are codes of printable characters, are converted to strings
+func getTracedValue(value interface{}) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case *types.Pair:
+		if text, ok := getPrintableText(typedValue); ok {
+			return text, nil
+		}
+
+		var items []interface{}
+		for _, item := range typedValue.Slice() {
+			tracedItem, err := getTracedValue(item)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to get the traced list")
+			}
+
+			items = append(items, tracedItem)
+		}
+
+		return items, nil
+	case types.HashTable:
+		items := make(map[string]interface{})
+		for key, item := range typedValue {
+			keyAsString, ok := key.(string)
+			if !ok {
+				return nil, errors.New("unable to get the traced hash table: not string key")
+			}
+
+			tracedItem, err := getTracedValue(item)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to get the traced hash table")
+			}
+
+			items[keyAsString] = tracedItem
+		}
+
+		return items, nil
+	}
+
+	return value, nil
+}
+
+func getPrintableText(pair *types.Pair) (string, bool) {
+	if pair == nil {
+		return "", false
+	}
+
+	var symbols []rune
+	for _, item := range pair.Slice() {
+		code, ok := item.(float64)
+		if !ok || code != math.Trunc(code) || code < 0 || code > unicode.MaxRune {
+			return "", false
+		}
+
+		symbol := rune(code)
+		if !unicode.IsPrint(symbol) && !unicode.IsSpace(symbol) {
+			return "", false
+		}
+
+		symbols = append(symbols, symbol)
+	}
+
+	return string(symbols), true
+}
+
+// the integer values are strings according to the OTLP/JSON format
+type otlpValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+// ...
+const (
+	otlpServiceName       = "tick-tock"
+	otlpInfoSeverity      = 9
+	otlpErrorSeverity     = 17
+	otlpInfoSeverityText  = "INFO"
+	otlpErrorSeverityText = "ERROR"
+)
+
+func makeOTLPRequest(event TraceEvent) otlpRequest {
+	record := otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(event.Time.UnixNano(), 10),
+		SeverityNumber: otlpInfoSeverity,
+		SeverityText:   otlpInfoSeverityText,
+		Body:           makeOTLPString(string(event.Kind)),
+	}
+	if event.Kind == ErrorEventKind {
+		record.SeverityNumber = otlpErrorSeverity
+		record.SeverityText = otlpErrorSeverityText
+	}
+
+	record.Attributes = append(
+		record.Attributes,
+		otlpAttribute{Key: "event.name", Value: makeOTLPString(string(event.Kind))},
+		otlpAttribute{Key: "actor.id", Value: makeOTLPInt(event.Actor)},
+		otlpAttribute{Key: "actor.class", Value: makeOTLPString(event.Class)},
+	)
+	for _, attribute := range []struct {
+		key   string
+		value interface{}
+	}{
+		{"actor.parent.id", event.Parent},
+		{"sender.id", event.Sender},
+		{"sender.class", event.SenderClass},
+		{"message.name", event.Message},
+		{"message.arguments", string(event.Arguments)},
+		{"state.name", event.State},
+		{"state.previous_name", event.PreviousState},
+		{"error.message", event.Error},
+	} {
+		switch value := attribute.value.(type) {
+		case int:
+			if value != 0 {
+				record.Attributes = append(
+					record.Attributes,
+					otlpAttribute{Key: attribute.key, Value: makeOTLPInt(value)},
+				)
+			}
+		case string:
+			if value != "" {
+				record.Attributes = append(
+					record.Attributes,
+					otlpAttribute{Key: attribute.key, Value: makeOTLPString(value)},
+				)
+			}
+		}
+	}
+
+	return otlpRequest{ResourceLogs: []otlpResourceLogs{{
+		Resource: otlpResource{Attributes: []otlpAttribute{
+			{Key: "service.name", Value: makeOTLPString(otlpServiceName)},
+		}},
+		ScopeLogs: []otlpScopeLogs{{
+			Scope:      otlpScope{Name: otlpServiceName},
+			LogRecords: []otlpLogRecord{record},
+		}},
+	}}}
+}
+
+func makeOTLPString(value string) otlpValue {
+	return otlpValue{StringValue: &value}
+}
+
+func makeOTLPInt(value int) otlpValue {
+	text := strconv.Itoa(value)
+	return otlpValue{IntValue: &text}
+}
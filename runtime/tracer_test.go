@@ -0,0 +1,321 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
+)
+
+func TestTraceFormatNames(test *testing.T) {
+	got := TraceFormatNames()
+	got[0] = "modified"
+
+	assert.Equal(test, []string{"jsonl", "otel"}, TraceFormatNames())
+}
+
+func TestParseTraceFormat(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		args    string
+		want    TraceFormat
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "success with the JSON Lines format",
+			args:    "jsonl",
+			want:    JSONLinesTraceFormat,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "success with the OpenTelemetry format",
+			args:    "otel",
+			want:    OpenTelemetryTraceFormat,
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error",
+			args:    "unknown",
+			want:    0,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := ParseTraceFormat(testData.args)
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestTraceFormat_String(test *testing.T) {
+	for _, testData := range []struct {
+		name   string
+		format TraceFormat
+		want   string
+	}{
+		{
+			name:   "known format",
+			format: OpenTelemetryTraceFormat,
+			want:   "otel",
+		},
+		{
+			name:   "unknown format",
+			format: TraceFormat(23),
+			want:   "unknown",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got := testData.format.String()
+
+			assert.Equal(test, testData.want, got)
+		})
+	}
+}
+
+func TestTraceWriter(test *testing.T) {
+	event := TraceEvent{
+		Time:        time.Unix(23, 42).UTC(),
+		Kind:        SendEventKind,
+		Actor:       2,
+		Class:       "Child",
+		Sender:      1,
+		SenderClass: "Parent",
+		Message:     "test",
+		Arguments:   json.RawMessage("[4.2]"),
+	}
+
+	for _, testData := range []struct {
+		name   string
+		format TraceFormat
+		want   string
+	}{
+		{
+			name:   "JSON Lines",
+			format: JSONLinesTraceFormat,
+			want: `{"time":"1970-01-01T00:00:23.000000042Z","event":"send","actor":2,` +
+				`"class":"Child","sender":1,"sender_class":"Parent","message":"test",` +
+				`"arguments":[4.2]}` + "\n",
+		},
+		{
+			name:   "OpenTelemetry",
+			format: OpenTelemetryTraceFormat,
+			want: `{"resourceLogs":[{"resource":{"attributes":[` +
+				`{"key":"service.name","value":{"stringValue":"tick-tock"}}]},` +
+				`"scopeLogs":[{"scope":{"name":"tick-tock"},"logRecords":[{` +
+				`"timeUnixNano":"23000000042","severityNumber":9,"severityText":"INFO",` +
+				`"body":{"stringValue":"send"},"attributes":[` +
+				`{"key":"event.name","value":{"stringValue":"send"}},` +
+				`{"key":"actor.id","value":{"intValue":"2"}},` +
+				`{"key":"actor.class","value":{"stringValue":"Child"}},` +
+				`{"key":"sender.id","value":{"intValue":"1"}},` +
+				`{"key":"sender.class","value":{"stringValue":"Parent"}},` +
+				`{"key":"message.name","value":{"stringValue":"test"}},` +
+				`{"key":"message.arguments","value":{"stringValue":"[4.2]"}}]}]}]}]}` + "\n",
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			var buffer bytes.Buffer
+			err := NewTraceWriter(&buffer, testData.format).TraceEvent(event)
+
+			assert.Equal(test, testData.want, buffer.String())
+			assert.NoError(test, err)
+		})
+	}
+}
+
+func TestLoadTraceEvents(test *testing.T) {
+	for _, testData := range []struct {
+		name    string
+		trace   string
+		want    []TraceEvent
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			trace: `{"time":"1970-01-01T00:00:23Z","event":"start","actor":23,"class":"Child","parent":12}
+
+{"time":"1970-01-01T00:00:42Z","event":"send","actor":23,"class":"Child","message":"test"}
+`,
+			want: []TraceEvent{
+				{
+					Time:   time.Unix(23, 0).UTC(),
+					Kind:   StartEventKind,
+					Actor:  23,
+					Class:  "Child",
+					Parent: 12,
+				},
+				{
+					Time:    time.Unix(42, 0).UTC(),
+					Kind:    SendEventKind,
+					Actor:   23,
+					Class:   "Child",
+					Message: "test",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "error on unmarshalling",
+			trace:   `{"event":"start","actor":23}` + "\nincorrect\n",
+			want:    nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:  "error with an unknown kind",
+			trace: `{"event":"start","actor":23}` + "\n" + `{"event":"unknown","actor":23}` + "\n",
+			want:  nil,
+			wantErr: func(test assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(test, err, `unknown kind "unknown" of the event on line 2`, msgAndArgs...)
+			},
+		},
+		{
+			name:  "error with an empty kind",
+			trace: `{"actor":23}` + "\n",
+			want:  nil,
+			wantErr: func(test assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(test, err, `unknown kind "" of the event on line 1`, msgAndArgs...)
+			},
+		},
+		{
+			name:  "error without the actor",
+			trace: `{"event":"start","actor":23}` + "\n\n" + `{"event":"send","class":"Child"}` + "\n",
+			want:  nil,
+			wantErr: func(test assert.TestingT, err error, msgAndArgs ...interface{}) bool {
+				return assert.EqualError(test, err, "missed actor of the event on line 3", msgAndArgs...)
+			},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			got, err := LoadTraceEvents(strings.NewReader(testData.trace))
+
+			assert.Equal(test, testData.want, got)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
+func TestConcurrentActor_withTracer(test *testing.T) {
+	setCommand := new(MockCommand)
+	setCommand.
+		On("Run", mock.AnythingOfType("*context.DefaultContext")).
+		Return(types.Nil{}, nil).
+		Run(func(arguments mock.Arguments) {
+			ctx := arguments.Get(0).(context.Context)
+			ctx.SetState(ctx, context.State{Name: "state_1"}) // nolint: errcheck, gosec
+		})
+
+	failedCommand := new(MockCommand)
+	failedCommand.
+		On("Run", mock.AnythingOfType("*context.DefaultContext")).
+		Return(nil, iotest.ErrTimeout)
+
+	states := StateGroup{
+		"state_0": NewParameterizedMessageGroup(nil, MessageGroup{
+			"test": NewParameterizedCommandGroup(nil, CommandGroup{setCommand}),
+		}),
+		"state_1": NewParameterizedMessageGroup(nil, MessageGroup{
+			"test": NewParameterizedCommandGroup(nil, CommandGroup{failedCommand}),
+		}),
+	}
+
+	errorHandler := new(MockErrorHandler)
+	errorHandler.On("LogError", mock.AnythingOfType("*errors.withStack")).Return()
+
+	var waiter sync.WaitGroup
+	var buffer bytes.Buffer
+	scheduler := NewRoundRobinScheduler(0)
+	group := NewConcurrentActorGroup(context.NewDefaultContext()).WithScheduler(scheduler)
+	actor := &ConcurrentActor{
+		id:        23,
+		className: "Test",
+		innerActor: &Actor{
+			states:       NewParameterizedStateGroup(nil, states),
+			currentState: context.State{Name: "state_0"},
+		},
+		inbox:         newInbox(0, &waiter),
+		failurePolicy: IgnoreAndLogPolicy,
+		dependencies: Dependencies{
+			WaitGroup:    &waiter,
+			ErrorHandler: errorHandler,
+			Tracer:       NewTraceWriter(&buffer, JSONLinesTraceFormat),
+		},
+	}
+	group.RegisterActor(actor, nil)
+
+	group.SendMessage(context.Message{
+		Name: "test",
+		Arguments: []interface{}{
+			4.2,
+			types.NewPairFromText("hi"),
+			types.NewPairFromSlice([]interface{}{1.0}),
+		},
+	})
+	group.SendMessage(context.Message{Name: "test"})
+	scheduler.Start()
+	waiter.Wait()
+
+	var gotEvents []TraceEvent
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var event TraceEvent
+		err := json.Unmarshal([]byte(line), &event)
+		require.NoError(test, err)
+
+		assert.False(test, event.Time.IsZero())
+		event.Time = time.Time{}
+
+		gotEvents = append(gotEvents, event)
+	}
+
+	wantEvents := []TraceEvent{
+		{Kind: StartEventKind, Actor: 23, Class: "Test", State: "state_0"},
+		{
+			Kind:      SendEventKind,
+			Actor:     23,
+			Class:     "Test",
+			Message:   "test",
+			Arguments: json.RawMessage(`[4.2,"hi",[1]]`),
+		},
+		{Kind: SendEventKind, Actor: 23, Class: "Test", Message: "test"},
+		{
+			Kind:      DequeueEventKind,
+			Actor:     23,
+			Class:     "Test",
+			Message:   "test",
+			Arguments: json.RawMessage(`[4.2,"hi",[1]]`),
+			State:     "state_0",
+		},
+		{
+			Kind:          StateChangeEventKind,
+			Actor:         23,
+			Class:         "Test",
+			State:         "state_1",
+			PreviousState: "state_0",
+		},
+		{Kind: DequeueEventKind, Actor: 23, Class: "Test", Message: "test", State: "state_1"},
+		{
+			Kind:  ErrorEventKind,
+			Actor: 23,
+			Class: "Test",
+			Error: "unable to process parameterized states: " +
+				"unable to process the state state_1: " +
+				"unable to process parameterized messages: " +
+				"unable to process the message test: " +
+				"unable to run parameterized commands: " +
+				"unable to run the command #0: " +
+				iotest.ErrTimeout.Error(),
+		},
+	}
+	mock.AssertExpectationsForObjects(test, setCommand, failedCommand, errorHandler)
+	assert.Equal(test, wantEvents, gotEvents)
+}
@@ -17,7 +17,7 @@ const (
 
 // NewBoolean ...
 func NewBoolean(value interface{}) (Boolean, error) {
-	if isActorClass(value) {
+	if isActorClass(value) || isActor(value) || isTimer(value) || isFunction(value) {
 		return True, nil
 	}
 
@@ -105,6 +105,34 @@ func TestNewBoolean(test *testing.T) {
 			wantResult: types.True,
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "success/actor",
+			args: args{
+				value: func() *runtime.ConcurrentActor {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+						context.State{Name: "state_0"},
+					)
+					return runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{}).
+						CreateActor()
+				}(),
+			},
+			wantResult: types.True,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/timer",
+			args:       args{value: runtime.NewTimer(nil, context.Message{}, 0, 0, nil)},
+			wantResult: types.True,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success/function",
+			args:       args{value: runtime.NewFunction(nil, nil)},
+			wantResult: types.True,
+			wantErr:    assert.NoError,
+		},
 		{
 			name:       "error",
 			args:       args{func() {}},
@@ -23,6 +23,14 @@ func Equals(leftValue interface{}, rightValue interface{}) (bool, error) {
 
 		return getActorClassName(leftValue) == getActorClassName(rightValue), nil
 	}
+	if isActor(leftValue) {
+		// actors are equal only if they are the same actor
+		return leftValue == rightValue, nil
+	}
+	if isTimer(leftValue) {
+		// timers are equal only if they are the same timer
+		return leftValue == rightValue, nil
+	}
 
 	// if operands have different types, they aren't equal
 	switch typedLeftValue := leftValue.(type) {
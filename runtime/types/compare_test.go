@@ -53,6 +53,30 @@ func TestEquals(test *testing.T) {
 			wantResult: assert.True,
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "success/equal/actor",
+			args: func() args {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+					context.State{Name: "state_0"},
+				)
+				actor := runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{}).
+					CreateActor()
+				return args{leftValue: actor, rightValue: actor}
+			}(),
+			wantResult: assert.True,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success/equal/timer",
+			args: func() args {
+				timer := runtime.NewTimer(nil, context.Message{}, 0, 0, nil)
+				return args{leftValue: timer, rightValue: timer}
+			}(),
+			wantResult: assert.True,
+			wantErr:    assert.NoError,
+		},
 		{
 			name: "success/equal/hash table",
 			args: args{
@@ -94,6 +118,33 @@ func TestEquals(test *testing.T) {
 			wantResult: assert.False,
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "success/not equal/same types/actor",
+			args: func() args {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+					context.State{Name: "state_0"},
+				)
+				concurrentActorFactory :=
+					runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{})
+				return args{
+					leftValue:  concurrentActorFactory.CreateActor(),
+					rightValue: concurrentActorFactory.CreateActor(),
+				}
+			}(),
+			wantResult: assert.False,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "success/not equal/same types/timer",
+			args: args{
+				leftValue:  runtime.NewTimer(nil, context.Message{}, 0, 0, nil),
+				rightValue: runtime.NewTimer(nil, context.Message{}, 0, 0, nil),
+			},
+			wantResult: assert.False,
+			wantErr:    assert.NoError,
+		},
 		{
 			name: "success/not equal/same types/hash table",
 			args: args{
@@ -146,6 +197,23 @@ func TestEquals(test *testing.T) {
 			wantResult: assert.False,
 			wantErr:    assert.NoError,
 		},
+		{
+			name: "success/not equal/different types/actor",
+			args: args{
+				leftValue: func() *runtime.ConcurrentActor {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test",
+						runtime.ParameterizedStateGroup{StateGroup: runtime.StateGroup{"state_0": {}}},
+						context.State{Name: "state_0"},
+					)
+					return runtime.NewConcurrentActorFactory(actorFactory, 0, runtime.Dependencies{}).
+						CreateActor()
+				}(),
+				rightValue: types.Nil{},
+			},
+			wantResult: assert.False,
+			wantErr:    assert.NoError,
+		},
 		{
 			name: "success/not equal/different types/hash table",
 			args: args{
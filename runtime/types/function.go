@@ -0,0 +1,10 @@
+package types
+
+import (
+	"reflect"
+)
+
+func isFunction(value interface{}) bool {
+	// can't use this type directly because it occurs an import cycle
+	return reflect.TypeOf(value).Name() == "Function"
+}
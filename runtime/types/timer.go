@@ -0,0 +1,11 @@
+package types
+
+import (
+	"reflect"
+)
+
+func isTimer(value interface{}) bool {
+	// can't use this type directly because it occurs an import cycle
+	valueType := reflect.TypeOf(value)
+	return valueType.Kind() == reflect.Ptr && valueType.Elem().Name() == "Timer"
+}
@@ -1,12 +1,24 @@
 package translator
 
 import (
+	"github.com/alecthomas/participle/lexer"
 	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/commands"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
 )
 
+// TranslatePosition ...
+func TranslatePosition(position lexer.Position) runtime.Position {
+	return runtime.Position{
+		Filename: position.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+	}
+}
+
 func translateExpressionGroup(expressions *parser.ExpressionGroup, declaredIdentifiers mapset.Set) (
 	translatedExpressions []expressions.Expression,
 	settedStates mapset.Set,
@@ -26,3 +38,33 @@ func translateExpressionGroup(expressions *parser.ExpressionGroup, declaredIdent
 
 	return translatedExpressions, settedStates, nil
 }
+
+func positionExpression(
+	expression expressions.Expression,
+	position lexer.Position,
+) expressions.Expression {
+	translatedPosition := TranslatePosition(position)
+	if !translatedPosition.IsValid() {
+		return expression
+	}
+
+	return expressions.NewPositionedExpression(expression, translatedPosition)
+}
+
+func positionCommand(command runtime.Command, position lexer.Position) runtime.Command {
+	translatedPosition := TranslatePosition(position)
+	if !translatedPosition.IsValid() {
+		return command
+	}
+
+	return commands.NewPositionedCommand(command, translatedPosition)
+}
+
+func positionError(err error, position lexer.Position) error {
+	translatedPosition := TranslatePosition(position)
+	if !translatedPosition.IsValid() {
+		return err
+	}
+
+	return runtime.NewPositionedError(err, translatedPosition)
+}
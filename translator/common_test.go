@@ -0,0 +1,119 @@
+package translator
+
+import (
+	"testing"
+
+	"github.com/alecthomas/participle/lexer"
+	mapset "github.com/deckarep/golang-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+	"github.com/thewizardplusplus/tick-tock/runtime/commands"
+	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+)
+
+func TestTranslatePosition(test *testing.T) {
+	got := TranslatePosition(lexer.Position{Filename: "test.tt", Offset: 5, Line: 2, Column: 3})
+
+	want := runtime.Position{Filename: "test.tt", Line: 2, Column: 3}
+	assert.Equal(test, want, got)
+}
+
+func TestTranslateExpression_withPositions(test *testing.T) {
+	type args struct {
+		code                string
+		declaredIdentifiers mapset.Set
+	}
+
+	for _, data := range []struct {
+		name           string
+		args           args
+		wantExpression expressions.Expression
+		wantPosition   runtime.Position
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				code:                "x + y(x.one)",
+				declaredIdentifiers: mapset.NewSet("x", "y"),
+			},
+			wantExpression: expressions.NewPositionedExpression(
+				expressions.NewFunctionCall(AdditionFunctionName, []expressions.Expression{
+					expressions.NewPositionedExpression(
+						expressions.NewIdentifier("x"),
+						runtime.Position{Filename: "test.tt", Line: 1, Column: 1},
+					),
+					expressions.NewPositionedExpression(
+						expressions.NewFunctionCall("y", []expressions.Expression{
+							expressions.NewPositionedExpression(
+								expressions.NewFunctionCall(KeyAccessorFunctionName, []expressions.Expression{
+									expressions.NewPositionedExpression(
+										expressions.NewIdentifier("x"),
+										runtime.Position{Filename: "test.tt", Line: 1, Column: 7},
+									),
+									expressions.NewString("one"),
+								}),
+								runtime.Position{Filename: "test.tt", Line: 1, Column: 7},
+							),
+						}),
+						runtime.Position{Filename: "test.tt", Line: 1, Column: 5},
+					),
+				}),
+				runtime.Position{Filename: "test.tt", Line: 1, Column: 1},
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			args: args{
+				code:                "x +\n  y(unknown)",
+				declaredIdentifiers: mapset.NewSet("x", "y"),
+			},
+			wantExpression: nil,
+			wantPosition:   runtime.Position{Filename: "test.tt", Line: 2, Column: 5},
+			wantErr:        assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			expression := new(parser.Expression)
+			err := parser.ParseFileToAST("test.tt", data.args.code, expression)
+			require.NoError(test, err)
+
+			gotExpression, _, gotErr :=
+				TranslateExpression(expression, data.args.declaredIdentifiers)
+			gotPosition, _ := runtime.FindPosition(gotErr)
+
+			assert.Equal(test, data.wantExpression, gotExpression)
+			assert.Equal(test, data.wantPosition, gotPosition)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestTranslateCommands_withPositions(test *testing.T) {
+	commandsWrapper := new(struct {
+		Commands []*parser.Command `parser:"{ @@ }"`
+	})
+	err := parser.ParseFileToAST("test.tt", "let x = 23\nx", commandsWrapper)
+	require.NoError(test, err)
+
+	gotCommands, _, gotErr := translateCommands(commandsWrapper.Commands, mapset.NewSet())
+
+	wantCommands := runtime.CommandGroup{
+		commands.NewPositionedCommand(
+			commands.NewLetCommand("x", expressions.NewNumber(23)),
+			runtime.Position{Filename: "test.tt", Line: 1, Column: 1},
+		),
+		commands.NewPositionedCommand(
+			commands.NewExpressionCommand(expressions.NewPositionedExpression(
+				expressions.NewIdentifier("x"),
+				runtime.Position{Filename: "test.tt", Line: 2, Column: 1},
+			)),
+			runtime.Position{Filename: "test.tt", Line: 2, Column: 1},
+		),
+	}
+	assert.Equal(test, wantCommands, gotCommands)
+	assert.NoError(test, gotErr)
+}
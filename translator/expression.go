@@ -4,9 +4,11 @@ import (
 	"reflect"
 	"unicode/utf8"
 
+	"github.com/alecthomas/participle/lexer"
 	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
 	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
@@ -94,6 +96,7 @@ func translateBinaryOperation(
 	argumentOneReflection := binaryOperationReflection.Field(0)
 	argumentTwoReflection := binaryOperationReflection.Field(2)
 	operationNameReflection := binaryOperationReflection.FieldByName("Operation")
+	positionReflection := binaryOperationReflection.FieldByName("Pos")
 
 	var translatedArgumentOne expressions.Expression
 	if argumentOneReflection.Type() == reflect.TypeOf(&parser.Unary{}) {
@@ -142,6 +145,9 @@ func translateBinaryOperation(
 		)
 	}
 
+	position := positionReflection.Interface().(lexer.Position)
+	translatedBinaryOperation = positionExpression(translatedBinaryOperation, position)
+
 	settedStates = settedStates.Union(settedStates2)
 	return translatedBinaryOperation, settedStates, nil
 }
@@ -213,6 +219,7 @@ func translateAccessor(
 			KeyAccessorFunctionName,
 			[]expressions.Expression{argumentOne, argumentTwo},
 		)
+		argumentOne = positionExpression(argumentOne, accessor.Pos)
 		settedStates = settedStates.Union(settedStates2)
 	}
 
@@ -241,10 +248,11 @@ func translateAtom(
 	case atom.Identifier != nil:
 		identifier := *atom.Identifier
 		if !declaredIdentifiers.Contains(identifier) {
-			return nil, nil, errors.Errorf("unknown identifier %s", identifier)
+			err := errors.Errorf("unknown identifier %s", identifier)
+			return nil, nil, positionError(err, atom.Pos)
 		}
 
-		expression = expressions.NewIdentifier(identifier)
+		expression = positionExpression(expressions.NewIdentifier(identifier), atom.Pos)
 	case atom.ListDefinition != nil:
 		expression, settedStates, err = translateListDefinition(atom.ListDefinition, declaredIdentifiers)
 		if err != nil {
@@ -256,11 +264,27 @@ func translateAtom(
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "unable to translate the hash table definition")
 		}
+	case atom.Start != nil:
+		var command runtime.Command
+		command, settedStates, err = translateStartCommand(atom.Start, declaredIdentifiers)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to translate the start command")
+		}
+
+		expression = expressions.NewCommandExpression(command)
+	case atom.Lambda != nil:
+		expression, settedStates, err = translateLambda(atom.Lambda, declaredIdentifiers)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to translate the lambda")
+		}
 	case atom.FunctionCall != nil:
 		expression, settedStates, err = translateFunctionCall(atom.FunctionCall, declaredIdentifiers)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, "unable to translate the function call")
+			err = errors.Wrap(positionError(err, atom.Pos), "unable to translate the function call")
+			return nil, nil, err
 		}
+
+		expression = positionExpression(expression, atom.Pos)
 	case atom.ConditionalExpression != nil:
 		expression, settedStates, err =
 			translateConditionalExpression(atom.ConditionalExpression, declaredIdentifiers)
@@ -376,6 +400,28 @@ func translateFunctionCall(
 	return expression, settedStates, nil
 }
 
+func translateLambda(
+	lambda *parser.Lambda,
+	declaredIdentifiers mapset.Set,
+) (
+	expression expressions.Expression,
+	settedStates mapset.Set,
+	err error,
+) {
+	localDeclaredIdentifiers := declaredIdentifiers.Clone()
+	for _, parameter := range lambda.Parameters.Identifiers {
+		localDeclaredIdentifiers.Add(parameter)
+	}
+
+	commands, settedStates, err := translateCommands(lambda.Commands, localDeclaredIdentifiers)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to translate commands")
+	}
+
+	expression = expressions.NewLambda(lambda.Parameters.Identifiers, commands)
+	return expression, settedStates, nil
+}
+
 func translateConditionalExpression(
 	conditionalExpression *parser.ConditionalExpression,
 	declaredIdentifiers mapset.Set,
@@ -75,6 +75,7 @@ func TestTranslateExpression(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			expression := new(parser.Expression)
 			err := parser.ParseToAST(data.args.code, expression)
+			parser.ClearPositions(expression)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -218,6 +219,7 @@ func TestTranslateBinaryOperation_listConstruction(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			listConstruction := new(parser.ListConstruction)
 			err := parser.ParseToAST(data.args.code, listConstruction)
+			parser.ClearPositions(listConstruction)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -361,6 +363,7 @@ func TestTranslateBinaryOperation_nilCoalescing(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			nilCoalescing := new(parser.NilCoalescing)
 			err := parser.ParseToAST(data.args.code, nilCoalescing)
+			parser.ClearPositions(nilCoalescing)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -507,6 +510,7 @@ func TestTranslateBinaryOperation_disjunction(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			disjunction := new(parser.Disjunction)
 			err := parser.ParseToAST(data.args.code, disjunction)
+			parser.ClearPositions(disjunction)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -653,6 +657,7 @@ func TestTranslateBinaryOperation_conjunction(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			conjunction := new(parser.Conjunction)
 			err := parser.ParseToAST(data.args.code, conjunction)
+			parser.ClearPositions(conjunction)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -796,6 +801,7 @@ func TestTranslateBinaryOperation_equality(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			equality := new(parser.Equality)
 			err := parser.ParseToAST(data.args.code, equality)
+			parser.ClearPositions(equality)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -987,6 +993,7 @@ func TestTranslateBinaryOperation_comparison(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			comparison := new(parser.Comparison)
 			err := parser.ParseToAST(data.args.code, comparison)
+			parser.ClearPositions(comparison)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -1138,6 +1145,7 @@ func TestTranslateBinaryOperation_bitwiseDisjunction(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			bitwiseDisjunction := new(parser.BitwiseDisjunction)
 			err := parser.ParseToAST(data.args.code, bitwiseDisjunction)
+			parser.ClearPositions(bitwiseDisjunction)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -1289,6 +1297,7 @@ func TestTranslateBinaryOperation_bitwiseExclusiveDisjunction(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			bitwiseExclusiveDisjunction := new(parser.BitwiseExclusiveDisjunction)
 			err := parser.ParseToAST(data.args.code, bitwiseExclusiveDisjunction)
+			parser.ClearPositions(bitwiseExclusiveDisjunction)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -1440,6 +1449,7 @@ func TestTranslateBinaryOperation_bitwiseConjunction(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			bitwiseConjunction := new(parser.BitwiseConjunction)
 			err := parser.ParseToAST(data.args.code, bitwiseConjunction)
+			parser.ClearPositions(bitwiseConjunction)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -1594,6 +1604,7 @@ func TestTranslateBinaryOperation_shift(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			shift := new(parser.Shift)
 			err := parser.ParseToAST(data.args.code, shift)
+			parser.ClearPositions(shift)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -1753,6 +1764,7 @@ func TestTranslateBinaryOperation_addition(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			addition := new(parser.Addition)
 			err := parser.ParseToAST(data.args.code, addition)
+			parser.ClearPositions(addition)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -1928,6 +1940,7 @@ func TestTranslateBinaryOperation_multiplication(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			multiplication := new(parser.Multiplication)
 			err := parser.ParseToAST(data.args.code, multiplication)
+			parser.ClearPositions(multiplication)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -2065,6 +2078,7 @@ func TestTranslateUnary(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			unary := new(parser.Unary)
 			err := parser.ParseToAST(data.args.code, unary)
+			parser.ClearPositions(unary)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -2256,6 +2270,7 @@ func TestTranslateAccessor(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			accessor := new(parser.Accessor)
 			err := parser.ParseToAST(data.args.code, accessor)
+			parser.ClearPositions(accessor)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -2502,6 +2517,68 @@ func TestTranslateAtom(test *testing.T) {
 			wantSettedStates: nil,
 			wantErr:          assert.Error,
 		},
+		{
+			name: "Atom/start/success",
+			args: args{
+				code:                "start Test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet("Test"),
+			},
+			wantExpression: expressions.NewCommandExpression(
+				commands.NewStartCommand(expressions.NewIdentifier("Test"), []expressions.Expression{
+					expressions.NewNumber(12),
+					expressions.NewNumber(23),
+					expressions.NewNumber(42),
+				}),
+			),
+			wantSettedStates: mapset.NewSet(),
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "Atom/start/error",
+			args: args{
+				code:                "start Test(12, 23, unknown)",
+				declaredIdentifiers: mapset.NewSet("Test"),
+			},
+			wantExpression:   nil,
+			wantSettedStates: nil,
+			wantErr:          assert.Error,
+		},
+		{
+			name: "Atom/lambda/success",
+			args: args{
+				code:                "func(one, two) test one two;",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantExpression: expressions.NewLambda([]string{"one", "two"}, runtime.CommandGroup{
+				commands.NewExpressionCommand(expressions.NewIdentifier("test")),
+				commands.NewExpressionCommand(expressions.NewIdentifier("one")),
+				commands.NewExpressionCommand(expressions.NewIdentifier("two")),
+			}),
+			wantSettedStates: mapset.NewSet(),
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "Atom/lambda/success/with setted states",
+			args: args{
+				code:                "func() set one();",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantExpression: expressions.NewLambda(nil, runtime.CommandGroup{
+				commands.NewSetCommand("one", nil),
+			}),
+			wantSettedStates: mapset.NewSet("one"),
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "Atom/lambda/error",
+			args: args{
+				code:                "func(one, two) unknown;",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantExpression:   nil,
+			wantSettedStates: nil,
+			wantErr:          assert.Error,
+		},
 		{
 			name: "Atom/function call/success",
 			args: args{
@@ -2728,6 +2805,7 @@ func TestTranslateAtom(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			atom := new(parser.Atom)
 			err := parser.ParseToAST(data.args.code, atom)
+			parser.ClearPositions(atom)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -2848,6 +2926,7 @@ func TestTranslateListDefinition(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			listDefinition := new(parser.ListDefinition)
 			err := parser.ParseToAST(data.args.code, listDefinition)
+			parser.ClearPositions(listDefinition)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -3059,6 +3138,7 @@ func TestTranslateHashTableDefinition(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			hashTableDefinition := new(parser.HashTableDefinition)
 			err := parser.ParseToAST(data.args.code, hashTableDefinition)
+			parser.ClearPositions(hashTableDefinition)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -3174,6 +3254,7 @@ func TestTranslateFunctionCall(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			functionCall := new(parser.FunctionCall)
 			err := parser.ParseToAST(data.args.code, functionCall)
+			parser.ClearPositions(functionCall)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -3456,6 +3537,7 @@ func TestTranslateConditionalExpression(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			conditionalExpression := new(parser.ConditionalExpression)
 			err := parser.ParseToAST(data.args.code, conditionalExpression)
+			parser.ClearPositions(conditionalExpression)
 			require.NoError(test, err)
 
 			gotExpression, gotSettedStates, gotErr :=
@@ -13,3 +13,8 @@ type MockErrorHandler struct {
 func (_m *MockErrorHandler) HandleError(err error) {
 	_m.Called(err)
 }
+
+// LogError provides a mock function with given fields: err
+func (_m *MockErrorHandler) LogError(err error) {
+	_m.Called(err)
+}
@@ -1,6 +1,9 @@
 package translator
 
 import (
+	"sort"
+	"strings"
+
 	mapset "github.com/deckarep/golang-set"
 	"github.com/pkg/errors"
 	"github.com/thewizardplusplus/tick-tock/parser"
@@ -8,12 +11,47 @@ import (
 	"github.com/thewizardplusplus/tick-tock/runtime/commands"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 // Options ...
 type Options struct {
-	InboxSize    int
-	InitialState context.State
+	InboxSize       int
+	InboxPolicies   runtime.InboxPolicyGroup
+	InitialState    context.State
+	FailurePolicies runtime.FailurePolicyGroup
+	DeadLetters     runtime.DeadLetterMode
+	Filename        string
+	Importer        Importer
+}
+
+//go:generate mockery --name=Importer --inpackage --case=underscore --testonly
+
+// Importer ...
+//
+// It loads the program imported by the path from the importing file. The returned filename
+// should be normalized, because it's used to detect import cycles.
+type Importer interface {
+	Import(path string, importingFilename string) (
+		filename string,
+		program *parser.Program,
+		err error,
+	)
+}
+
+type module struct {
+	definitions context.ValueGroup
+	origins     map[string]string
+	actors      []runtime.ConcurrentActorFactory
+	classNames  []string
+	stateGraphs []StateGraph
+	namespace   types.HashTable
+}
+
+type importState struct {
+	declaredIdentifiers mapset.Set
+	importChain         []string
+	modules             map[string]module
 }
 
 // TranslateProgram ...
@@ -27,27 +65,238 @@ func TranslateProgram(
 	translatedActors []runtime.ConcurrentActorFactory,
 	err error,
 ) {
-	definitions = make(context.ValueGroup)
-	localDeclaredIdentifiers := declaredIdentifiers.Clone()
+	state := &importState{
+		declaredIdentifiers: declaredIdentifiers,
+		importChain:         []string{options.Filename},
+		modules:             make(map[string]module),
+	}
+	translatedModule, err := translateModule(program, options, dependencies, state)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the policies are checked only after the translation of all modules,
+	// because the classes may be imported
+	classNames := mapset.NewSet()
+	for _, className := range translatedModule.classNames {
+		classNames.Add(className)
+	}
+	for className := range options.FailurePolicies.ByClass {
+		if !classNames.Contains(className) {
+			return nil, nil, errors.Errorf("unknown class %s in the failure policies", className)
+		}
+	}
+	for className := range options.InboxPolicies.ByClass {
+		if !classNames.Contains(className) {
+			return nil, nil, errors.Errorf("unknown class %s in the inbox policies", className)
+		}
+	}
+
+	return translatedModule.definitions, translatedModule.actors, nil
+}
+
+func translateModule(
+	program *parser.Program,
+	options Options,
+	dependencies runtime.Dependencies,
+	state *importState,
+) (
+	translatedModule module,
+	err error,
+) {
+	translatedModule = module{
+		definitions: make(context.ValueGroup),
+		origins:     make(map[string]string),
+	}
+	// bind the definitions and the actors of the imported module to each other,
+	// because they aren't available in the global context, if the module is imported
+	// via a namespace; the values are filled after the translation of the module
+	var values context.ValueGroup
+	if len(state.importChain) > 1 {
+		values = make(context.ValueGroup)
+	}
+
+	localDeclaredIdentifiers := state.declaredIdentifiers.Clone()
 	for index, definition := range program.Definitions {
-		translatedActorClass, wasActor, err :=
+		if definition.Import != nil {
+			err := translateImport(
+				definition.Import,
+				localDeclaredIdentifiers,
+				options,
+				dependencies,
+				state,
+				&translatedModule,
+			)
+			if err != nil {
+				return module{}, errors.Wrapf(err, "unable to translate the definition #%d", index)
+			}
+
+			continue
+		}
+
+		definitionName, translatedDefinition, wasActor, stateGraph, err :=
 			translateDefinition(definition, localDeclaredIdentifiers, options, dependencies)
 		if err != nil {
-			return nil, nil, errors.Wrapf(err, "unable to translate the definition #%d", index)
+			return module{}, errors.Wrapf(err, "unable to translate the definition #%d", index)
+		}
+		if stateGraph != nil {
+			translatedModule.stateGraphs = append(translatedModule.stateGraphs, *stateGraph)
 		}
 
-		definitionName := translatedActorClass.Name()
-		if _, ok := definitions[definitionName]; ok {
-			return nil, nil, errors.Errorf("duplicate definition %s", definitionName)
+		err = translatedModule.addDefinition(
+			definitionName,
+			translatedDefinition,
+			options.Filename,
+			options.Filename,
+		)
+		if err != nil {
+			return module{}, err
 		}
 
-		definitions[definitionName] = translatedActorClass
+		if _, ok := translatedDefinition.(runtime.ConcurrentActorFactory); ok {
+			translatedModule.classNames = append(translatedModule.classNames, definitionName)
+		}
 		if wasActor {
-			translatedActors = append(translatedActors, translatedActorClass)
+			translatedModule.actors = append(
+				translatedModule.actors,
+				translatedDefinition.(runtime.ConcurrentActorFactory).WithValues(values),
+			)
+		}
+	}
+
+	if values != nil {
+		translatedModule.namespace = bindModule(translatedModule.definitions, values)
+	}
+
+	return translatedModule, nil
+}
+
+func translateImport(
+	importDefinition *parser.Import,
+	declaredIdentifiers mapset.Set,
+	options Options,
+	dependencies runtime.Dependencies,
+	state *importState,
+	importingModule *module,
+) error {
+	if options.Importer == nil {
+		return errors.Errorf("unable to import the file %s: imports aren't supported", importDefinition.Path)
+	}
+
+	filename, program, err := options.Importer.Import(importDefinition.Path, options.Filename)
+	if err != nil {
+		return errors.Wrapf(err, "unable to import the file %s", importDefinition.Path)
+	}
+
+	for index, importingFilename := range state.importChain {
+		if importingFilename == filename {
+			importCycle := append([]string(nil), state.importChain[index:]...)
+			importCycle = append(importCycle, filename)
+			return errors.Errorf("import cycle %s", strings.Join(importCycle, " -> "))
+		}
+	}
+
+	importedModule, wasCached := state.modules[filename]
+	if !wasCached {
+		moduleOptions := options
+		moduleOptions.Filename = filename
+
+		state.importChain = append(state.importChain, filename)
+		importedModule, err = translateModule(program, moduleOptions, dependencies, state)
+		state.importChain = state.importChain[:len(state.importChain)-1]
+		if err != nil {
+			return errors.Wrapf(err, "unable to translate the file %s", filename)
+		}
+
+		state.modules[filename] = importedModule
+		importingModule.actors = append(importingModule.actors, importedModule.actors...)
+		importingModule.classNames = append(importingModule.classNames, importedModule.classNames...)
+		importingModule.stateGraphs =
+			append(importingModule.stateGraphs, importedModule.stateGraphs...)
+	}
+
+	if importDefinition.Namespace != nil {
+		err := importingModule.addDefinition(
+			*importDefinition.Namespace,
+			importedModule.namespace,
+			options.Filename,
+			options.Filename,
+		)
+		if err != nil {
+			return err
 		}
+
+		declaredIdentifiers.Add(*importDefinition.Namespace)
+		return nil
 	}
 
-	return definitions, translatedActors, nil
+	for name, definition := range importedModule.definitions {
+		err := importingModule.addDefinition(
+			name,
+			definition,
+			importedModule.origins[name],
+			options.Filename,
+		)
+		if err != nil {
+			return err
+		}
+
+		declaredIdentifiers.Add(name)
+	}
+
+	return nil
+}
+
+func (translatedModule module) addDefinition(
+	name string,
+	definition interface{},
+	filename string,
+	currentFilename string,
+) error {
+	if previousFilename, ok := translatedModule.origins[name]; ok {
+		// the same definition can be imported several times via different files
+		if previousFilename == filename && filename != currentFilename {
+			return nil
+		}
+
+		return errors.Errorf(
+			"duplicate definition %s in %s (previously defined in %s)",
+			name,
+			describeFilename(filename),
+			describeFilename(previousFilename),
+		)
+	}
+
+	translatedModule.definitions[name] = definition
+	translatedModule.origins[name] = filename
+
+	return nil
+}
+
+// the passed values are filled by the bound definitions
+func bindModule(definitions context.ValueGroup, values context.ValueGroup) types.HashTable {
+	namespace := make(types.HashTable)
+	for name, definition := range definitions {
+		switch typedDefinition := definition.(type) {
+		case runtime.ConcurrentActorFactory:
+			definition = typedDefinition.WithValues(values)
+		case runtime.Function:
+			definition = typedDefinition.WithValues(values)
+		}
+
+		values[name] = definition
+		namespace[name] = definition
+	}
+
+	return namespace
+}
+
+func describeFilename(filename string) string {
+	if len(filename) == 0 {
+		return "the main program"
+	}
+
+	return "the file " + filename
 }
 
 func translateDefinition(
@@ -56,40 +305,58 @@ func translateDefinition(
 	options Options,
 	dependencies runtime.Dependencies,
 ) (
-	translatedActorClass runtime.ConcurrentActorFactory,
+	definitionName string,
+	translatedDefinition interface{},
 	wasActor bool,
+	stateGraph *StateGraph,
 	err error,
 ) {
 	switch {
 	case definition.Actor != nil:
 		actorClass := (*parser.ActorClass)(definition.Actor)
-		translatedActorClass, err =
+		var actorStateGraph StateGraph
+		translatedDefinition, actorStateGraph, err =
 			translateActorClass(actorClass, declaredIdentifiers, options, dependencies)
 		if err != nil {
-			return runtime.ConcurrentActorFactory{}, false, errors.Wrapf(
+			return "", nil, false, nil, errors.Wrapf(
 				err,
 				"unable to translate the actor %s",
 				definition.Actor.Name,
 			)
 		}
 
-		declaredIdentifiers.Add(definition.Actor.Name)
+		definitionName = definition.Actor.Name
 		wasActor = true
+		stateGraph = &actorStateGraph
 	case definition.ActorClass != nil:
-		translatedActorClass, err =
+		var actorClassStateGraph StateGraph
+		translatedDefinition, actorClassStateGraph, err =
 			translateActorClass(definition.ActorClass, declaredIdentifiers, options, dependencies)
 		if err != nil {
-			return runtime.ConcurrentActorFactory{}, false, errors.Wrapf(
+			return "", nil, false, nil, errors.Wrapf(
 				err,
 				"unable to translate the actor class %s",
 				definition.ActorClass.Name,
 			)
 		}
 
-		declaredIdentifiers.Add(definition.ActorClass.Name)
+		definitionName = definition.ActorClass.Name
+		stateGraph = &actorClassStateGraph
+	case definition.Function != nil:
+		translatedDefinition, err = translateFunction(definition.Function, declaredIdentifiers)
+		if err != nil {
+			return "", nil, false, nil, errors.Wrapf(
+				err,
+				"unable to translate the function %s",
+				definition.Function.Name,
+			)
+		}
+
+		definitionName = definition.Function.Name
 	}
 
-	return translatedActorClass, wasActor, nil
+	declaredIdentifiers.Add(definitionName)
+	return definitionName, translatedDefinition, wasActor, stateGraph, nil
 }
 
 func translateActorClass(
@@ -99,16 +366,19 @@ func translateActorClass(
 	dependencies runtime.Dependencies,
 ) (
 	translatedActorClass runtime.ConcurrentActorFactory,
+	stateGraph StateGraph,
 	err error,
 ) {
 	localDeclaredIdentifiers := declaredIdentifiers.Clone()
+	localDeclaredIdentifiers.Add(runtime.SelfValueName)
 	for _, parameter := range actorClass.Parameters.Identifiers {
 		localDeclaredIdentifiers.Add(parameter)
 	}
 
-	states, err := translateStates(actorClass.States, localDeclaredIdentifiers)
+	states, transitions, err := translateStates(actorClass.States, localDeclaredIdentifiers)
 	if err != nil {
-		return runtime.ConcurrentActorFactory{}, errors.Wrap(err, "unable to translate states")
+		err = errors.Wrap(err, "unable to translate states")
+		return runtime.ConcurrentActorFactory{}, StateGraph{}, err
 	}
 
 	parameterizedStates :=
@@ -116,27 +386,65 @@ func translateActorClass(
 	actorFactory, err :=
 		runtime.NewActorFactory(actorClass.Name, parameterizedStates, options.InitialState)
 	if err != nil {
-		return runtime.ConcurrentActorFactory{}, errors.Wrap(err, "unable to construct the factory")
+		err = errors.Wrap(err, "unable to construct the factory")
+		return runtime.ConcurrentActorFactory{}, StateGraph{}, err
 	}
 
 	concurrentActorFactory :=
-		runtime.NewConcurrentActorFactory(actorFactory, options.InboxSize, dependencies)
-	return concurrentActorFactory, nil
+		runtime.NewConcurrentActorFactory(actorFactory, options.InboxSize, dependencies).
+			WithInboxPolicy(options.InboxPolicies.Select(actorClass.Name)).
+			WithFailurePolicy(options.FailurePolicies.Select(actorClass.Name)).
+			WithDeadLetterMode(options.DeadLetters)
+	stateGraph = StateGraph{
+		Class:        actorClass.Name,
+		InitialState: options.InitialState.Name,
+		Transitions:  transitions,
+	}
+	for _, state := range actorClass.States {
+		stateGraph.States = append(stateGraph.States, state.Name)
+	}
+
+	return concurrentActorFactory, stateGraph, nil
+}
+
+func translateFunction(function *parser.Function, declaredIdentifiers mapset.Set) (
+	translatedFunction runtime.Function,
+	err error,
+) {
+	localDeclaredIdentifiers := declaredIdentifiers.Clone()
+	// declare the function itself to support the recursion
+	localDeclaredIdentifiers.Add(function.Name)
+	for _, parameter := range function.Parameters.Identifiers {
+		localDeclaredIdentifiers.Add(parameter)
+	}
+
+	translatedCommands, settedStates, err :=
+		translateCommands(function.Commands, localDeclaredIdentifiers)
+	if err != nil {
+		return runtime.Function{}, errors.Wrap(err, "unable to translate commands")
+	}
+	if settedStates.Cardinality() != 0 {
+		return runtime.Function{}, errors.Errorf("states %v are set outside of the actor", settedStates)
+	}
+
+	translatedFunction = runtime.NewFunction(function.Parameters.Identifiers, translatedCommands)
+	return translatedFunction, nil
 }
 
 func translateStates(states []*parser.State, declaredIdentifiers mapset.Set) (
 	translatedStates runtime.StateGroup,
+	transitions []StateTransition,
 	err error,
 ) {
 	if len(states) == 0 {
-		return nil, errors.New("no states")
+		return nil, nil, errors.New("no states")
 	}
 
 	translatedStates = make(runtime.StateGroup)
 	messagesWithSettingsByStates := make(map[string][]string)
 	for _, state := range states {
 		if _, ok := translatedStates[state.Name]; ok {
-			return nil, errors.Errorf("duplicate state %s", state.Name)
+			return nil, nil, errors.Errorf("duplicate state %s", state.Name)
 		}
 
 		localDeclaredIdentifiers := declaredIdentifiers.Clone()
@@ -147,11 +455,18 @@ func translateStates(states []*parser.State, declaredIdentifiers mapset.Set) (
 		translatedMessages, settedStatesByMessages, err :=
 			translateMessages(state.Messages, localDeclaredIdentifiers)
 		if err != nil {
-			return nil, errors.Wrapf(err, "unable to translate the state %s", state.Name)
+			return nil, nil, errors.Wrapf(err, "unable to translate the state %s", state.Name)
 		}
 
-		translatedStates[state.Name] =
-			runtime.NewParameterizedMessageGroup(state.Parameters.Identifiers, translatedMessages)
+		translatedEnterHook, translatedExitHook, err :=
+			translateHooks(state, localDeclaredIdentifiers, settedStatesByMessages)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to translate the state %s", state.Name)
+		}
+
+		translatedStates[state.Name] = runtime.
+			NewParameterizedMessageGroup(state.Parameters.Identifiers, translatedMessages).
+			WithHooks(translatedEnterHook, translatedExitHook)
 		for message, settedStates := range settedStatesByMessages {
 			for _, state := range settedStates.ToSlice() {
 				messagesWithSettingsByStates[state.(string)] = append(
@@ -160,19 +475,92 @@ func translateStates(states []*parser.State, declaredIdentifiers mapset.Set) (
 				)
 			}
 		}
+
+		transitions = append(transitions, makeStateTransitions(state, settedStatesByMessages)...)
 	}
 
 	for state, messages := range messagesWithSettingsByStates {
+		if state == runtime.DefaultStateName {
+			return nil, nil, errors.Errorf(
+				"the default state %s can't be set in messages %v",
+				state,
+				messages,
+			)
+		}
 		if _, ok := translatedStates[state]; !ok {
-			return nil, errors.Errorf("unknown state %s in messages %v", state, messages)
+			return nil, nil, errors.Errorf("unknown state %s in messages %v", state, messages)
 		}
 	}
 
-	return translatedStates, nil
+	return translatedStates, transitions, nil
 }
 
 type settedStateGroup map[string]mapset.Set
 
+// the transitions are sorted by the order of messages and the names of the set states
+func makeStateTransitions(
+	state *parser.State,
+	settedStatesByMessages settedStateGroup,
+) []StateTransition {
+	var messages []string
+	for _, message := range state.Messages {
+		messages = append(messages, message.Name)
+	}
+	messages = append(messages, "enter hook")
+
+	var transitions []StateTransition
+	for _, message := range messages {
+		var settedStates []string
+		for _, settedState := range settedStatesByMessages[message].ToSlice() {
+			settedStates = append(settedStates, settedState.(string))
+		}
+		sort.Strings(settedStates)
+
+		for _, settedState := range settedStates {
+			transitions = append(transitions, StateTransition{
+				From:    state.Name,
+				To:      settedState,
+				Message: message,
+			})
+		}
+	}
+
+	return transitions
+}
+
+// the states set by the enter hook are added to the passed group
+func translateHooks(
+	state *parser.State,
+	declaredIdentifiers mapset.Set,
+	settedStatesByMessages settedStateGroup,
+) (
+	translatedEnterHook runtime.CommandGroup,
+	translatedExitHook runtime.CommandGroup,
+	err error,
+) {
+	if state.Name == runtime.DefaultStateName && (len(state.Enter) != 0 || len(state.Exit) != 0) {
+		return nil, nil, errors.Errorf("the default state %s can't have hooks", state.Name)
+	}
+
+	translatedEnterHook, settedStates, err := translateCommands(state.Enter, declaredIdentifiers)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to translate the enter hook")
+	}
+	settedStatesByMessages["enter hook"] = settedStates
+
+	// the exit hook is run before the change of the current state, so setting of a state in it
+	// would start the transition again
+	translatedExitHook, settedStates, err = translateCommands(state.Exit, declaredIdentifiers)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to translate the exit hook")
+	}
+	if settedStates.Cardinality() != 0 {
+		return nil, nil, errors.New("the exit hook can't set states")
+	}
+
+	return translatedEnterHook, translatedExitHook, nil
+}
+
 func translateMessages(messages []*parser.Message, declaredIdentifiers mapset.Set) (
 	translatedMessages runtime.MessageGroup,
 	settedStatesByMessages settedStateGroup,
@@ -186,6 +574,7 @@ func translateMessages(messages []*parser.Message, declaredIdentifiers mapset.Se
 		}
 
 		localDeclaredIdentifiers := declaredIdentifiers.Clone()
+		localDeclaredIdentifiers.Add(runtime.SenderValueName)
 		for _, parameter := range message.Parameters.Identifiers {
 			localDeclaredIdentifiers.Add(parameter)
 		}
@@ -216,10 +605,12 @@ func translateCommands(commands []*parser.Command, declaredIdentifiers mapset.Se
 		translatedCommand, topLevelSettedState2, settedStates2, didReturn, err :=
 			translateCommand(command, localDeclaredIdentifiers)
 		if err != nil {
+			err = positionError(err, command.Pos)
 			return nil, nil, errors.Wrapf(err, "unable to translate the command #%d", index)
 		}
 		if didReturn && index != len(commands)-1 {
-			return nil, nil, errors.Errorf("unreachable commands after the command #%d", index)
+			err := errors.Errorf("unreachable commands after the command #%d", index)
+			return nil, nil, positionError(err, commands[index+1].Pos)
 		}
 
 		translatedCommands = append(translatedCommands, translatedCommand)
@@ -229,11 +620,12 @@ func translateCommands(commands []*parser.Command, declaredIdentifiers mapset.Se
 			continue
 		}
 		if len(topLevelSettedState) != 0 {
-			return nil, nil, errors.Errorf(
+			err := errors.Errorf(
 				"second set command %s (first was %s)",
 				topLevelSettedState2,
 				topLevelSettedState,
 			)
+			return nil, nil, positionError(err, command.Pos)
 		}
 		topLevelSettedState = topLevelSettedState2
 	}
@@ -241,6 +633,27 @@ func translateCommands(commands []*parser.Command, declaredIdentifiers mapset.Se
 	return translatedCommands, settedStates, nil
 }
 
+// TranslateCommand ...
+//
+// It translates the single command outside of actors (e.g. an input of the REPL),
+// so the commands setting states aren't allowed. The declared identifiers
+// are updated by the let command.
+func TranslateCommand(command *parser.Command, declaredIdentifiers mapset.Set) (
+	translatedCommand runtime.Command,
+	err error,
+) {
+	translatedCommand, _, settedStates, _, err := translateCommand(command, declaredIdentifiers)
+	if err != nil {
+		return nil, positionError(err, command.Pos)
+	}
+	if settedStates.Cardinality() != 0 {
+		err := errors.Errorf("states %v are set outside of the actor", settedStates)
+		return nil, positionError(err, command.Pos)
+	}
+
+	return translatedCommand, nil
+}
+
 func translateCommand(command *parser.Command, declaredIdentifiers mapset.Set) (
 	translatedCommand runtime.Command,
 	topLevelSettedState string,
@@ -269,6 +682,12 @@ func translateCommand(command *parser.Command, declaredIdentifiers mapset.Set) (
 		if err != nil {
 			return nil, "", nil, false, errors.Wrap(err, "unable to translate the send command")
 		}
+	case command.Publish != nil:
+		translatedCommand, settedStates, err =
+			translatePublishCommand(command.Publish, declaredIdentifiers)
+		if err != nil {
+			return nil, "", nil, false, errors.Wrap(err, "unable to translate the publish command")
+		}
 	case command.Set != nil:
 		translatedCommand, settedStates, err = translateSetCommand(command.Set, declaredIdentifiers)
 		if err != nil {
@@ -277,6 +696,13 @@ func translateCommand(command *parser.Command, declaredIdentifiers mapset.Set) (
 
 		topLevelSettedState = command.Set.Name
 		settedStates.Add(command.Set.Name)
+	case command.Stop != nil:
+		translatedCommand, settedStates, err = translateStopCommand(command.Stop, declaredIdentifiers)
+		if err != nil {
+			return nil, "", nil, false, errors.Wrap(err, "unable to translate the stop command")
+		}
+	case command.Defer:
+		translatedCommand = commands.DeferCommand{}
 	case command.Return:
 		translatedCommand = commands.ReturnCommand{}
 		didReturn = true
@@ -290,6 +716,7 @@ func translateCommand(command *parser.Command, declaredIdentifiers mapset.Set) (
 		translatedCommand = commands.NewExpressionCommand(expression)
 	}
 
+	translatedCommand = positionCommand(translatedCommand, command.Pos)
 	return translatedCommand, topLevelSettedState, settedStates, didReturn, nil
 }
 
@@ -322,7 +749,12 @@ func translateStartCommand(startCommand *parser.StartCommand, declaredIdentifier
 		return nil, nil, errors.Wrapf(err, "unable to translate arguments for the start command")
 	}
 
-	translatedCommand = commands.NewStartCommand(actorFactory, arguments)
+	translatedStartCommand := commands.NewStartCommand(actorFactory, arguments)
+	if startCommand.ActorName != nil {
+		translatedStartCommand = translatedStartCommand.WithName(*startCommand.ActorName)
+	}
+
+	translatedCommand = translatedStartCommand
 	settedStates = settedStates.Union(settedStates2)
 	return translatedCommand, settedStates, nil
 }
@@ -332,13 +764,77 @@ func translateSendCommand(sendCommand *parser.SendCommand, declaredIdentifiers m
 	settedStates mapset.Set,
 	err error,
 ) {
-	arguments, settedStates, err :=
+	var receiver expressions.Expression
+	settedStates = mapset.NewSet()
+	if sendCommand.Receiver != nil {
+		switch {
+		case sendCommand.Receiver.Name != nil:
+			identifier := *sendCommand.Receiver.Name
+			if !declaredIdentifiers.Contains(identifier) {
+				return nil, nil, errors.Errorf("unknown identifier %s", identifier)
+			}
+
+			receiver = expressions.NewIdentifier(identifier)
+		case sendCommand.Receiver.Expression != nil:
+			receiver, settedStates, err =
+				TranslateExpression(sendCommand.Receiver.Expression, declaredIdentifiers)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "unable to translate the receiver for the send command")
+			}
+		}
+	}
+
+	arguments, settedStates2, err :=
 		translateExpressionGroup(sendCommand.Arguments, declaredIdentifiers)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "unable to translate arguments for the send command")
 	}
 
-	translatedCommand = commands.NewSendCommand(sendCommand.Name, arguments)
+	settedStates = settedStates.Union(settedStates2)
+
+	translatedSendCommand := commands.NewSendCommand(receiver, sendCommand.Name, arguments)
+	if sendCommand.Priority != nil {
+		priority, settedStates3, err := TranslateExpression(sendCommand.Priority, declaredIdentifiers)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to translate the priority for the send command")
+		}
+
+		translatedSendCommand = translatedSendCommand.WithPriority(priority)
+		settedStates = settedStates.Union(settedStates3)
+	}
+
+	return translatedSendCommand, settedStates, nil
+}
+
+func translatePublishCommand(
+	publishCommand *parser.PublishCommand,
+	declaredIdentifiers mapset.Set,
+) (
+	translatedCommand runtime.Command,
+	settedStates mapset.Set,
+	err error,
+) {
+	var topic expressions.Expression
+	switch {
+	case publishCommand.Topic.Name != nil:
+		topic = expressions.NewString(*publishCommand.Topic.Name)
+		settedStates = mapset.NewSet()
+	case publishCommand.Topic.Expression != nil:
+		topic, settedStates, err =
+			TranslateExpression(publishCommand.Topic.Expression, declaredIdentifiers)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to translate the topic for the publish command")
+		}
+	}
+
+	arguments, settedStates2, err :=
+		translateExpressionGroup(publishCommand.Arguments, declaredIdentifiers)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "unable to translate arguments for the publish command")
+	}
+
+	translatedCommand = commands.NewPublishCommand(topic, publishCommand.Name, arguments)
+	settedStates = settedStates.Union(settedStates2)
 	return translatedCommand, settedStates, nil
 }
 
@@ -355,3 +851,21 @@ func translateSetCommand(setCommand *parser.SetCommand, declaredIdentifiers maps
 	translatedCommand = commands.NewSetCommand(setCommand.Name, arguments)
 	return translatedCommand, settedStates, nil
 }
+
+func translateStopCommand(stopCommand *parser.StopCommand, declaredIdentifiers mapset.Set) (
+	translatedCommand runtime.Command,
+	settedStates mapset.Set,
+	err error,
+) {
+	var actor expressions.Expression
+	settedStates = mapset.NewSet()
+	if stopCommand.Actor != nil {
+		actor, settedStates, err = TranslateExpression(stopCommand.Actor, declaredIdentifiers)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "unable to translate the actor for the stop command")
+		}
+	}
+
+	translatedCommand = commands.NewStopCommand(actor)
+	return translatedCommand, settedStates, nil
+}
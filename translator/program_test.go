@@ -2,6 +2,7 @@ package translator
 
 import (
 	"testing"
+	"testing/iotest"
 
 	mapset "github.com/deckarep/golang-set"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,7 @@ import (
 	"github.com/thewizardplusplus/tick-tock/runtime/commands"
 	"github.com/thewizardplusplus/tick-tock/runtime/context"
 	"github.com/thewizardplusplus/tick-tock/runtime/expressions"
+	"github.com/thewizardplusplus/tick-tock/runtime/types"
 )
 
 func TestTranslateProgram(test *testing.T) {
@@ -417,12 +419,170 @@ func TestTranslateProgram(test *testing.T) {
 			wantTranslatedActors: nil,
 			wantErr:              assert.Error,
 		},
+		{
+			name: "success with functions",
+			args: args{
+				code: `
+					func double(x) x + x;
+					actor Test0()
+						state state_0()
+							message message_0()
+								double(2)
+							;
+						;
+					;
+				`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDefinitions: context.ValueGroup{
+				"double": runtime.NewFunction([]string{"x"}, runtime.CommandGroup{
+					commands.NewExpressionCommand(
+						expressions.NewFunctionCall(AdditionFunctionName, []expressions.Expression{
+							expressions.NewIdentifier("x"),
+							expressions.NewIdentifier("x"),
+						}),
+					),
+				}),
+				"Test0": func() runtime.ConcurrentActorFactory {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test0",
+						runtime.ParameterizedStateGroup{
+							StateGroup: runtime.StateGroup{
+								"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{
+									"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
+										commands.NewExpressionCommand(
+											expressions.NewFunctionCall("double", []expressions.Expression{
+												expressions.NewNumber(2),
+											}),
+										),
+									}),
+								}),
+							},
+						},
+						context.State{Name: "state_0"},
+					)
+					return runtime.NewConcurrentActorFactory(actorFactory, 23, runtime.Dependencies{
+						WaitGroup:    new(MockWaiter),
+						ErrorHandler: new(MockErrorHandler),
+					})
+				}(),
+			},
+			wantTranslatedActors: []runtime.ConcurrentActorFactory{
+				func() runtime.ConcurrentActorFactory {
+					actorFactory, _ := runtime.NewActorFactory(
+						"Test0",
+						runtime.ParameterizedStateGroup{
+							StateGroup: runtime.StateGroup{
+								"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{
+									"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
+										commands.NewExpressionCommand(
+											expressions.NewFunctionCall("double", []expressions.Expression{
+												expressions.NewNumber(2),
+											}),
+										),
+									}),
+								}),
+							},
+						},
+						context.State{Name: "state_0"},
+					)
+					return runtime.NewConcurrentActorFactory(actorFactory, 23, runtime.Dependencies{
+						WaitGroup:    new(MockWaiter),
+						ErrorHandler: new(MockErrorHandler),
+					})
+				}(),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with duplicate definitions of the function",
+			args: args{
+				code: `
+					func Test0();
+					class Test0()
+						state state_0();
+					;
+				`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDefinitions:      nil,
+			wantTranslatedActors: nil,
+			wantErr:              assert.Error,
+		},
+		{
+			name: "error with an unknown class in the failure policies",
+			args: args{
+				code:                "class Test0() state state_0(); ;",
+				declaredIdentifiers: mapset.NewSet("test"),
+				options: Options{
+					InboxSize:    23,
+					InitialState: context.State{Name: "state_0"},
+					FailurePolicies: runtime.FailurePolicyGroup{
+						ByClass: map[string]runtime.FailurePolicy{"Unknown": runtime.StopActorPolicy},
+					},
+				},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDefinitions:      nil,
+			wantTranslatedActors: nil,
+			wantErr:              assert.Error,
+		},
+		{
+			name: "error with an unknown class in the inbox policies",
+			args: args{
+				code:                "class Test0() state state_0(); ;",
+				declaredIdentifiers: mapset.NewSet("test"),
+				options: Options{
+					InboxSize:    23,
+					InitialState: context.State{Name: "state_0"},
+					InboxPolicies: runtime.InboxPolicyGroup{
+						ByClass: map[string]runtime.InboxPolicy{"Unknown": runtime.BlockInboxPolicy},
+					},
+				},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDefinitions:      nil,
+			wantTranslatedActors: nil,
+			wantErr:              assert.Error,
+		},
+		{
+			name: "error with the import without the importer",
+			args: args{
+				code:                `import "lib.tt"`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDefinitions:      nil,
+			wantTranslatedActors: nil,
+			wantErr:              assert.Error,
+		},
 	} {
 		test.Run(testData.name, func(test *testing.T) {
 			originDeclaredIdentifiers := testData.args.declaredIdentifiers.Clone()
 
 			program := new(parser.Program)
 			err := parser.ParseToAST(testData.args.code, program)
+			parser.ClearPositions(program)
 			require.NoError(test, err)
 
 			gotDefinitions, gotTranslatedActors, err := TranslateProgram(
@@ -445,6 +605,278 @@ func TestTranslateProgram(test *testing.T) {
 	}
 }
 
+func TestTranslateProgram_withImports(test *testing.T) {
+	type args struct {
+		code     string
+		filename string
+		modules  map[string]string
+	}
+
+	for _, testData := range []struct {
+		name                string
+		args                args
+		wantDefinitionNames []string
+		wantNamespaceNames  map[string][]string
+		wantActorNames      []string
+		wantImportCounts    map[string]int
+		wantErr             assert.ErrorAssertionFunc
+		wantErrSubstrings   []string
+	}{
+		{
+			name: "success without a namespace",
+			args: args{
+				code: `
+					import "lib.tt"
+					actor Main()
+						state state_0()
+							message test()
+								start Fork()
+								log("test")
+							;
+						;
+					;
+				`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"lib.tt": `
+						func log(text) text;
+						class Fork()
+							state state_0();
+						;
+						actor Ticker()
+							state state_0();
+						;
+					`,
+				},
+			},
+			wantDefinitionNames: []string{"Fork", "Main", "Ticker", "log"},
+			wantActorNames:      []string{"Ticker", "Main"},
+			wantImportCounts:    map[string]int{"lib.tt": 1},
+			wantErr:             assert.NoError,
+		},
+		{
+			name: "success with a namespace",
+			args: args{
+				code: `
+					import "lib.tt" as lib
+					actor Main()
+						state state_0()
+							message test()
+								start [lib.Fork]()
+							;
+						;
+					;
+				`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"lib.tt": `
+						func log(text) text;
+						class Fork()
+							state state_0()
+								message test()
+									log("test")
+								;
+							;
+						;
+					`,
+				},
+			},
+			wantDefinitionNames: []string{"Main", "lib"},
+			wantNamespaceNames:  map[string][]string{"lib": {"Fork", "log"}},
+			wantActorNames:      []string{"Main"},
+			wantImportCounts:    map[string]int{"lib.tt": 1},
+			wantErr:             assert.NoError,
+		},
+		{
+			name: "success with the same module imported via different files",
+			args: args{
+				code: `
+					import "one.tt"
+					import "two.tt"
+				`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"one.tt": `
+						import "common.tt"
+						func one() common();
+					`,
+					"two.tt": `
+						import "common.tt"
+						func two() common();
+					`,
+					"common.tt": `
+						func common() 23;
+						actor Ticker()
+							state state_0();
+						;
+					`,
+				},
+			},
+			wantDefinitionNames: []string{"Ticker", "common", "one", "two"},
+			wantActorNames:      []string{"Ticker"},
+			wantImportCounts:    map[string]int{"one.tt": 1, "two.tt": 1, "common.tt": 2},
+			wantErr:             assert.NoError,
+		},
+		{
+			name: "error with the namespaced definition used without the namespace",
+			args: args{
+				code: `
+					import "lib.tt" as lib
+					func test() log("test");
+				`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"lib.tt": "func log(text) text;",
+				},
+			},
+			wantImportCounts:  map[string]int{"lib.tt": 1},
+			wantErr:           assert.Error,
+			wantErrSubstrings: []string{"unknown function log"},
+		},
+		{
+			name: "error with the import cycle",
+			args: args{
+				code:     `import "one.tt"`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"one.tt": `import "two.tt"`,
+					"two.tt": `import "one.tt"`,
+				},
+			},
+			wantImportCounts:  map[string]int{"one.tt": 2, "two.tt": 1},
+			wantErr:           assert.Error,
+			wantErrSubstrings: []string{"import cycle one.tt -> two.tt -> one.tt"},
+		},
+		{
+			name: "error with the duplicate definition in different files",
+			args: args{
+				code: `
+					import "lib.tt"
+					func log(text) text;
+				`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"lib.tt": "func log(text) text;",
+				},
+			},
+			wantImportCounts: map[string]int{"lib.tt": 1},
+			wantErr:          assert.Error,
+			wantErrSubstrings: []string{
+				"duplicate definition log in the file main.tt (previously defined in the file lib.tt)",
+			},
+		},
+		{
+			name: "error with the duplicate definition in imported files",
+			args: args{
+				code: `
+					import "one.tt"
+					import "two.tt"
+				`,
+				filename: "",
+				modules: map[string]string{
+					"one.tt": "func log(text) text;",
+					"two.tt": "func log(text) text;",
+				},
+			},
+			wantImportCounts: map[string]int{"one.tt": 1, "two.tt": 1},
+			wantErr:          assert.Error,
+			wantErrSubstrings: []string{
+				"duplicate definition log in the file two.tt (previously defined in the file one.tt)",
+			},
+		},
+		{
+			name: "error with the translation of the imported file",
+			args: args{
+				code:     `import "lib.tt"`,
+				filename: "main.tt",
+				modules: map[string]string{
+					"lib.tt": "func log() unknown();",
+				},
+			},
+			wantImportCounts:  map[string]int{"lib.tt": 1},
+			wantErr:           assert.Error,
+			wantErrSubstrings: []string{"unable to translate the file lib.tt"},
+		},
+		{
+			name: "error with the importer",
+			args: args{
+				code:     `import "lib.tt"`,
+				filename: "main.tt",
+				modules:  map[string]string{},
+			},
+			wantImportCounts:  map[string]int{"lib.tt": 1},
+			wantErr:           assert.Error,
+			wantErrSubstrings: []string{"unable to import the file lib.tt"},
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			importer := new(MockImporter)
+			for path, count := range testData.wantImportCounts {
+				if code, ok := testData.args.modules[path]; ok {
+					program := new(parser.Program)
+					err := parser.ParseToAST(code, program)
+					parser.ClearPositions(program)
+					require.NoError(test, err)
+
+					importer.On("Import", path, mock.AnythingOfType("string")).Return(path, program, nil).Times(count)
+				} else {
+					importer.On("Import", path, mock.AnythingOfType("string")).Return("", nil, iotest.ErrTimeout).Times(count)
+				}
+			}
+
+			program := new(parser.Program)
+			err := parser.ParseToAST(testData.args.code, program)
+			parser.ClearPositions(program)
+			require.NoError(test, err)
+
+			dependencies := runtime.Dependencies{
+				WaitGroup:    new(MockWaiter),
+				ErrorHandler: new(MockErrorHandler),
+			}
+			gotDefinitions, gotTranslatedActors, err := TranslateProgram(
+				program,
+				mapset.NewSet(),
+				Options{
+					InboxSize:    23,
+					InitialState: context.State{Name: "state_0"},
+					Filename:     testData.args.filename,
+					Importer:     importer,
+				},
+				dependencies,
+			)
+
+			mock.AssertExpectationsForObjects(test, importer, dependencies.WaitGroup, dependencies.ErrorHandler)
+
+			var gotDefinitionNames []string
+			for name := range gotDefinitions {
+				gotDefinitionNames = append(gotDefinitionNames, name)
+			}
+			assert.ElementsMatch(test, testData.wantDefinitionNames, gotDefinitionNames)
+
+			for namespace, wantNames := range testData.wantNamespaceNames {
+				require.IsType(test, types.HashTable{}, gotDefinitions[namespace])
+
+				var gotNames []string
+				for name := range gotDefinitions[namespace].(types.HashTable) {
+					gotNames = append(gotNames, name.(string))
+				}
+				assert.ElementsMatch(test, wantNames, gotNames)
+			}
+
+			var gotActorNames []string
+			for _, actor := range gotTranslatedActors {
+				gotActorNames = append(gotActorNames, actor.Name())
+			}
+			assert.Equal(test, testData.wantActorNames, gotActorNames)
+
+			testData.wantErr(test, err)
+			for _, wantErrSubstring := range testData.wantErrSubstrings {
+				assert.Contains(test, err.Error(), wantErrSubstring)
+			}
+		})
+	}
+}
+
 func TestTranslateDefinition(test *testing.T) {
 	type args struct {
 		code                string
@@ -457,7 +889,8 @@ func TestTranslateDefinition(test *testing.T) {
 		name                     string
 		args                     args
 		wantDeclaredIdentifiers  mapset.Set
-		wantTranslatedActorClass runtime.ConcurrentActorFactory
+		wantDefinitionName       string
+		wantTranslatedDefinition interface{}
 		wantActor                assert.BoolAssertionFunc
 		wantErr                  assert.ErrorAssertionFunc
 	}{
@@ -476,7 +909,8 @@ func TestTranslateDefinition(test *testing.T) {
 				},
 			},
 			wantDeclaredIdentifiers: mapset.NewSet("test", "Test"),
-			wantTranslatedActorClass: func() runtime.ConcurrentActorFactory {
+			wantDefinitionName:      "Test",
+			wantTranslatedDefinition: func() runtime.ConcurrentActorFactory {
 				actorFactory, _ := runtime.NewActorFactory(
 					"Test",
 					runtime.ParameterizedStateGroup{
@@ -510,7 +944,8 @@ func TestTranslateDefinition(test *testing.T) {
 				},
 			},
 			wantDeclaredIdentifiers:  mapset.NewSet("test"),
-			wantTranslatedActorClass: runtime.ConcurrentActorFactory{},
+			wantDefinitionName:       "",
+			wantTranslatedDefinition: nil,
 			wantActor:                assert.False,
 			wantErr:                  assert.Error,
 		},
@@ -529,7 +964,8 @@ func TestTranslateDefinition(test *testing.T) {
 				},
 			},
 			wantDeclaredIdentifiers: mapset.NewSet("test", "Test"),
-			wantTranslatedActorClass: func() runtime.ConcurrentActorFactory {
+			wantDefinitionName:      "Test",
+			wantTranslatedDefinition: func() runtime.ConcurrentActorFactory {
 				actorFactory, _ := runtime.NewActorFactory(
 					"Test",
 					runtime.ParameterizedStateGroup{
@@ -563,7 +999,64 @@ func TestTranslateDefinition(test *testing.T) {
 				},
 			},
 			wantDeclaredIdentifiers:  mapset.NewSet("test"),
-			wantTranslatedActorClass: runtime.ConcurrentActorFactory{},
+			wantDefinitionName:       "",
+			wantTranslatedDefinition: nil,
+			wantActor:                assert.False,
+			wantErr:                  assert.Error,
+		},
+		{
+			name: "Definition/function/success",
+			args: args{
+				code:                "func test2(one, two) test one two test2;",
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test", "test2"),
+			wantDefinitionName:      "test2",
+			wantTranslatedDefinition: runtime.NewFunction([]string{"one", "two"}, runtime.CommandGroup{
+				commands.NewExpressionCommand(expressions.NewIdentifier("test")),
+				commands.NewExpressionCommand(expressions.NewIdentifier("one")),
+				commands.NewExpressionCommand(expressions.NewIdentifier("two")),
+				commands.NewExpressionCommand(expressions.NewIdentifier("test2")),
+			}),
+			wantActor: assert.False,
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "Definition/function/error/commands translation",
+			args: args{
+				code:                "func test2() unknown;",
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDeclaredIdentifiers:  mapset.NewSet("test"),
+			wantDefinitionName:       "",
+			wantTranslatedDefinition: nil,
+			wantActor:                assert.False,
+			wantErr:                  assert.Error,
+		},
+		{
+			name: "Definition/function/error/set command",
+			args: args{
+				code:                "func test2() set state_0();",
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantDeclaredIdentifiers:  mapset.NewSet("test"),
+			wantDefinitionName:       "",
+			wantTranslatedDefinition: nil,
 			wantActor:                assert.False,
 			wantErr:                  assert.Error,
 		},
@@ -571,9 +1064,10 @@ func TestTranslateDefinition(test *testing.T) {
 		test.Run(testData.name, func(test *testing.T) {
 			definition := new(parser.Definition)
 			err := parser.ParseToAST(testData.args.code, definition)
+			parser.ClearPositions(definition)
 			require.NoError(test, err)
 
-			gotTranslatedActorClass, gotActor, err := translateDefinition(
+			gotDefinitionName, gotTranslatedDefinition, gotActor, _, err := translateDefinition(
 				definition,
 				testData.args.declaredIdentifiers,
 				testData.args.options,
@@ -586,7 +1080,8 @@ func TestTranslateDefinition(test *testing.T) {
 				testData.args.dependencies.ErrorHandler,
 			)
 			assert.Equal(test, testData.wantDeclaredIdentifiers, testData.args.declaredIdentifiers)
-			assert.Equal(test, testData.wantTranslatedActorClass, gotTranslatedActorClass)
+			assert.Equal(test, testData.wantDefinitionName, gotDefinitionName)
+			assert.Equal(test, testData.wantTranslatedDefinition, gotTranslatedDefinition)
 			testData.wantActor(test, gotActor)
 			testData.wantErr(test, err)
 		})
@@ -639,6 +1134,120 @@ func TestTranslateActorClass(test *testing.T) {
 			}(),
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with failure policies",
+			args: args{
+				code: `class Test()
+					state state_0();
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options: Options{
+					InboxSize:    23,
+					InitialState: context.State{Name: "state_0"},
+					FailurePolicies: runtime.FailurePolicyGroup{
+						Default: runtime.StopActorPolicy,
+						ByClass: map[string]runtime.FailurePolicy{"Test": runtime.RestartActorPolicy},
+					},
+				},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantTranslatedActorClass: func() runtime.ConcurrentActorFactory {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{
+						StateGroup: runtime.StateGroup{
+							"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{}),
+						},
+					},
+					context.State{Name: "state_0"},
+				)
+				dependencies := runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				}
+				return runtime.NewConcurrentActorFactory(actorFactory, 23, dependencies).
+					WithFailurePolicy(runtime.RestartActorPolicy)
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with inbox policies",
+			args: args{
+				code: `class Test()
+					state state_0();
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options: Options{
+					InboxSize: 23,
+					InboxPolicies: runtime.InboxPolicyGroup{
+						Default: runtime.BlockInboxPolicy,
+						ByClass: map[string]runtime.InboxPolicy{"Test": runtime.DropOldestInboxPolicy},
+					},
+					InitialState: context.State{Name: "state_0"},
+				},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantTranslatedActorClass: func() runtime.ConcurrentActorFactory {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{
+						StateGroup: runtime.StateGroup{
+							"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{}),
+						},
+					},
+					context.State{Name: "state_0"},
+				)
+				dependencies := runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				}
+				return runtime.NewConcurrentActorFactory(actorFactory, 23, dependencies).
+					WithInboxPolicy(runtime.DropOldestInboxPolicy)
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the dead letter mode",
+			args: args{
+				code: `class Test()
+					state state_0();
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options: Options{
+					InboxSize:    23,
+					InitialState: context.State{Name: "state_0"},
+					DeadLetters:  runtime.WarnOnDeadLetters,
+				},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantTranslatedActorClass: func() runtime.ConcurrentActorFactory {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{
+						StateGroup: runtime.StateGroup{
+							"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{}),
+						},
+					},
+					context.State{Name: "state_0"},
+				)
+				dependencies := runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				}
+				return runtime.NewConcurrentActorFactory(actorFactory, 23, dependencies).
+					WithDeadLetterMode(runtime.WarnOnDeadLetters)
+			}(),
+			wantErr: assert.NoError,
+		},
 		{
 			name: "success with the expression",
 			args: args{
@@ -677,6 +1286,44 @@ func TestTranslateActorClass(test *testing.T) {
 			}(),
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with the self reference",
+			args: args{
+				code: `class Test()
+					state state_0()
+						message message_0()
+							self
+						;
+					;
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+				options:             Options{InboxSize: 23, InitialState: context.State{Name: "state_0"}},
+				dependencies: runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				},
+			},
+			wantTranslatedActorClass: func() runtime.ConcurrentActorFactory {
+				actorFactory, _ := runtime.NewActorFactory(
+					"Test",
+					runtime.ParameterizedStateGroup{
+						StateGroup: runtime.StateGroup{
+							"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{
+								"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
+									commands.NewExpressionCommand(expressions.NewIdentifier("self")),
+								}),
+							}),
+						},
+					},
+					context.State{Name: "state_0"},
+				)
+				return runtime.NewConcurrentActorFactory(actorFactory, 23, runtime.Dependencies{
+					WaitGroup:    new(MockWaiter),
+					ErrorHandler: new(MockErrorHandler),
+				})
+			}(),
+			wantErr: assert.NoError,
+		},
 		{
 			name: "success with parameters",
 			args: args{
@@ -775,9 +1422,10 @@ func TestTranslateActorClass(test *testing.T) {
 
 			actorClass := new(parser.ActorClass)
 			err := parser.ParseToAST(testData.args.code, actorClass)
+			parser.ClearPositions(actorClass)
 			require.NoError(test, err)
 
-			gotTranslatedActorClass, err := translateActorClass(
+			gotTranslatedActorClass, _, err := translateActorClass(
 				actorClass,
 				testData.args.declaredIdentifiers,
 				testData.args.options,
@@ -890,6 +1538,70 @@ func TestTranslateStates(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with the default state and the wildcard handler",
+			args: args{
+				code: `
+					state state_0()
+						message _(name, args)
+							name
+							args
+						;
+					;
+					state _()
+						message message_0()
+							set state_0()
+						;
+					;
+				`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: runtime.StateGroup{
+				"state_0": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{
+					"_": runtime.NewParameterizedCommandGroup(
+						[]string{"name", "args"},
+						runtime.CommandGroup{
+							commands.NewExpressionCommand(expressions.NewIdentifier("name")),
+							commands.NewExpressionCommand(expressions.NewIdentifier("args")),
+						},
+					),
+				}),
+				"_": runtime.NewParameterizedMessageGroup(nil, runtime.MessageGroup{
+					"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
+						commands.NewSetCommand("state_0", nil),
+					}),
+				}),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with hooks",
+			args: args{
+				code: `state state_0(one)
+					enter
+						one
+					;
+					exit
+						test
+					;
+					message message_0();
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: runtime.StateGroup{
+				"state_0": runtime.
+					NewParameterizedMessageGroup([]string{"one"}, runtime.MessageGroup{"message_0": {}}).
+					WithHooks(
+						runtime.CommandGroup{
+							commands.NewExpressionCommand(expressions.NewIdentifier("one")),
+						},
+						runtime.CommandGroup{
+							commands.NewExpressionCommand(expressions.NewIdentifier("test")),
+						},
+					),
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "error without states",
 			args: args{
@@ -927,18 +1639,92 @@ func TestTranslateStates(test *testing.T) {
 			wantErr:    assert.Error,
 		},
 		{
-			name: "error with an unknown state",
+			name: "error with an unknown state",
+			args: args{
+				code: `state state_0()
+					message message_0()
+						send command_0()
+						set state_unknown()
+					;
+					message message_1()
+						send command_2()
+						set state_unknown()
+					;
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with the hook translation",
+			args: args{
+				code: `state state_0()
+					enter
+						unknown
+					;
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with an unknown state in the hook",
+			args: args{
+				code: `state state_0()
+					enter
+						set state_unknown()
+					;
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with setting of a state in the exit hook",
+			args: args{
+				code: `
+					state state_0()
+						exit
+							set state_1()
+						;
+					;
+					state state_1();
+				`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with hooks in the default state",
+			args: args{
+				code: `
+					state state_0();
+					state _()
+						enter
+							test
+						;
+					;
+				`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantStates: nil,
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with setting of the default state",
 			args: args{
-				code: `state state_0()
-					message message_0()
-						send command_0()
-						set state_unknown()
-					;
-					message message_1()
-						send command_2()
-						set state_unknown()
+				code: `
+					state state_0()
+						message message_0()
+							set _()
+						;
 					;
-				;`,
+					state _();
+				`,
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
 			wantStates: nil,
@@ -963,9 +1749,10 @@ func TestTranslateStates(test *testing.T) {
 
 			statesWrapper := new(statesWrapper)
 			err := parser.ParseToAST(testData.args.code, statesWrapper)
+			parser.ClearPositions(statesWrapper)
 			require.NoError(test, err)
 
-			gotStates, err := translateStates(statesWrapper.States, testData.args.declaredIdentifiers)
+			gotStates, _, err := translateStates(statesWrapper.States, testData.args.declaredIdentifiers)
 
 			assert.Equal(test, originDeclaredIdentifiers, testData.args.declaredIdentifiers)
 			assert.Equal(test, testData.wantStates, gotStates)
@@ -1007,12 +1794,12 @@ func TestTranslateMessages(test *testing.T) {
 			},
 			wantMessages: runtime.MessageGroup{
 				"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
-					commands.NewSendCommand("command_0", nil),
-					commands.NewSendCommand("command_1", nil),
+					commands.NewSendCommand(nil, "command_0", nil),
+					commands.NewSendCommand(nil, "command_1", nil),
 				}),
 				"message_1": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
-					commands.NewSendCommand("command_2", nil),
-					commands.NewSendCommand("command_3", nil),
+					commands.NewSendCommand(nil, "command_2", nil),
+					commands.NewSendCommand(nil, "command_3", nil),
 				}),
 			},
 			wantSettedStatesByMessages: settedStateGroup{
@@ -1038,11 +1825,11 @@ func TestTranslateMessages(test *testing.T) {
 			},
 			wantMessages: runtime.MessageGroup{
 				"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
-					commands.NewSendCommand("command_0", nil),
+					commands.NewSendCommand(nil, "command_0", nil),
 					commands.NewSetCommand("command_1", nil),
 				}),
 				"message_1": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
-					commands.NewSendCommand("command_2", nil),
+					commands.NewSendCommand(nil, "command_2", nil),
 					commands.NewSetCommand("command_3", nil),
 				}),
 			},
@@ -1069,11 +1856,11 @@ func TestTranslateMessages(test *testing.T) {
 			},
 			wantMessages: runtime.MessageGroup{
 				"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
-					commands.NewSendCommand("command_1", nil),
+					commands.NewSendCommand(nil, "command_1", nil),
 					commands.NewSetCommand("command_0", nil),
 				}),
 				"message_1": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
-					commands.NewSendCommand("command_2", nil),
+					commands.NewSendCommand(nil, "command_2", nil),
 					commands.NewSetCommand("command_0", nil),
 				}),
 			},
@@ -1138,6 +1925,20 @@ func TestTranslateMessages(test *testing.T) {
 			wantSettedStatesByMessages: settedStateGroup{"message_0": mapset.NewSet()},
 			wantErr:                    assert.NoError,
 		},
+		{
+			name: "success with the sender",
+			args: args{
+				code:                "message message_0() sender;",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantMessages: runtime.MessageGroup{
+				"message_0": runtime.NewParameterizedCommandGroup(nil, runtime.CommandGroup{
+					commands.NewExpressionCommand(expressions.NewIdentifier("sender")),
+				}),
+			},
+			wantSettedStatesByMessages: settedStateGroup{"message_0": mapset.NewSet()},
+			wantErr:                    assert.NoError,
+		},
 		{
 			name: "error with duplicate messages",
 			args: args{
@@ -1185,6 +1986,7 @@ func TestTranslateMessages(test *testing.T) {
 
 			messagesWrapper := new(messagesWrapper)
 			err := parser.ParseToAST(testData.args.code, messagesWrapper)
+			parser.ClearPositions(messagesWrapper)
 			require.NoError(test, err)
 
 			gotMessages, gotSettedStatesByMessages, err :=
@@ -1221,8 +2023,8 @@ func TestTranslateCommands(test *testing.T) {
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
 			wantCommands: runtime.CommandGroup{
-				commands.NewSendCommand("one", nil),
-				commands.NewSendCommand("two", nil),
+				commands.NewSendCommand(nil, "one", nil),
+				commands.NewSendCommand(nil, "two", nil),
 			},
 			wantSettedStates: mapset.NewSet(),
 			wantErr:          assert.NoError,
@@ -1234,7 +2036,7 @@ func TestTranslateCommands(test *testing.T) {
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
 			wantCommands: runtime.CommandGroup{
-				commands.NewSendCommand("one", nil),
+				commands.NewSendCommand(nil, "one", nil),
 				commands.NewSetCommand("two", nil),
 			},
 			wantSettedStates: mapset.NewSet("two"),
@@ -1247,8 +2049,8 @@ func TestTranslateCommands(test *testing.T) {
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
 			wantCommands: runtime.CommandGroup{
-				commands.NewSendCommand("one", nil),
-				commands.NewSendCommand("two", nil),
+				commands.NewSendCommand(nil, "one", nil),
+				commands.NewSendCommand(nil, "two", nil),
 				commands.ReturnCommand{},
 			},
 			wantSettedStates: mapset.NewSet(),
@@ -1368,6 +2170,7 @@ func TestTranslateCommands(test *testing.T) {
 
 			commandsWrapper := new(commandsWrapper)
 			err := parser.ParseToAST(testData.args.code, commandsWrapper)
+			parser.ClearPositions(commandsWrapper)
 			require.NoError(test, err)
 
 			gotCommands, gotSettedStates, err :=
@@ -1478,6 +2281,20 @@ func TestTranslateCommand(test *testing.T) {
 			wantReturn:              assert.False,
 			wantErr:                 assert.NoError,
 		},
+		{
+			name: "Command/start/success/with the actor name",
+			args: args{
+				code:                "start test() as name",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand: commands.NewStartCommand(expressions.NewIdentifier("test"), nil).
+				WithName("name"),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
 		{
 			name: "Command/start/success/with setted states",
 			args: args{
@@ -1491,29 +2308,285 @@ func TestTranslateCommand(test *testing.T) {
 				]()`,
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
-			wantDeclaredIdentifiers: mapset.NewSet("test"),
-			wantCommand: commands.NewStartCommand(
-				expressions.NewConditionalExpression([]expressions.ConditionalCase{
-					{
-						Condition: expressions.NewNumber(23),
-						Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
-					},
-					{
-						Condition: expressions.NewNumber(42),
-						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
-					},
-				}),
-				nil,
-			),
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand: commands.NewStartCommand(
+				expressions.NewConditionalExpression([]expressions.ConditionalCase{
+					{
+						Condition: expressions.NewNumber(23),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
+					},
+					{
+						Condition: expressions.NewNumber(42),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+					},
+				}),
+				nil,
+			),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet("one", "two"),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/start/error",
+			args: args{
+				code:                "start unknown()",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand:             nil,
+			wantTopLevelSettedState: "",
+			wantSettedStates:        nil,
+			wantReturn:              assert.False,
+			wantErr:                 assert.Error,
+		},
+		{
+			name: "Command/send/success",
+			args: args{
+				code:                "send test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand: commands.NewSendCommand(nil, "test", []expressions.Expression{
+				expressions.NewNumber(12),
+				expressions.NewNumber(23),
+				expressions.NewNumber(42),
+			}),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/success/with setted states",
+			args: args{
+				code: `send test(
+					when
+						=> 23
+							set one()
+						=> 42
+							set two()
+					;,
+					when
+						=> 24
+							set two()
+						=> 43
+							set three()
+					;,
+				)`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand: commands.NewSendCommand(nil, "test", []expressions.Expression{
+				expressions.NewConditionalExpression([]expressions.ConditionalCase{
+					{
+						Condition: expressions.NewNumber(23),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
+					},
+					{
+						Condition: expressions.NewNumber(42),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+					},
+				}),
+				expressions.NewConditionalExpression([]expressions.ConditionalCase{
+					{
+						Condition: expressions.NewNumber(24),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+					},
+					{
+						Condition: expressions.NewNumber(43),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("three", nil)},
+					},
+				}),
+			}),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet("one", "two", "three"),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/success/with the receiver/identifier",
+			args: args{
+				code:                "send receiver.test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet("receiver"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("receiver"),
+			wantCommand: commands.NewSendCommand(
+				expressions.NewIdentifier("receiver"),
+				"test",
+				[]expressions.Expression{
+					expressions.NewNumber(12),
+					expressions.NewNumber(23),
+					expressions.NewNumber(42),
+				},
+			),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/success/with the receiver/expression",
+			args: args{
+				code:                "send [receivers[0]].test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet("receivers"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("receivers"),
+			wantCommand: commands.NewSendCommand(
+				expressions.NewFunctionCall(KeyAccessorFunctionName, []expressions.Expression{
+					expressions.NewIdentifier("receivers"),
+					expressions.NewNumber(0),
+				}),
+				"test",
+				[]expressions.Expression{
+					expressions.NewNumber(12),
+					expressions.NewNumber(23),
+					expressions.NewNumber(42),
+				},
+			),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/success/with the receiver/setted states",
+			args: args{
+				code: `send [
+					when
+						=> 23
+							set one()
+					;
+				].test(
+					when
+						=> 24
+							set two()
+					;,
+				)`,
+				declaredIdentifiers: mapset.NewSet(),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand: commands.NewSendCommand(
+				expressions.NewConditionalExpression([]expressions.ConditionalCase{
+					{
+						Condition: expressions.NewNumber(23),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
+					},
+				}),
+				"test",
+				[]expressions.Expression{
+					expressions.NewConditionalExpression([]expressions.ConditionalCase{
+						{
+							Condition: expressions.NewNumber(24),
+							Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+						},
+					}),
+				},
+			),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet("one", "two"),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/success/with the priority",
+			args: args{
+				code:                "send (priority) receiver.test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet("priority", "receiver"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("priority", "receiver"),
+			wantCommand: commands.NewSendCommand(
+				expressions.NewIdentifier("receiver"),
+				"test",
+				[]expressions.Expression{
+					expressions.NewNumber(12),
+					expressions.NewNumber(23),
+					expressions.NewNumber(42),
+				},
+			).WithPriority(expressions.NewIdentifier("priority")),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/success/with the priority/setted states",
+			args: args{
+				code: `send (
+					when
+						=> 23
+							set one()
+					;
+				) test(
+					when
+						=> 24
+							set two()
+					;,
+				)`,
+				declaredIdentifiers: mapset.NewSet(),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand: commands.NewSendCommand(nil, "test", []expressions.Expression{
+				expressions.NewConditionalExpression([]expressions.ConditionalCase{
+					{
+						Condition: expressions.NewNumber(24),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+					},
+				}),
+			}).WithPriority(expressions.NewConditionalExpression([]expressions.ConditionalCase{
+				{
+					Condition: expressions.NewNumber(23),
+					Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
+				},
+			})),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet("one", "two"),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/send/error/unknown receiver",
+			args: args{
+				code:                "send unknown.test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet(),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand:             nil,
+			wantTopLevelSettedState: "",
+			wantSettedStates:        nil,
+			wantReturn:              assert.False,
+			wantErr:                 assert.Error,
+		},
+		{
+			name: "Command/send/error/receiver translation",
+			args: args{
+				code:                "send [unknown].test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet(),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand:             nil,
+			wantTopLevelSettedState: "",
+			wantSettedStates:        nil,
+			wantReturn:              assert.False,
+			wantErr:                 assert.Error,
+		},
+		{
+			name: "Command/send/error/priority translation",
+			args: args{
+				code:                "send (unknown) test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet(),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand:             nil,
 			wantTopLevelSettedState: "",
-			wantSettedStates:        mapset.NewSet("one", "two"),
+			wantSettedStates:        nil,
 			wantReturn:              assert.False,
-			wantErr:                 assert.NoError,
+			wantErr:                 assert.Error,
 		},
 		{
-			name: "Command/start/error",
+			name: "Command/send/error",
 			args: args{
-				code:                "start unknown()",
+				code:                "send test(12, 23, unknown)",
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
 			wantDeclaredIdentifiers: mapset.NewSet("test"),
@@ -1524,76 +2597,109 @@ func TestTranslateCommand(test *testing.T) {
 			wantErr:                 assert.Error,
 		},
 		{
-			name: "Command/send/success",
+			name: "Command/publish/success/with the topic/identifier",
 			args: args{
-				code:                "send test(12, 23, 42)",
-				declaredIdentifiers: mapset.NewSet("test"),
+				code:                "publish topic.test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet(),
 			},
-			wantDeclaredIdentifiers: mapset.NewSet("test"),
-			wantCommand: commands.NewSendCommand("test", []expressions.Expression{
-				expressions.NewNumber(12),
-				expressions.NewNumber(23),
-				expressions.NewNumber(42),
-			}),
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand: commands.NewPublishCommand(
+				expressions.NewString("topic"),
+				"test",
+				[]expressions.Expression{
+					expressions.NewNumber(12),
+					expressions.NewNumber(23),
+					expressions.NewNumber(42),
+				},
+			),
 			wantTopLevelSettedState: "",
 			wantSettedStates:        mapset.NewSet(),
 			wantReturn:              assert.False,
 			wantErr:                 assert.NoError,
 		},
 		{
-			name: "Command/send/success/with setted states",
+			name: "Command/publish/success/with the topic/expression",
 			args: args{
-				code: `send test(
+				code:                "publish [topics[0]].test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet("topics"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("topics"),
+			wantCommand: commands.NewPublishCommand(
+				expressions.NewFunctionCall(KeyAccessorFunctionName, []expressions.Expression{
+					expressions.NewIdentifier("topics"),
+					expressions.NewNumber(0),
+				}),
+				"test",
+				[]expressions.Expression{
+					expressions.NewNumber(12),
+					expressions.NewNumber(23),
+					expressions.NewNumber(42),
+				},
+			),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/publish/success/with setted states",
+			args: args{
+				code: `publish [
 					when
 						=> 23
 							set one()
-						=> 42
-							set two()
-					;,
+					;
+				].test(
 					when
 						=> 24
 							set two()
-						=> 43
-							set three()
 					;,
 				)`,
-				declaredIdentifiers: mapset.NewSet("test"),
+				declaredIdentifiers: mapset.NewSet(),
 			},
-			wantDeclaredIdentifiers: mapset.NewSet("test"),
-			wantCommand: commands.NewSendCommand("test", []expressions.Expression{
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand: commands.NewPublishCommand(
 				expressions.NewConditionalExpression([]expressions.ConditionalCase{
 					{
 						Condition: expressions.NewNumber(23),
 						Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
 					},
-					{
-						Condition: expressions.NewNumber(42),
-						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
-					},
-				}),
-				expressions.NewConditionalExpression([]expressions.ConditionalCase{
-					{
-						Condition: expressions.NewNumber(24),
-						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
-					},
-					{
-						Condition: expressions.NewNumber(43),
-						Command:   runtime.CommandGroup{commands.NewSetCommand("three", nil)},
-					},
 				}),
-			}),
+				"test",
+				[]expressions.Expression{
+					expressions.NewConditionalExpression([]expressions.ConditionalCase{
+						{
+							Condition: expressions.NewNumber(24),
+							Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+						},
+					}),
+				},
+			),
 			wantTopLevelSettedState: "",
-			wantSettedStates:        mapset.NewSet("one", "two", "three"),
+			wantSettedStates:        mapset.NewSet("one", "two"),
 			wantReturn:              assert.False,
 			wantErr:                 assert.NoError,
 		},
 		{
-			name: "Command/send/error",
+			name: "Command/publish/error/topic translation",
 			args: args{
-				code:                "send test(12, 23, unknown)",
-				declaredIdentifiers: mapset.NewSet("test"),
+				code:                "publish [unknown].test(12, 23, 42)",
+				declaredIdentifiers: mapset.NewSet(),
 			},
-			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantDeclaredIdentifiers: mapset.NewSet(),
+			wantCommand:             nil,
+			wantTopLevelSettedState: "",
+			wantSettedStates:        nil,
+			wantReturn:              assert.False,
+			wantErr:                 assert.Error,
+		},
+		{
+			name: "Command/publish/error",
+			args: args{
+				code:                "publish topic.test(12, 23, unknown)",
+				declaredIdentifiers: mapset.NewSet(),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet(),
 			wantCommand:             nil,
 			wantTopLevelSettedState: "",
 			wantSettedStates:        nil,
@@ -1677,6 +2783,45 @@ func TestTranslateCommand(test *testing.T) {
 			wantReturn:              assert.False,
 			wantErr:                 assert.Error,
 		},
+		{
+			name: "Command/stop/success",
+			args: args{
+				code:                "stop(test)",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand:             commands.NewStopCommand(expressions.NewIdentifier("test")),
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "Command/stop/error",
+			args: args{
+				code:                "stop(unknown)",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand:             nil,
+			wantTopLevelSettedState: "",
+			wantSettedStates:        nil,
+			wantReturn:              assert.False,
+			wantErr:                 assert.Error,
+		},
+		{
+			name: "Command/defer",
+			args: args{
+				code:                "defer",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand:             commands.DeferCommand{},
+			wantTopLevelSettedState: "",
+			wantSettedStates:        mapset.NewSet(),
+			wantReturn:              assert.False,
+			wantErr:                 assert.NoError,
+		},
 		{
 			name: "Command/return",
 			args: args{
@@ -1748,6 +2893,7 @@ func TestTranslateCommand(test *testing.T) {
 		test.Run(testData.name, func(test *testing.T) {
 			command := new(parser.Command)
 			err := parser.ParseToAST(testData.args.code, command)
+			parser.ClearPositions(command)
 			require.NoError(test, err)
 
 			gotCommand, gotTopLevelSettedState, gotSettedStates, gotReturn, err :=
@@ -1763,6 +2909,68 @@ func TestTranslateCommand(test *testing.T) {
 	}
 }
 
+func TestTranslateCommand_outsideOfActor(test *testing.T) {
+	type args struct {
+		code                string
+		declaredIdentifiers mapset.Set
+	}
+
+	for _, testData := range []struct {
+		name                    string
+		args                    args
+		wantDeclaredIdentifiers mapset.Set
+		wantCommand             runtime.Command
+		wantErr                 assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				code:                "let test2 = test",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test", "test2"),
+			wantCommand:             commands.NewLetCommand("test2", expressions.NewIdentifier("test")),
+			wantErr:                 assert.NoError,
+		},
+		{
+			name: "error/translation",
+			args: args{
+				code:                "unknown",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand:             nil,
+			wantErr:                 assert.Error,
+		},
+		{
+			name: "error/setted states",
+			args: args{
+				code: `when
+					=> test
+						set one()
+				;`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantDeclaredIdentifiers: mapset.NewSet("test"),
+			wantCommand:             nil,
+			wantErr:                 assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			command := new(parser.Command)
+			err := parser.ParseToAST(testData.args.code, command)
+			parser.ClearPositions(command)
+			require.NoError(test, err)
+
+			gotCommand, err := TranslateCommand(command, testData.args.declaredIdentifiers)
+
+			assert.Equal(test, testData.wantDeclaredIdentifiers, testData.args.declaredIdentifiers)
+			assert.Equal(test, testData.wantCommand, gotCommand)
+			testData.wantErr(test, err)
+		})
+	}
+}
+
 func TestTranslateStartCommand(test *testing.T) {
 	type args struct {
 		code                string
@@ -1923,6 +3131,7 @@ func TestTranslateStartCommand(test *testing.T) {
 		test.Run(testData.name, func(test *testing.T) {
 			startCommand := new(parser.StartCommand)
 			err := parser.ParseToAST(testData.args.code, startCommand)
+			parser.ClearPositions(startCommand)
 			require.NoError(test, err)
 
 			gotCommand, gotSettedStates, err :=
@@ -1954,7 +3163,7 @@ func TestTranslateSendCommand(test *testing.T) {
 				code:                "send test(12, 23, 42)",
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
-			wantCommand: commands.NewSendCommand("test", []expressions.Expression{
+			wantCommand: commands.NewSendCommand(nil, "test", []expressions.Expression{
 				expressions.NewNumber(12),
 				expressions.NewNumber(23),
 				expressions.NewNumber(42),
@@ -1981,7 +3190,7 @@ func TestTranslateSendCommand(test *testing.T) {
 				)`,
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
-			wantCommand: commands.NewSendCommand("test", []expressions.Expression{
+			wantCommand: commands.NewSendCommand(nil, "test", []expressions.Expression{
 				expressions.NewConditionalExpression([]expressions.ConditionalCase{
 					{
 						Condition: expressions.NewNumber(23),
@@ -2012,7 +3221,7 @@ func TestTranslateSendCommand(test *testing.T) {
 				code:                "send test()",
 				declaredIdentifiers: mapset.NewSet("test"),
 			},
-			wantCommand:      commands.NewSendCommand("test", nil),
+			wantCommand:      commands.NewSendCommand(nil, "test", nil),
 			wantSettedStates: mapset.NewSet(),
 			wantErr:          assert.NoError,
 		},
@@ -2029,6 +3238,7 @@ func TestTranslateSendCommand(test *testing.T) {
 		test.Run(testData.name, func(test *testing.T) {
 			sendCommand := new(parser.SendCommand)
 			err := parser.ParseToAST(testData.args.code, sendCommand)
+			parser.ClearPositions(sendCommand)
 			require.NoError(test, err)
 
 			gotCommand, gotSettedStates, err :=
@@ -2135,6 +3345,7 @@ func TestTranslateSetCommand(test *testing.T) {
 		test.Run(testData.name, func(test *testing.T) {
 			setCommand := new(parser.SetCommand)
 			err := parser.ParseToAST(testData.args.code, setCommand)
+			parser.ClearPositions(setCommand)
 			require.NoError(test, err)
 
 			gotCommand, gotSettedStates, err :=
@@ -2146,3 +3357,88 @@ func TestTranslateSetCommand(test *testing.T) {
 		})
 	}
 }
+
+func TestTranslateStopCommand(test *testing.T) {
+	type args struct {
+		code                string
+		declaredIdentifiers mapset.Set
+	}
+
+	for _, testData := range []struct {
+		name             string
+		args             args
+		wantCommand      runtime.Command
+		wantSettedStates mapset.Set
+		wantErr          assert.ErrorAssertionFunc
+	}{
+		{
+			name: "StopCommand/success/current actor",
+			args: args{
+				code:                "stop()",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantCommand:      commands.NewStopCommand(nil),
+			wantSettedStates: mapset.NewSet(),
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "StopCommand/success/referenced actor",
+			args: args{
+				code:                "stop(test)",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantCommand:      commands.NewStopCommand(expressions.NewIdentifier("test")),
+			wantSettedStates: mapset.NewSet(),
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "StopCommand/success/referenced actor/with setted states",
+			args: args{
+				code: `stop(when
+					=> 23
+						set one()
+					=> 42
+						set two()
+				;)`,
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantCommand: commands.NewStopCommand(
+				expressions.NewConditionalExpression([]expressions.ConditionalCase{
+					{
+						Condition: expressions.NewNumber(23),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("one", nil)},
+					},
+					{
+						Condition: expressions.NewNumber(42),
+						Command:   runtime.CommandGroup{commands.NewSetCommand("two", nil)},
+					},
+				}),
+			),
+			wantSettedStates: mapset.NewSet("one", "two"),
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "StopCommand/error",
+			args: args{
+				code:                "stop(unknown)",
+				declaredIdentifiers: mapset.NewSet("test"),
+			},
+			wantCommand: nil,
+			wantErr:     assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			stopCommand := new(parser.StopCommand)
+			err := parser.ParseToAST(testData.args.code, stopCommand)
+			parser.ClearPositions(stopCommand)
+			require.NoError(test, err)
+
+			gotCommand, gotSettedStates, err :=
+				translateStopCommand(stopCommand, testData.args.declaredIdentifiers)
+
+			assert.Equal(test, testData.wantCommand, gotCommand)
+			assert.Equal(test, testData.wantSettedStates, gotSettedStates)
+			testData.wantErr(test, err)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package translator
+
+import (
+	mapset "github.com/deckarep/golang-set"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime"
+)
+
+// StateGraph ...
+//
+// It describes states of the actor class in order of their declaration
+// and transitions between them by the set commands.
+type StateGraph struct {
+	Class        string
+	InitialState string
+	States       []string
+	Transitions  []StateTransition
+}
+
+// StateTransition ...
+//
+// The Message field contains the name of the message, whose handler sets
+// the state, or the "enter hook" string for the enter hook.
+// The set commands nested into other commands and expressions
+// (e.g. into the when expression) are considered too.
+type StateTransition struct {
+	From    string
+	To      string
+	Message string
+}
+
+// TranslateStateGraphs ...
+//
+// It translates the program in the same way as TranslateProgram, but returns
+// the state graphs of all actor classes instead, including imported ones.
+func TranslateStateGraphs(
+	program *parser.Program,
+	declaredIdentifiers mapset.Set,
+	options Options,
+) (
+	stateGraphs []StateGraph,
+	err error,
+) {
+	state := &importState{
+		declaredIdentifiers: declaredIdentifiers,
+		importChain:         []string{options.Filename},
+		modules:             make(map[string]module),
+	}
+	translatedModule, err := translateModule(program, options, runtime.Dependencies{}, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return translatedModule.stateGraphs, nil
+}
@@ -0,0 +1,165 @@
+package translator
+
+import (
+	"testing"
+
+	mapset "github.com/deckarep/golang-set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/tick-tock/parser"
+	"github.com/thewizardplusplus/tick-tock/runtime/context"
+)
+
+func TestTranslateStateGraphs(test *testing.T) {
+	type args struct {
+		code    string
+		modules map[string]string
+	}
+
+	for _, testData := range []struct {
+		name            string
+		args            args
+		wantStateGraphs []StateGraph
+		wantErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			args: args{
+				code: `
+					class Counter()
+						state state_0()
+							message inc()
+								set state_1()
+							;
+							message reset()
+								set state_0()
+							;
+							message log();
+						;
+						state state_1()
+							enter
+								when
+									=> test
+										set state_2()
+									=> 1
+										set state_0()
+								;
+							;
+						;
+						state state_2();
+					;
+					func log(text) text;
+					actor Main()
+						state state_0();
+					;
+				`,
+			},
+			wantStateGraphs: []StateGraph{
+				{
+					Class:        "Counter",
+					InitialState: "state_0",
+					States:       []string{"state_0", "state_1", "state_2"},
+					Transitions: []StateTransition{
+						{From: "state_0", To: "state_1", Message: "inc"},
+						{From: "state_0", To: "state_0", Message: "reset"},
+						{From: "state_1", To: "state_0", Message: "enter hook"},
+						{From: "state_1", To: "state_2", Message: "enter hook"},
+					},
+				},
+				{
+					Class:        "Main",
+					InitialState: "state_0",
+					States:       []string{"state_0"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an import",
+			args: args{
+				code: `
+					import "lib.tt"
+					actor Main()
+						state state_0()
+							message test()
+								start Fork()
+							;
+						;
+					;
+				`,
+				modules: map[string]string{
+					"lib.tt": `
+						class Fork()
+							state state_0()
+								enter
+									set state_1()
+								;
+							;
+							state state_1();
+						;
+					`,
+				},
+			},
+			wantStateGraphs: []StateGraph{
+				{
+					Class:        "Fork",
+					InitialState: "state_0",
+					States:       []string{"state_0", "state_1"},
+					Transitions: []StateTransition{
+						{From: "state_0", To: "state_1", Message: "enter hook"},
+					},
+				},
+				{
+					Class:        "Main",
+					InitialState: "state_0",
+					States:       []string{"state_0"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			args: args{
+				code: `
+					actor Main()
+						state state_0()
+							message test()
+								set unknown()
+							;
+						;
+					;
+				`,
+			},
+			wantStateGraphs: nil,
+			wantErr:         assert.Error,
+		},
+	} {
+		test.Run(testData.name, func(test *testing.T) {
+			importer := new(MockImporter)
+			for path, code := range testData.args.modules {
+				program := new(parser.Program)
+				err := parser.ParseToAST(code, program)
+				parser.ClearPositions(program)
+				require.NoError(test, err)
+
+				importer.On("Import", path, "main.tt").Return(path, program, nil)
+			}
+
+			program := new(parser.Program)
+			err := parser.ParseToAST(testData.args.code, program)
+			parser.ClearPositions(program)
+			require.NoError(test, err)
+
+			gotStateGraphs, err := TranslateStateGraphs(program, mapset.NewSet("test"), Options{
+				InitialState: context.State{Name: "state_0"},
+				Filename:     "main.tt",
+				Importer:     importer,
+			})
+
+			mock.AssertExpectationsForObjects(test, importer)
+			assert.Equal(test, testData.wantStateGraphs, gotStateGraphs)
+			testData.wantErr(test, err)
+		})
+	}
+}